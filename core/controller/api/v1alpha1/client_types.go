@@ -35,6 +35,10 @@ type ClientStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 	Credential *corev1.LocalObjectReference `json:"credential,omitempty"`
 	Endpoint   string                       `json:"endpoint,omitempty"`
+	// The groups of the identity the client last authenticated with through the JWT authenticators
+	Groups []string `json:"groups,omitempty"`
+	// The extra claims of the identity the client last authenticated with through the JWT authenticators
+	Claims map[string][]string `json:"claims,omitempty"`
 }
 
 // +kubebuilder:object:root=true
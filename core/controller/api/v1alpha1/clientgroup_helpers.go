@@ -0,0 +1,25 @@
+package v1alpha1
+
+import "slices"
+
+// HasMember returns true if the client is listed by name, or by OIDC username, in the group
+func (g *ClientGroup) HasMember(c *Client) bool {
+	if slices.Contains(g.Spec.Clients, c.Name) {
+		return true
+	}
+	return c.Spec.Username != nil && slices.Contains(g.Spec.Usernames, *c.Spec.Username)
+}
+
+// PolicyWithDefaults returns the policy, with the settings it does not set taken from the group
+func (g *ClientGroup) PolicyWithDefaults(policy Policy) Policy {
+	if policy.Priority == 0 && g.Spec.Priority != nil {
+		policy.Priority = *g.Spec.Priority
+	}
+	if policy.MaximumDuration == nil {
+		policy.MaximumDuration = g.Spec.MaximumDuration
+	}
+	if policy.Quota == nil {
+		policy.Quota = g.Spec.Quota
+	}
+	return policy
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClientGroupSpec defines the desired state of ClientGroup.
+type ClientGroupSpec struct {
+	// The names of the clients of the namespace that are members of the group
+	Clients []string `json:"clients,omitempty"`
+	// The OIDC usernames of the clients that are members of the group, as set on the clients
+	Usernames []string `json:"usernames,omitempty"`
+	// The priority of the policies referencing the group that do not set one
+	Priority *int `json:"priority,omitempty"`
+	// The maximum duration of the policies referencing the group that do not set one
+	MaximumDuration *metav1.Duration `json:"maximumDuration,omitempty"`
+	// The quota of the policies referencing the group that do not set one
+	Quota *Quota `json:"quota,omitempty"`
+}
+
+// ClientGroupStatus defines the observed state of ClientGroup.
+type ClientGroupStatus struct {
+	// The names of the clients currently matched as members of the group
+	Members []string `json:"members,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".spec.priority",name=Priority,type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.members",name=Members,type=string
+
+// ClientGroup is the Schema for the clientgroups API, a team of clients whose
+// access is granted by referencing the group from exporter access policies.
+type ClientGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClientGroupSpec   `json:"spec,omitempty"`
+	Status ClientGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClientGroupList contains a list of ClientGroup.
+type ClientGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClientGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClientGroup{}, &ClientGroupList{})
+}
@@ -1,11 +1,17 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	cpb "github.com/the78mole/jumpstarter-mono/core/controller/internal/protocol/jumpstarter/client/v1"
 	"github.com/the78mole/jumpstarter-mono/core/controller/internal/service/utils"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -23,6 +29,95 @@ func (e *Exporter) Usernames(prefix string) []string {
 	return usernames
 }
 
+// UnderMaintenance returns true if the exporter has been taken out of rotation
+func (e *Exporter) UnderMaintenance() bool {
+	return e.Spec.Maintenance != nil
+}
+
+// MaintenanceMessage describes why the exporter is under maintenance
+func (e *Exporter) MaintenanceMessage() string {
+	if e.Spec.Maintenance == nil {
+		return ""
+	}
+	message := fmt.Sprintf("exporter %s is under maintenance", e.Name)
+	if e.Spec.Maintenance.Owner != "" {
+		message += " by " + e.Spec.Maintenance.Owner
+	}
+	if e.Spec.Maintenance.Reason != "" {
+		message += ": " + e.Spec.Maintenance.Reason
+	}
+	return message
+}
+
+const (
+	// DefaultCleanupTimeout is how long exporters have to clean up after a lease when their
+	// cleanup does not set a timeout
+	DefaultCleanupTimeout = 5 * time.Minute
+	// CleanupMaintenanceOwner owns the maintenance of the exporters that failed to clean up
+	CleanupMaintenanceOwner = "jumpstarter-controller"
+)
+
+// IsCleaning returns true if the exporter has been asked to clean up after a lease, and has
+// not reported the cleanup done yet
+func (e *Exporter) IsCleaning() bool {
+	return e.Status.Cleanup != nil
+}
+
+// CleanupTimeout returns how long the exporter has to clean up after a lease
+func (e *Exporter) CleanupTimeout() time.Duration {
+	if e.Spec.Cleanup == nil || e.Spec.Cleanup.Timeout == nil {
+		return DefaultCleanupTimeout
+	}
+	return e.Spec.Cleanup.Timeout.Duration
+}
+
+// CleanupDeadline returns the time the exporter is expected to be done cleaning up by, or the
+// zero time if it is not cleaning
+func (e *Exporter) CleanupDeadline() time.Time {
+	if e.Status.Cleanup == nil {
+		return time.Time{}
+	}
+	return e.Status.Cleanup.RequestedAt.Add(e.CleanupTimeout())
+}
+
+// RequestCleanup asks the exporter to clean up after the lease, if its spec requires it,
+// and returns true if a new cleanup has been requested
+func (e *Exporter) RequestCleanup(lease string, now time.Time) bool {
+	if e.Spec.Cleanup == nil || (e.Status.Cleanup != nil && e.Status.Cleanup.LeaseRef.Name == lease) {
+		return false
+	}
+	e.Status.Cleanup = &ExporterCleanupStatus{
+		LeaseRef:    corev1.LocalObjectReference{Name: lease},
+		RequestedAt: metav1.NewTime(now),
+	}
+	e.Status.Phase = ExporterPhaseCleaning
+	return true
+}
+
+// FailCleanup puts the exporter under maintenance, unless it already is, as it could not be
+// reset after its last lease
+func (e *Exporter) FailCleanup(reason string) {
+	if e.Spec.Maintenance == nil {
+		e.Spec.Maintenance = &ExporterMaintenance{
+			Reason: reason,
+			Owner:  CleanupMaintenanceOwner,
+		}
+	}
+}
+
+// HasDevicesMatching returns true if each of the selectors is matched by the labels
+// of at least one of the devices reported by the exporter
+func (e *Exporter) HasDevicesMatching(selectors []labels.Selector) bool {
+	for _, selector := range selectors {
+		if !slices.ContainsFunc(e.Status.Devices, func(device Device) bool {
+			return selector.Matches(labels.Set(device.Labels))
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
 func (e *Exporter) ToProtobuf() *cpb.Exporter {
 	// get online status from conditions
 	isOnline := meta.IsStatusConditionTrue(e.Status.Conditions, string(ExporterConditionTypeOnline))
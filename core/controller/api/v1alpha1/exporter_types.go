@@ -27,6 +27,29 @@ import (
 // ExporterSpec defines the desired state of Exporter
 type ExporterSpec struct {
 	Username *string `json:"username,omitempty"`
+	// Takes the exporter out of rotation, no new leases are assigned to it
+	Maintenance *ExporterMaintenance `json:"maintenance,omitempty"`
+	// Has the exporter reset its devices after each lease, it is not assigned to another
+	// lease until it reports the cleanup done. Preempted spot leases hand the exporter over
+	// right away, without a cleanup.
+	Cleanup *ExporterCleanup `json:"cleanup,omitempty"`
+}
+
+// ExporterMaintenance describes why, and by whom, an exporter has been taken out of rotation
+type ExporterMaintenance struct {
+	// Why the exporter is under maintenance, shown on the leases it refuses
+	Reason string `json:"reason,omitempty"`
+	// Who put the exporter under maintenance
+	Owner string `json:"owner,omitempty"`
+	// Let the active leases of the exporter finish instead of ending them right away
+	Drain bool `json:"drain,omitempty"`
+}
+
+// ExporterCleanup configures the reset of the exporter between leases
+type ExporterCleanup struct {
+	// How long the exporter has to report the cleanup done before it is put under
+	// maintenance, five minutes by default
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // ExporterStatus defines the observed state of Exporter
@@ -39,17 +62,43 @@ type ExporterStatus struct {
 	LeaseRef   *corev1.LocalObjectReference `json:"leaseRef,omitempty"`
 	LastSeen   metav1.Time                  `json:"lastSeen,omitempty"`
 	Endpoint   string                       `json:"endpoint,omitempty"`
+	// Whether the exporter is available, leased, or cleaning up after its last lease
+	Phase ExporterPhase `json:"phase,omitempty"`
+	// The cleanup the exporter has been asked to perform, and has not reported done yet
+	Cleanup *ExporterCleanupStatus `json:"cleanup,omitempty"`
+}
+
+// ExporterCleanupStatus describes a pending cleanup of the exporter
+type ExporterCleanupStatus struct {
+	// The lease after which the cleanup has been requested
+	LeaseRef corev1.LocalObjectReference `json:"leaseRef"`
+	// When the cleanup has been requested
+	RequestedAt metav1.Time `json:"requestedAt"`
 }
 
+// +kubebuilder:validation:Enum=Available;Leased;Cleaning
+type ExporterPhase string
+
+const (
+	ExporterPhaseAvailable ExporterPhase = "Available"
+	ExporterPhaseLeased    ExporterPhase = "Leased"
+	ExporterPhaseCleaning  ExporterPhase = "Cleaning"
+)
+
 type ExporterConditionType string
 
 const (
-	ExporterConditionTypeRegistered ExporterConditionType = "Registered"
-	ExporterConditionTypeOnline     ExporterConditionType = "Online"
+	ExporterConditionTypeRegistered  ExporterConditionType = "Registered"
+	ExporterConditionTypeOnline      ExporterConditionType = "Online"
+	ExporterConditionTypeMaintenance ExporterConditionType = "Maintenance"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type==\"Online\")].status",name=Online,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type==\"Maintenance\")].status",name=Maintenance,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=Phase,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.leaseRef.name",name=Lease,type=string
 
 // Exporter is the Schema for the exporters API
 type Exporter struct {
@@ -0,0 +1,198 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DefaultQuotaPeriod is the rolling period quotas account the leased time over when unset
+const DefaultQuotaPeriod = 24 * time.Hour
+
+// Matches returns true if the client is matched by the client selector, along with the groups
+// and claims the client last authenticated with, and is a member of the referenced client group,
+// given as group, nil if it does not exist
+func (f *From) Matches(c *Client, group *ClientGroup) (bool, error) {
+	if f.ClientGroupRef != nil && (group == nil || !group.HasMember(c)) {
+		return false, nil
+	}
+	clientSelector, err := metav1.LabelSelectorAsSelector(&f.ClientSelector)
+	if err != nil {
+		return false, err
+	}
+	if !clientSelector.Matches(labels.Set(c.Labels)) {
+		return false, nil
+	}
+	if len(f.Groups) > 0 && !containsAny(c.Status.Groups, f.Groups) {
+		return false, nil
+	}
+	for _, requirement := range f.Claims {
+		values, ok := c.Status.Claims[requirement.Claim]
+		if !ok {
+			return false, nil
+		}
+		if len(requirement.Values) > 0 && !containsAny(values, requirement.Values) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ClientGroupName returns the name of the referenced client group, empty if none
+func (f *From) ClientGroupName() string {
+	if f.ClientGroupRef == nil {
+		return ""
+	}
+	return f.ClientGroupRef.Name
+}
+
+// containsAny returns true if any of the values is in the slice
+func containsAny(s []string, values []string) bool {
+	return slices.ContainsFunc(values, func(value string) bool {
+		return slices.Contains(s, value)
+	})
+}
+
+func (q *Quota) GetScope() QuotaScope {
+	if q.Scope == "" {
+		return QuotaScopeClient
+	}
+	return q.Scope
+}
+
+func (q *Quota) GetPeriod() time.Duration {
+	if q.Period == nil || q.Period.Duration <= 0 {
+		return DefaultQuotaPeriod
+	}
+	return q.Period.Duration
+}
+
+// Location returns the time zone the windows are expressed in
+func (tw *TimeWindows) Location() (*time.Location, error) {
+	if tw.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(tw.TimeZone)
+}
+
+// AllowedAt returns true if the policy applies at the given time
+func (tw *TimeWindows) AllowedAt(t time.Time) (bool, error) {
+	loc, err := tw.Location()
+	if err != nil {
+		return false, err
+	}
+
+	allowed := len(tw.Allow) == 0
+	for _, window := range tw.Allow {
+		contains, err := window.contains(t, loc)
+		if err != nil {
+			return false, err
+		}
+		allowed = allowed || contains
+	}
+	for _, window := range tw.Deny {
+		contains, err := window.contains(t, loc)
+		if err != nil {
+			return false, err
+		}
+		allowed = allowed && !contains
+	}
+	return allowed, nil
+}
+
+// AllowedUntil returns the first time within [begin, end) the policy does not apply at,
+// or end if the policy applies during the whole window
+func (tw *TimeWindows) AllowedUntil(begin, end time.Time) (time.Time, error) {
+	loc, err := tw.Location()
+	if err != nil {
+		return end, err
+	}
+
+	// whether the policy applies only changes at the boundaries of the windows
+	instants := []time.Time{begin}
+	for day := dayOf(begin.In(loc)).AddDate(0, 0, -1); day.Before(end); day = day.AddDate(0, 0, 1) {
+		for _, window := range slices.Concat(tw.Allow, tw.Deny) {
+			start, stop, err := window.on(day)
+			if err != nil {
+				return end, err
+			}
+			for _, instant := range []time.Time{start, stop} {
+				if instant.After(begin) && instant.Before(end) {
+					instants = append(instants, instant)
+				}
+			}
+		}
+	}
+	slices.SortFunc(instants, func(a, b time.Time) int { return a.Compare(b) })
+
+	for _, instant := range instants {
+		allowed, err := tw.AllowedAt(instant)
+		if err != nil {
+			return end, err
+		}
+		if !allowed {
+			return instant, nil
+		}
+	}
+	return end, nil
+}
+
+// contains returns true if the time falls within an occurrence of the window
+func (w *TimeWindow) contains(t time.Time, loc *time.Location) (bool, error) {
+	today := dayOf(t.In(loc))
+	// windows running past midnight may have started the day before
+	for _, day := range []time.Time{today.AddDate(0, 0, -1), today} {
+		start, stop, err := w.on(day)
+		if err != nil {
+			return false, err
+		}
+		if !start.IsZero() && !t.Before(start) && t.Before(stop) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// on returns the occurrence of the window starting on the given day, or zero times if
+// the window does not occur on that day
+func (w *TimeWindow) on(day time.Time) (time.Time, time.Time, error) {
+	if len(w.Days) > 0 && !slices.Contains(w.Days, Weekday(day.Weekday().String())) {
+		return time.Time{}, time.Time{}, nil
+	}
+
+	startHour, startMinute, err := parseTimeOfDay(w.Start, 0)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	endHour, endMinute, err := parseTimeOfDay(w.End, 24)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), startHour, startMinute, 0, 0, day.Location())
+	stop := time.Date(day.Year(), day.Month(), day.Day(), endHour, endMinute, 0, 0, day.Location())
+	if !stop.After(start) {
+		stop = time.Date(day.Year(), day.Month(), day.Day()+1, endHour, endMinute, 0, 0, day.Location())
+	}
+	return start, stop, nil
+}
+
+// dayOf returns the beginning of the day of the given time, in its location
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// parseTimeOfDay parses a HH:MM time of day, returning the given hour when empty
+func parseTimeOfDay(value string, hour int) (int, int, error) {
+	if value == "" {
+		return hour, 0, nil
+	}
+	var minute int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hour, &minute); err != nil {
+		return 0, 0, fmt.Errorf("invalid time of day %q: %w", value, err)
+	}
+	return hour, minute, nil
+}
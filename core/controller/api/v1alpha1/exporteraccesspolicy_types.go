@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,13 +26,89 @@ import (
 
 type From struct {
 	ClientSelector metav1.LabelSelector `json:"clientSelector,omitempty"`
+	// Only matches the clients last authenticated with any of the groups, as mapped by the
+	// groups claim mappings of the JWT authenticators
+	Groups []string `json:"groups,omitempty"`
+	// Only matches the clients last authenticated with each of the claims, as mapped by the
+	// extra claim mappings of the JWT authenticators
+	Claims []ClaimRequirement `json:"claims,omitempty"`
+	// Only matches the members of the client group, the defaults of the first client group
+	// referenced by the policy apply to the settings the policy does not set
+	ClientGroupRef *corev1.LocalObjectReference `json:"clientGroupRef,omitempty"`
+}
+
+// ClaimRequirement matches the clients authenticated with a claim holding one of the values
+type ClaimRequirement struct {
+	// The key of the claim, as configured in the extra claim mappings
+	Claim string `json:"claim"`
+	// The values the claim may hold, any value when empty
+	Values []string `json:"values,omitempty"`
 }
 
 type Policy struct {
 	Priority        int              `json:"priority,omitempty"`
 	From            []From           `json:"from,omitempty"`
 	MaximumDuration *metav1.Duration `json:"maximumDuration,omitempty"`
+	// The duration of the leases of the clients matched by the policy that do not request one,
+	// set by the lease defaulting webhook
+	DefaultDuration *metav1.Duration `json:"defaultDuration,omitempty"`
 	SpotAccess      bool             `json:"spotAccess,omitempty"`
+	// Limits the leases the clients matched by the policy can hold on the exporters of the policy
+	Quota *Quota `json:"quota,omitempty"`
+	// Restricts the policy to recurring time windows, the policy applies at all times when unset
+	TimeWindows *TimeWindows `json:"timeWindows,omitempty"`
+	// Releases the leases approved by the policy once their exporters have not been connected
+	// to for this long, the client is warned before the lease is released
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+}
+
+type TimeWindows struct {
+	// The IANA time zone the windows are expressed in, UTC by default
+	TimeZone string `json:"timeZone,omitempty"`
+	// The windows the policy applies during, at all times when empty
+	Allow []TimeWindow `json:"allow,omitempty"`
+	// The windows the policy does not apply during, taking precedence over the allowed windows
+	Deny []TimeWindow `json:"deny,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
+
+// TimeWindow is a range of hours recurring on some days of the week, a window ending
+// before it starts runs past midnight into the next day
+type TimeWindow struct {
+	// The days of the week the window starts on, every day when empty
+	Days []Weekday `json:"days,omitempty"`
+	// The time of day the window starts at, as HH:MM, midnight when empty
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start,omitempty"`
+	// The time of day the window ends at, as HH:MM, the end of the day when empty
+	// +kubebuilder:validation:Pattern=`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`
+	End string `json:"end,omitempty"`
+}
+
+// QuotaScope defines who a quota is accounted for
+type QuotaScope string
+
+const (
+	// QuotaScopeClient accounts the quota separately for each client matched by the policy
+	QuotaScopeClient QuotaScope = "Client"
+	// QuotaScopeGroup accounts the quota once for all the clients matched by the policy together
+	QuotaScopeGroup QuotaScope = "Group"
+)
+
+type Quota struct {
+	// Whether the quota applies to each client, or to all the clients matched by the policy together
+	// +kubebuilder:validation:Enum=Client;Group
+	// +kubebuilder:default=Client
+	Scope QuotaScope `json:"scope,omitempty"`
+	// The maximum number of leases held at the same time
+	// +kubebuilder:validation:Minimum=0
+	MaximumLeases *int `json:"maximumLeases,omitempty"`
+	// The maximum time leases can be held for within the rolling period
+	MaximumLeasedTime *metav1.Duration `json:"maximumLeasedTime,omitempty"`
+	// The rolling period the leased time is accounted over, one day by default
+	Period *metav1.Duration `json:"period,omitempty"`
 }
 
 // ExporterAccessPolicySpec defines the desired state of ExporterAccessPolicy.
@@ -40,14 +117,70 @@ type ExporterAccessPolicySpec struct {
 	Policies         []Policy             `json:"policies,omitempty"`
 }
 
+// QuotaUsage is the current usage of the quota of a policy
+type QuotaUsage struct {
+	// The index of the policy the quota belongs to
+	Policy int `json:"policy"`
+	// The client the usage is accounted for, empty for quotas with the Group scope
+	Client string `json:"client,omitempty"`
+	// The number of leases currently held
+	Leases int `json:"leases"`
+	// The time leases have been held for within the rolling period of the quota
+	LeasedTime metav1.Duration `json:"leasedTime"`
+}
+
+// PolicyMatches are the clients an entry of the policies currently matches
+type PolicyMatches struct {
+	// The index of the policy
+	Policy int `json:"policy"`
+	// The clients matched by any of the subjects of the policy
+	Clients []string `json:"clients,omitempty"`
+}
+
+// PolicyConflict are the exporters and clients matched both by an entry of the policies and by
+// another entry of the policies, or of another access policy, at a different priority
+type PolicyConflict struct {
+	// The index of the policy
+	Policy int `json:"policy"`
+	// The priority of the policy
+	Priority int `json:"priority"`
+	// The name of the other access policy, or of the access policy itself
+	ConflictingPolicy string `json:"conflictingPolicy"`
+	// The index of the entry of the other access policy
+	ConflictingIndex int `json:"conflictingIndex"`
+	// The priority of the entry of the other access policy
+	ConflictingPriority int `json:"conflictingPriority"`
+	// The exporters matched by both
+	Exporters []string `json:"exporters"`
+	// The clients matched by both
+	Clients []string `json:"clients"`
+}
+
 // ExporterAccessPolicyStatus defines the observed state of ExporterAccessPolicy.
 type ExporterAccessPolicyStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// The usage of the quotas of the policies, for the clients currently using them
+	QuotaUsage []QuotaUsage `json:"quotaUsage,omitempty"`
+	// The exporters currently matched by the exporter selector
+	Exporters []string `json:"exporters,omitempty"`
+	// The clients currently matched by each of the policies
+	Matches []PolicyMatches `json:"matches,omitempty"`
+	// The policies matching the same exporters and clients as other access policies, at different priorities
+	Conflicts []PolicyConflict `json:"conflicts,omitempty"`
+	// The selectors and subjects matching nothing, as paths within the spec, e.g. policies[0].from[1]
+	UnmatchedSelectors []string           `json:"unmatchedSelectors,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+type ExporterAccessPolicyConditionType string
+
+const (
+	ExporterAccessPolicyConditionTypeReady ExporterAccessPolicyConditionType = "Ready"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type==\"Ready\")].status",name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type==\"Ready\")].message",name=Message,type=string,priority=1
 
 // ExporterAccessPolicy is the Schema for the exporteraccesspolicies API.
 type ExporterAccessPolicy struct {
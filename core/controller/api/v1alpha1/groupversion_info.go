@@ -34,3 +34,7 @@ var (
 	// AddToScheme adds the types in this group-version to the given scheme.
 	AddToScheme = SchemeBuilder.AddToScheme
 )
+
+// ReservedLabelPrefix is the prefix of the labels managed by the controller, and by the exporters
+// when they register through it
+const ReservedLabelPrefix = "jumpstarter.dev/"
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	cpb "github.com/the78mole/jumpstarter-mono/core/controller/internal/protocol/jumpstarter/client/v1"
@@ -30,19 +31,202 @@ func LeaseFromProtobuf(
 		return nil, err
 	}
 
+	deviceSelectors, err := deviceSelectorsFromProtobuf(req.DeviceSelectors)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, beginTime, endTime, err := leaseWindowFromProtobuf(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var members []LeaseMember
+	for _, member := range req.Members {
+		memberSelector, err := metav1.ParseToLabelSelector(member.Selector)
+		if err != nil {
+			return nil, err
+		}
+		memberDeviceSelectors, err := deviceSelectorsFromProtobuf(member.DeviceSelectors)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, LeaseMember{
+			Name:            member.Name,
+			Selector:        *memberSelector,
+			DeviceSelectors: memberDeviceSelectors,
+			Count:           int(member.Count),
+		})
+	}
+
+	affinity, err := leaseAffinityFromProtobuf(req.Affinity, key.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	antiAffinity, err := leaseAffinityFromProtobuf(req.AntiAffinity, key.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var exporterLossGracePeriod *metav1.Duration
+	if req.ExporterLossGracePeriod != nil {
+		exporterLossGracePeriod = &metav1.Duration{Duration: req.ExporterLossGracePeriod.AsDuration()}
+	}
+
 	return &Lease{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: key.Namespace,
 			Name:      key.Name,
 		},
 		Spec: LeaseSpec{
-			ClientRef: clientRef,
-			Duration:  metav1.Duration{Duration: req.Duration.AsDuration()},
-			Selector:  *selector,
+			ClientRef:               clientRef,
+			Duration:                metav1.Duration{Duration: duration},
+			Selector:                *selector,
+			DeviceSelectors:         deviceSelectors,
+			BeginTime:               beginTime,
+			EndTime:                 endTime,
+			ClampDuration:           req.ClampDuration,
+			Members:                 members,
+			ExporterLossAction:      exporterLossActionFromProtobuf(req.ExporterLossAction),
+			ExporterLossGracePeriod: exporterLossGracePeriod,
+			Affinity:                affinity,
+			AntiAffinity:            antiAffinity,
 		},
 	}, nil
 }
 
+func deviceSelectorsFromProtobuf(selectors []string) ([]metav1.LabelSelector, error) {
+	var deviceSelectors []metav1.LabelSelector
+	for _, selector := range selectors {
+		deviceSelector, err := metav1.ParseToLabelSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+		deviceSelectors = append(deviceSelectors, *deviceSelector)
+	}
+	return deviceSelectors, nil
+}
+
+func deviceSelectorsToProtobuf(selectors []metav1.LabelSelector) []string {
+	var deviceSelectors []string
+	for _, selector := range selectors {
+		deviceSelectors = append(deviceSelectors, metav1.FormatLabelSelector(&selector))
+	}
+	return deviceSelectors
+}
+
+// leaseAffinityFromProtobuf converts affinity terms referencing leases by their resource name,
+// the leases must belong to the namespace of the lease
+func leaseAffinityFromProtobuf(terms []*cpb.LeaseAffinityTerm, namespace string) ([]LeaseAffinityTerm, error) {
+	var affinity []LeaseAffinityTerm
+	for _, term := range terms {
+		var leases []string
+		for _, identifier := range term.Leases {
+			key, err := utils.ParseLeaseIdentifier(identifier)
+			if err != nil {
+				return nil, err
+			}
+			if key.Namespace != namespace {
+				return nil, fmt.Errorf("affinity terms can only reference leases in namespace %s, got %s", namespace, identifier)
+			}
+			leases = append(leases, key.Name)
+		}
+		affinity = append(affinity, LeaseAffinityTerm{
+			Leases:      leases,
+			TopologyKey: term.TopologyKey,
+		})
+	}
+	return affinity, nil
+}
+
+func leaseAffinityToProtobuf(terms []LeaseAffinityTerm, namespace string) []*cpb.LeaseAffinityTerm {
+	var affinity []*cpb.LeaseAffinityTerm
+	for _, term := range terms {
+		pbTerm := &cpb.LeaseAffinityTerm{TopologyKey: term.TopologyKey}
+		for _, name := range term.Leases {
+			pbTerm.Leases = append(pbTerm.Leases, utils.UnparseLeaseIdentifier(kclient.ObjectKey{
+				Namespace: namespace,
+				Name:      name,
+			}))
+		}
+		affinity = append(affinity, pbTerm)
+	}
+	return affinity
+}
+
+func exporterLossActionFromProtobuf(action cpb.ExporterLossAction) ExporterLossAction {
+	switch action {
+	case cpb.ExporterLossAction_EXPORTER_LOSS_ACTION_KEEP:
+		return ExporterLossActionKeep
+	case cpb.ExporterLossAction_EXPORTER_LOSS_ACTION_END:
+		return ExporterLossActionEnd
+	case cpb.ExporterLossAction_EXPORTER_LOSS_ACTION_REACQUIRE:
+		return ExporterLossActionReacquire
+	default:
+		return ""
+	}
+}
+
+func exporterLossActionToProtobuf(action ExporterLossAction) cpb.ExporterLossAction {
+	switch action {
+	case ExporterLossActionKeep:
+		return cpb.ExporterLossAction_EXPORTER_LOSS_ACTION_KEEP
+	case ExporterLossActionEnd:
+		return cpb.ExporterLossAction_EXPORTER_LOSS_ACTION_END
+	case ExporterLossActionReacquire:
+		return cpb.ExporterLossAction_EXPORTER_LOSS_ACTION_REACQUIRE
+	default:
+		return cpb.ExporterLossAction_EXPORTER_LOSS_ACTION_UNSPECIFIED
+	}
+}
+
+// leaseWindowFromProtobuf normalizes the requested duration, begin and end time of a lease,
+// any two of them are enough to describe a scheduled lease, the missing one is derived
+func leaseWindowFromProtobuf(req *cpb.Lease, now time.Time) (time.Duration, *metav1.Time, *metav1.Time, error) {
+	duration := req.Duration.AsDuration()
+
+	if req.BeginTime == nil && req.EndTime == nil {
+		return duration, nil, nil, nil
+	}
+
+	var begin, end time.Time
+	switch {
+	case req.BeginTime != nil && req.EndTime != nil:
+		begin, end = req.BeginTime.AsTime(), req.EndTime.AsTime()
+		if !end.After(begin) {
+			return 0, nil, nil, fmt.Errorf("end_time must be after begin_time")
+		}
+		if duration == 0 {
+			duration = end.Sub(begin)
+		} else if duration != end.Sub(begin) {
+			return 0, nil, nil, fmt.Errorf("duration does not match the difference between begin_time and end_time")
+		}
+	case req.BeginTime != nil:
+		if duration <= 0 {
+			return 0, nil, nil, fmt.Errorf("duration is required when only begin_time is set")
+		}
+		begin = req.BeginTime.AsTime()
+		end = begin.Add(duration)
+	default:
+		if duration <= 0 {
+			return 0, nil, nil, fmt.Errorf("duration is required when only end_time is set")
+		}
+		end = req.EndTime.AsTime()
+		begin = end.Add(-duration)
+	}
+
+	// the lease would otherwise be granted less than the requested duration
+	if begin.Before(now) {
+		if req.BeginTime == nil {
+			return 0, nil, nil, fmt.Errorf("duration is longer than the time left until end_time, "+
+				"the derived begin_time %s is in the past", begin.Format(time.RFC3339))
+		}
+		return 0, nil, nil, fmt.Errorf("begin_time %s is in the past", begin.Format(time.RFC3339))
+	}
+
+	return duration, &metav1.Time{Time: begin}, &metav1.Time{Time: end}, nil
+}
+
 func (l *Lease) ToProtobuf() *cpb.Lease {
 	var conditions []*pb.Condition
 	for _, condition := range l.Status.Conditions {
@@ -60,22 +244,51 @@ func (l *Lease) ToProtobuf() *cpb.Lease {
 	}
 
 	lease := cpb.Lease{
-		Name:              fmt.Sprintf("namespaces/%s/leases/%s", l.Namespace, l.Name),
-		Selector:          metav1.FormatLabelSelector(&l.Spec.Selector),
-		Duration:          durationpb.New(l.Spec.Duration.Duration),
-		EffectiveDuration: durationpb.New(l.Spec.Duration.Duration), // TODO: implement lease renewal
-		Client:            ptr.To(fmt.Sprintf("namespaces/%s/clients/%s", l.Namespace, l.Spec.ClientRef.Name)),
-		Conditions:        conditions,
-		// TODO: implement scheduled leases
-		BeginTime: nil,
-		EndTime:   nil,
+		Name:               fmt.Sprintf("namespaces/%s/leases/%s", l.Namespace, l.Name),
+		Selector:           metav1.FormatLabelSelector(&l.Spec.Selector),
+		DeviceSelectors:    deviceSelectorsToProtobuf(l.Spec.DeviceSelectors),
+		Duration:           durationpb.New(l.Spec.Duration.Duration),
+		EffectiveDuration:  durationpb.New(l.Spec.Duration.Duration),
+		Client:             ptr.To(fmt.Sprintf("namespaces/%s/clients/%s", l.Namespace, l.Spec.ClientRef.Name)),
+		Conditions:         conditions,
+		ClampDuration:      l.Spec.ClampDuration,
+		ExporterLossAction: exporterLossActionToProtobuf(l.Spec.ExporterLossAction),
+		Affinity:           leaseAffinityToProtobuf(l.Spec.Affinity, l.Namespace),
+		AntiAffinity:       leaseAffinityToProtobuf(l.Spec.AntiAffinity, l.Namespace),
+	}
+
+	if l.Spec.ExporterLossGracePeriod != nil {
+		lease.ExporterLossGracePeriod = durationpb.New(l.Spec.ExporterLossGracePeriod.Duration)
+	}
+
+	if l.Spec.BeginTime != nil {
+		lease.BeginTime = timestamppb.New(l.Spec.BeginTime.Time)
+	}
+	if l.Spec.EndTime != nil {
+		lease.EndTime = timestamppb.New(l.Spec.EndTime.Time)
 	}
 
 	if l.Status.BeginTime != nil {
 		lease.EffectiveBeginTime = timestamppb.New(l.Status.BeginTime.Time)
 	}
+	// acquired leases report the time they have actually been granted, including extensions,
+	// and the time they have ended at, or are going to end at
 	if l.Status.EndTime != nil {
 		lease.EffectiveEndTime = timestamppb.New(l.Status.EndTime.Time)
+	} else if expiration := l.GetExpirationTime(); expiration != nil {
+		lease.EffectiveEndTime = timestamppb.New(*expiration)
+	}
+	if l.Status.BeginTime != nil && lease.EffectiveEndTime != nil {
+		lease.EffectiveDuration = durationpb.New(lease.EffectiveEndTime.AsTime().Sub(l.Status.BeginTime.Time))
+	}
+	if l.Status.QueuePosition > 0 {
+		lease.QueuePosition = ptr.To(int32(l.Status.QueuePosition))
+	}
+	if l.Status.EstimatedBeginTime != nil {
+		lease.EstimatedBeginTime = timestamppb.New(l.Status.EstimatedBeginTime.Time)
+	}
+	if l.Status.LastActivityTime != nil {
+		lease.LastActivityTime = timestamppb.New(l.Status.LastActivityTime.Time)
 	}
 	if l.Status.ExporterRef != nil {
 		lease.Exporter = ptr.To(utils.UnparseExporterIdentifier(kclient.ObjectKey{
@@ -84,6 +297,24 @@ func (l *Lease) ToProtobuf() *cpb.Lease {
 		}))
 	}
 
+	for _, member := range l.Spec.Members {
+		pbMember := &cpb.LeaseMember{
+			Name:            member.Name,
+			Selector:        metav1.FormatLabelSelector(&member.Selector),
+			DeviceSelectors: deviceSelectorsToProtobuf(member.DeviceSelectors),
+			Count:           int32(member.GetCount()),
+		}
+		for _, assigned := range l.Status.Members {
+			if assigned.Member == member.Name {
+				pbMember.Exporters = append(pbMember.Exporters, utils.UnparseExporterIdentifier(kclient.ObjectKey{
+					Namespace: l.Namespace,
+					Name:      assigned.ExporterRef.Name,
+				}))
+			}
+		}
+		lease.Members = append(lease.Members, pbMember)
+	}
+
 	return &lease
 }
 
@@ -118,6 +349,52 @@ func (l *Lease) SetStatusInvalid(reason, messageFormat string, a ...any) {
 	l.SetStatusCondition(LeaseConditionTypeInvalid, true, reason, messageFormat, a...)
 }
 
+func (l *Lease) SetStatusQuotaExceeded(status bool, reason, messageFormat string, a ...any) {
+	l.SetStatusCondition(LeaseConditionTypeQuotaExceeded, status, reason, messageFormat, a...)
+}
+
+func (l *Lease) SetStatusDegraded(status bool, reason, messageFormat string, a ...any) {
+	l.SetStatusCondition(LeaseConditionTypeDegraded, status, reason, messageFormat, a...)
+}
+
+func (l *Lease) SetStatusIdle(status bool, reason, messageFormat string, a ...any) {
+	l.SetStatusCondition(LeaseConditionTypeIdle, status, reason, messageFormat, a...)
+}
+
+// GetIdleSince returns the time the lease has been idle since, which is the last time its
+// exporters were connected to, or the time the lease began if they never were
+func (l *Lease) GetIdleSince() *time.Time {
+	if l.Status.BeginTime == nil {
+		return nil
+	}
+	since := l.Status.BeginTime.Time
+	if l.Status.LastActivityTime != nil && l.Status.LastActivityTime.After(since) {
+		since = l.Status.LastActivityTime.Time
+	}
+	return &since
+}
+
+// DefaultExporterLossGracePeriod is how long an exporter held by a lease can be offline
+// before it is considered lost, unless the lease requests otherwise
+const DefaultExporterLossGracePeriod = time.Minute
+
+// GetExporterLossGracePeriod returns how long an exporter held by the lease can be
+// offline before it is considered lost
+func (l *Lease) GetExporterLossGracePeriod() time.Duration {
+	if l.Spec.ExporterLossGracePeriod == nil {
+		return DefaultExporterLossGracePeriod
+	}
+	return l.Spec.ExporterLossGracePeriod.Duration
+}
+
+// GetExporterLossAction returns what to do once an exporter held by the lease is lost
+func (l *Lease) GetExporterLossAction() ExporterLossAction {
+	if l.Spec.ExporterLossAction == "" {
+		return ExporterLossActionKeep
+	}
+	return l.Spec.ExporterLossAction
+}
+
 func (l *Lease) SetStatusCondition(
 	condition LeaseConditionType,
 	status bool,
@@ -143,6 +420,82 @@ func (l *Lease) SetStatusCondition(
 	})
 }
 
+// IsScheduled returns true if the lease has been requested for a specific time window
+func (l *Lease) IsScheduled() bool {
+	return l.Spec.BeginTime != nil && l.Spec.EndTime != nil
+}
+
+// GetRequestedWindow returns the time window the lease would hold its exporter for if
+// it was granted now, scheduled leases ask for their requested window
+func (l *Lease) GetRequestedWindow(now time.Time) (time.Time, time.Time) {
+	if l.IsScheduled() {
+		return l.Spec.BeginTime.Time, l.Spec.EndTime.Time
+	}
+	return now, now.Add(l.Spec.Duration.Duration)
+}
+
+// GetExpirationTime returns the time at which an acquired lease expires, or nil if
+// the lease has not been acquired yet
+func (l *Lease) GetExpirationTime() *time.Time {
+	if l.Status.BeginTime == nil {
+		return nil
+	}
+	if l.IsScheduled() {
+		return &l.Spec.EndTime.Time
+	}
+	expiration := l.Status.BeginTime.Add(l.Spec.Duration.Duration)
+	return &expiration
+}
+
+// IsGang returns true if the lease requests exporters for several members
+func (l *Lease) IsGang() bool {
+	return len(l.Spec.Members) > 0
+}
+
+// GetCount returns the number of exporters requested by the member
+func (m *LeaseMember) GetCount() int {
+	return max(m.Count, 1)
+}
+
+// GetSlotName returns the name the i-th exporter of the member is addressed by
+func (m *LeaseMember) GetSlotName(i int) string {
+	if m.GetCount() == 1 {
+		return m.Name
+	}
+	return fmt.Sprintf("%s-%d", m.Name, i)
+}
+
+// GetExporterNames returns the names of all the exporters held, or reserved, by the lease
+func (l *Lease) GetExporterNames() []string {
+	if len(l.Status.Members) > 0 {
+		var names []string
+		for _, member := range l.Status.Members {
+			names = append(names, member.ExporterRef.Name)
+		}
+		return names
+	}
+	if l.Status.ExporterRef != nil {
+		return []string{l.Status.ExporterRef.Name}
+	}
+	return nil
+}
+
+// HoldsExporter returns true if the exporter is held, or reserved, by the lease
+func (l *Lease) HoldsExporter(name string) bool {
+	return slices.Contains(l.GetExporterNames(), name)
+}
+
+// GetMemberExporterName returns the name of the exporter addressed by the given
+// name within a gang lease
+func (l *Lease) GetMemberExporterName(name string) (string, bool) {
+	for _, member := range l.Status.Members {
+		if member.Name == name {
+			return member.ExporterRef.Name, true
+		}
+	}
+	return "", false
+}
+
 func (l *Lease) GetExporterName() string {
 	if l.Status.ExporterRef == nil {
 		return "(none)"
@@ -169,3 +522,55 @@ func (l *Lease) Expire(ctx context.Context) {
 	l.Status.Ended = true
 	l.Status.EndTime = &metav1.Time{Time: time.Now()}
 }
+
+func (l *Lease) Preempt(ctx context.Context, by string) {
+	logger := log.FromContext(ctx)
+	logger.Info("The lease has been preempted", "lease", l.Name, "exporter", l.GetExporterName(), "client", l.GetClientName(), "by", by)
+	l.SetStatusCondition(LeaseConditionTypePreempted, true, "Preempted", "The spot lease was preempted by lease %s", by)
+	l.SetStatusReady(false, "Preempted", "The spot lease was preempted by lease %s", by)
+	l.Status.Ended = true
+	l.Status.EndTime = &metav1.Time{Time: time.Now()}
+}
+
+func (l *Lease) EndOutsideTimeWindow(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	logger.Info("The lease has crossed into a time window its policies forbid", "lease", l.Name, "exporter", l.GetExporterName(), "client", l.GetClientName())
+	l.SetStatusReady(false, "OutsideTimeWindow", "The lease has ended as it crossed into a time window the policies forbid")
+	l.Status.Ended = true
+	l.Status.EndTime = &metav1.Time{Time: time.Now()}
+}
+
+func (l *Lease) EndExporterLost(ctx context.Context, message string) {
+	logger := log.FromContext(ctx)
+	logger.Info("The lease has been ended as its exporter was lost", "lease", l.Name, "exporter", l.GetExporterName(), "client", l.GetClientName())
+	l.SetStatusReady(false, "ExporterLost", "The lease has ended as %s", message)
+	l.Status.Ended = true
+	l.Status.EndTime = &metav1.Time{Time: time.Now()}
+}
+
+// Reacquire gives up the exporters of the lease, keeping its begin time, so that the lease
+// gets assigned other exporters matching the same selectors until its original expiration
+func (l *Lease) Reacquire(ctx context.Context, message string) {
+	logger := log.FromContext(ctx)
+	logger.Info("Re-acquiring exporters for the lease as its exporter was lost", "lease", l.Name, "exporter", l.GetExporterName(), "client", l.GetClientName())
+	l.SetStatusReady(false, "ExporterLost", "Re-acquiring exporters for the lease as %s", message)
+	l.Status.ExporterRef = nil
+	l.Status.Members = nil
+}
+
+func (l *Lease) EndIdle(ctx context.Context, timeout time.Duration) {
+	logger := log.FromContext(ctx)
+	logger.Info("The lease has been released as it was idle", "lease", l.Name, "exporter", l.GetExporterName(), "client", l.GetClientName())
+	l.SetStatusIdle(true, "IdleTimeout", "The lease was released after being idle for %s", timeout)
+	l.SetStatusReady(false, "IdleTimeout", "The lease was released after being idle for %s", timeout)
+	l.Status.Ended = true
+	l.Status.EndTime = &metav1.Time{Time: time.Now()}
+}
+
+func (l *Lease) EndForMaintenance(ctx context.Context, message string) {
+	logger := log.FromContext(ctx)
+	logger.Info("The lease has been ended for exporter maintenance", "lease", l.Name, "exporter", l.GetExporterName(), "client", l.GetClientName())
+	l.SetStatusReady(false, "ExporterMaintenance", "The lease has ended as the %s", message)
+	l.Status.Ended = true
+	l.Status.EndTime = &metav1.Time{Time: time.Now()}
+}
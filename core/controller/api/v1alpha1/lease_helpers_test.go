@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	cpb "github.com/the78mole/jumpstarter-mono/core/controller/internal/protocol/jumpstarter/client/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLeaseWindowFromProtobuf(t *testing.T) {
+	now := time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name     string
+		req      *cpb.Lease
+		duration time.Duration
+		begin    time.Time
+		invalid  bool
+	}{
+		{
+			name:     "duration only",
+			req:      &cpb.Lease{Duration: durationpb.New(time.Hour)},
+			duration: time.Hour,
+		},
+		{
+			name: "begin time and duration",
+			req: &cpb.Lease{
+				BeginTime: timestamppb.New(now.Add(time.Hour)),
+				Duration:  durationpb.New(time.Hour),
+			},
+			duration: time.Hour,
+			begin:    now.Add(time.Hour),
+		},
+		{
+			name: "end time and duration",
+			req: &cpb.Lease{
+				EndTime:  timestamppb.New(now.Add(2 * time.Hour)),
+				Duration: durationpb.New(time.Hour),
+			},
+			duration: time.Hour,
+			begin:    now.Add(time.Hour),
+		},
+		{
+			name: "end time closer than the duration",
+			req: &cpb.Lease{
+				EndTime:  timestamppb.New(now.Add(30 * time.Minute)),
+				Duration: durationpb.New(time.Hour),
+			},
+			invalid: true,
+		},
+		{
+			name: "begin time in the past and duration",
+			req: &cpb.Lease{
+				BeginTime: timestamppb.New(now.Add(-time.Hour)),
+				Duration:  durationpb.New(2 * time.Hour),
+			},
+			invalid: true,
+		},
+		{
+			name: "begin time in the past and end time",
+			req: &cpb.Lease{
+				BeginTime: timestamppb.New(now.Add(-time.Hour)),
+				EndTime:   timestamppb.New(now.Add(time.Hour)),
+			},
+			invalid: true,
+		},
+		{
+			name: "begin time in the past, end time and duration",
+			req: &cpb.Lease{
+				BeginTime: timestamppb.New(now.Add(-time.Hour)),
+				EndTime:   timestamppb.New(now.Add(time.Hour)),
+				Duration:  durationpb.New(2 * time.Hour),
+			},
+			invalid: true,
+		},
+		{
+			name: "end time before the begin time",
+			req: &cpb.Lease{
+				BeginTime: timestamppb.New(now.Add(time.Hour)),
+				EndTime:   timestamppb.New(now),
+			},
+			invalid: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			duration, begin, _, err := leaseWindowFromProtobuf(tc.req, now)
+			if tc.invalid {
+				if err == nil {
+					t.Fatalf("expected the window to be rejected, got begin time %v", begin)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if duration != tc.duration {
+				t.Errorf("expected duration %s, got %s", tc.duration, duration)
+			}
+			if tc.begin.IsZero() != (begin == nil) || begin != nil && !begin.Time.Equal(tc.begin) {
+				t.Errorf("expected begin time %v, got %v", tc.begin, begin)
+			}
+		})
+	}
+}
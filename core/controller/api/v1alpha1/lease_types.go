@@ -29,8 +29,85 @@ type LeaseSpec struct {
 	Duration metav1.Duration `json:"duration"`
 	// The selector for the exporter to be used
 	Selector metav1.LabelSelector `json:"selector"`
+	// The selectors for the devices reported by the exporter to be used, each
+	// one must be matched by the labels of at least one of its devices
+	DeviceSelectors []metav1.LabelSelector `json:"deviceSelectors,omitempty"`
 	// The release flag requests the controller to end the lease now
 	Release bool `json:"release,omitempty"`
+	// The requested begin time for a scheduled lease, the exporter is reserved
+	// in advance and assigned to the client at this time
+	BeginTime *metav1.Time `json:"beginTime,omitempty"`
+	// The requested end time for a scheduled lease, set together with BeginTime
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// Shorten the lease to the maximum duration allowed by the policies instead
+	// of marking it unsatisfiable when the requested duration exceeds it
+	ClampDuration bool `json:"clampDuration,omitempty"`
+	// The members of a gang lease, each one requesting a number of exporters,
+	// the lease is only acquired once exporters are available for all of them
+	// and the selector of the lease is not used
+	Members []LeaseMember `json:"members,omitempty"`
+	// What to do once an exporter held by the lease has been offline for longer
+	// than the grace period, the lease is marked as degraded in any case
+	// +kubebuilder:validation:Enum=Keep;End;Reacquire
+	ExporterLossAction ExporterLossAction `json:"exporterLossAction,omitempty"`
+	// How long an exporter held by the lease can be offline before it is
+	// considered lost, defaults to one minute
+	ExporterLossGracePeriod *metav1.Duration `json:"exporterLossGracePeriod,omitempty"`
+	// Prefer exporters in the same topology domain as the exporters held by other leases,
+	// e.g. in the same rack
+	Affinity []LeaseAffinityTerm `json:"affinity,omitempty"`
+	// Prefer exporters in other topology domains than the exporters held by other leases,
+	// e.g. to spread leases over different locations
+	AntiAffinity []LeaseAffinityTerm `json:"antiAffinity,omitempty"`
+}
+
+// LeaseAffinityTerm references other leases of the namespace, whose exporters are compared
+// with the candidate exporters of a lease by the value of a topology label
+type LeaseAffinityTerm struct {
+	// The names of the other leases
+	// +kubebuilder:validation:MinItems=1
+	Leases []string `json:"leases"`
+	// The exporter label key whose value identifies the topology domain of an exporter
+	// +kubebuilder:validation:MinLength=1
+	TopologyKey string `json:"topologyKey"`
+}
+
+// ExporterLossAction is what to do with a lease whose exporter has been lost
+type ExporterLossAction string
+
+const (
+	// ExporterLossActionKeep keeps the lease going, waiting for the exporter to come back
+	ExporterLossActionKeep ExporterLossAction = "Keep"
+	// ExporterLossActionEnd ends the lease
+	ExporterLossActionEnd ExporterLossAction = "End"
+	// ExporterLossActionReacquire replaces the exporters of the lease with other exporters
+	// matching the same selectors, the lease keeps its original expiration time
+	ExporterLossActionReacquire ExporterLossAction = "Reacquire"
+)
+
+// LeaseMember requests one or more exporters as part of a gang lease
+type LeaseMember struct {
+	// The name of the member, used to address its exporters
+	Name string `json:"name"`
+	// The selector for the exporters of the member
+	Selector metav1.LabelSelector `json:"selector"`
+	// The selectors for the devices reported by the exporters of the member, each
+	// one must be matched by the labels of at least one of their devices
+	DeviceSelectors []metav1.LabelSelector `json:"deviceSelectors,omitempty"`
+	// The number of exporters requested for the member
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	Count int `json:"count,omitempty"`
+}
+
+// LeaseMemberExporter is an exporter assigned to a member of a gang lease
+type LeaseMemberExporter struct {
+	// The name the exporter is addressed by, which is the member name, followed
+	// by the index of the exporter when the member requests more than one
+	Name string `json:"name"`
+	// The member the exporter has been assigned to
+	Member      string                      `json:"member"`
+	ExporterRef corev1.LocalObjectReference `json:"exporterRef"`
 }
 
 // LeaseStatus defines the observed state of Lease
@@ -44,6 +121,17 @@ type LeaseStatus struct {
 	Priority    int                          `json:"priority,omitempty"`
 	SpotAccess  bool                         `json:"spotAccess,omitempty"`
 	Conditions  []metav1.Condition           `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// The exporters assigned to each member of a gang lease, ExporterRef
+	// points to the exporter of the first member
+	Members []LeaseMemberExporter `json:"members,omitempty"`
+	// The position of the lease in the queue of leases waiting for the same
+	// exporters, starting at 1, only set while the lease is pending
+	QueuePosition int `json:"queuePosition,omitempty"`
+	// The time the lease is expected to acquire its exporters at, estimated from
+	// the end time of the active leases and the leases ahead in the queue
+	EstimatedBeginTime *metav1.Time `json:"estimatedBeginTime,omitempty"`
+	// The last time the client dialed, or exchanged data with, the exporters of the lease
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
 }
 
 type LeaseConditionType string
@@ -53,6 +141,10 @@ const (
 	LeaseConditionTypeReady         LeaseConditionType = "Ready"
 	LeaseConditionTypeUnsatisfiable LeaseConditionType = "Unsatisfiable"
 	LeaseConditionTypeInvalid       LeaseConditionType = "Invalid"
+	LeaseConditionTypePreempted     LeaseConditionType = "Preempted"
+	LeaseConditionTypeQuotaExceeded LeaseConditionType = "QuotaExceeded"
+	LeaseConditionTypeDegraded      LeaseConditionType = "Degraded"
+	LeaseConditionTypeIdle          LeaseConditionType = "Idle"
 )
 
 type LeaseLabel string
@@ -67,6 +159,8 @@ const (
 // +kubebuilder:printcolumn:JSONPath=".status.ended",name=Ended,type=boolean
 // +kubebuilder:printcolumn:JSONPath=".spec.clientRef.name",name=Client,type=string
 // +kubebuilder:printcolumn:JSONPath=".status.exporterRef.name",name=Exporter,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.beginTime",name=Scheduled,type=date,priority=1
+// +kubebuilder:printcolumn:JSONPath=".status.queuePosition",name=Queue,type=integer,priority=1
 
 // Lease is the Schema for the exporters API
 type Lease struct {
@@ -24,12 +24,14 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	apiserverinstall "k8s.io/apiserver/pkg/apis/apiserver/install"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -47,6 +49,7 @@ import (
 	"github.com/the78mole/jumpstarter-mono/core/controller/internal/controller"
 	"github.com/the78mole/jumpstarter-mono/core/controller/internal/oidc"
 	"github.com/the78mole/jumpstarter-mono/core/controller/internal/service"
+	webhookv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/internal/webhook/v1alpha1"
 
 	// +kubebuilder:scaffold:imports
 
@@ -81,6 +84,9 @@ func main() {
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var printVersion bool
+	var enableWebhooks bool
+	var controllerUsernames string
+	var controllerGroups string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metric endpoint binds to. "+
 		"Use the port :8080. If not set, it will be 0 in order to disable the metrics server")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -92,6 +98,14 @@ func main() {
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.BoolVar(&printVersion, "version", false, "Print version information and exit")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the validating and defaulting admission webhooks are served. "+
+			"Requires Kubernetes 1.28 or later, unless --controller-usernames or --controller-groups are set")
+	flag.StringVar(&controllerUsernames, "controller-usernames", "",
+		"Comma separated usernames the controller authenticates as, the only ones allowed to manage "+
+			"the reserved labels of the resources. Defaults to the username of the controller")
+	flag.StringVar(&controllerGroups, "controller-groups", "",
+		"Comma separated groups whose members are allowed to manage the reserved labels of the resources")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -172,7 +186,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	authenticator, prefix, router, option, provisioning, err := config.LoadConfiguration(
+	authenticator, prefix, router, option, provisioning, retention, err := config.LoadConfiguration(
 		context.Background(),
 		mgr.GetAPIReader(),
 		mgr.GetScheme(),
@@ -192,9 +206,10 @@ func main() {
 	}
 
 	if err = (&controller.ExporterReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Signer: oidcSigner,
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Signer:   oidcSigner,
+		Recorder: mgr.GetEventRecorderFor("exporter-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Exporter")
 		os.Exit(1)
@@ -208,12 +223,59 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&controller.LeaseReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("lease-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Lease")
+		os.Exit(1)
+	}
+	if err = (&controller.ExporterAccessPolicyReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Lease")
+		setupLog.Error(err, "unable to create controller", "controller", "ExporterAccessPolicy")
+		os.Exit(1)
+	}
+	if err = (&controller.ClientGroupReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClientGroup")
+		os.Exit(1)
+	}
+
+	leaseGarbageCollector, err := config.LoadRetentionConfiguration(mgr.GetClient(), os.Getenv("NAMESPACE"), *retention)
+	if err != nil {
+		setupLog.Error(err, "unable to load retention configuration")
 		os.Exit(1)
 	}
+	if leaseGarbageCollector != nil {
+		if err = mgr.Add(leaseGarbageCollector); err != nil {
+			setupLog.Error(err, "unable to add lease garbage collector")
+			os.Exit(1)
+		}
+	}
+	if enableWebhooks {
+		// the controller is the only one allowed to manage the reserved labels
+		controllerIdentity := webhookv1alpha1.ControllerIdentity{
+			Usernames: splitList(controllerUsernames),
+			Groups:    splitList(controllerGroups),
+		}
+		if controllerIdentity.Empty() {
+			review := &authenticationv1.SelfSubjectReview{}
+			if err = mgr.GetClient().Create(context.Background(), review); err != nil {
+				setupLog.Error(err, "unable to get the username of the controller, "+
+					"set --controller-usernames on clusters without SelfSubjectReview")
+				os.Exit(1)
+			}
+			controllerIdentity.Usernames = []string{review.Status.UserInfo.Username}
+		}
+		if err = webhookv1alpha1.SetupWebhooksWithManager(mgr, controllerIdentity); err != nil {
+			setupLog.Error(err, "unable to create webhooks")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	watchClient, err := client.NewWithWatch(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
@@ -270,3 +332,14 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitList returns the non-empty values of a comma separated list
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -63,7 +63,7 @@ func main() {
 	ctx := logr.NewContext(context.Background(), logger)
 
 	cfg := ctrl.GetConfigOrDie()
-	client, err := kclient.New(cfg, kclient.Options{})
+	client, err := service.NewRouterClient(cfg)
 	if err != nil {
 		logger.Error(err, "failed to create k8s client")
 		os.Exit(1)
@@ -80,6 +80,7 @@ func main() {
 
 	svc := service.RouterService{
 		ServerOption: serverOption,
+		Client:       client,
 	}
 
 	logger.Info("starting router service", "version", Version, "commit", Commit, "buildTime", BuildTime)
@@ -44,6 +44,45 @@ class Grpc(BaseModel):
     keepalive: Optional[Keepalive] = None
 
 
+class ArchiveType(Enum):
+    jsonl = "jsonl"
+    configmap = "configmap"
+
+
+class Archive(BaseModel):
+    model_config = ConfigDict(extra="forbid")
+
+    type: Optional[ArchiveType] = Field(
+        None, description="Sink ended leases are archived to before being deleted"
+    )
+    path: Optional[str] = Field(
+        None,
+        description="Path of the file lease records are appended to, for the jsonl archive",
+    )
+    configMap: Optional[str] = Field(
+        None,
+        description="Name of the ConfigMap lease records are kept in, for the configmap archive",
+    )
+    maximumRecords: Optional[int] = Field(
+        None, description="How many lease records the configmap archive keeps"
+    )
+
+
+class Retention(BaseModel):
+    model_config = ConfigDict(extra="forbid")
+
+    maximumAge: Optional[str] = Field(
+        None, description="How long ended leases are kept for, e.g. 720h"
+    )
+    maximumLeasesPerClient: Optional[int] = Field(
+        None, description="How many ended leases are kept for each client"
+    )
+    interval: Optional[str] = Field(
+        None, description="How often ended leases are garbage collected"
+    )
+    archive: Optional[Archive] = None
+
+
 class Metrics(BaseModel):
     enabled: Optional[bool] = None
 
@@ -197,6 +236,7 @@ class JumpstarterConfig(BaseModel):
     provisioning: Optional[Provisioning] = None
     authentication: Optional[Authentication] = None
     grpc: Optional[Grpc] = None
+    retention: Optional[Retention] = None
 
 
 class Nodeport(BaseModel):
@@ -251,6 +291,15 @@ class Grpc1(BaseModel):
     tls: Optional[Tls] = None
 
 
+class Webhooks(BaseModel):
+    model_config = ConfigDict(extra="forbid")
+
+    enabled: Optional[bool] = Field(
+        None,
+        description="Whether to serve the validating and defaulting admission webhooks, the serving certificate is issued by cert-manager",
+    )
+
+
 class Model(BaseModel):
     model_config = ConfigDict(extra="forbid")
 
@@ -272,6 +321,7 @@ class Model(BaseModel):
         None, alias="global", description="Global parameters"
     )
     grpc: Optional[Grpc1] = None
+    webhooks: Optional[Webhooks] = None
 
 
 print(json.dumps(Model.model_json_schema(), indent=2))
@@ -2,11 +2,14 @@ package authorization
 
 import (
 	"context"
+	"maps"
 	"slices"
+	"strings"
 
 	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -69,12 +72,48 @@ func (b *BasicAuthorizer) Authorize(
 			}
 		}
 
-		if slices.Contains(c.Usernames(b.prefix), attributes.GetUser().GetName()) {
-			return authorizer.DecisionAllow, "", nil
-		} else {
+		if !slices.Contains(c.Usernames(b.prefix), attributes.GetUser().GetName()) {
 			return authorizer.DecisionDeny, "", nil
 		}
+
+		// internal tokens carry no groups nor claims, only record those of external identities
+		if c.Spec.Username != nil && *c.Spec.Username == attributes.GetUser().GetName() {
+			if err := b.recordClientIdentity(ctx, &c, attributes.GetUser()); err != nil {
+				return authorizer.DecisionDeny, "failed to record client identity", err
+			}
+		}
+		return authorizer.DecisionAllow, "", nil
 	default:
 		return authorizer.DecisionDeny, "invalid object kind", nil
 	}
 }
+
+// credentialExtraPrefix is the prefix of the extra claims set by the authenticators themselves,
+// e.g. the id of the credential, which change with every token
+const credentialExtraPrefix = "authentication.kubernetes.io/"
+
+// recordClientIdentity records the groups and extra claims of the identity the client
+// authenticated with in its status, for access policies to match on
+func (b *BasicAuthorizer) recordClientIdentity(
+	ctx context.Context,
+	c *jumpstarterdevv1alpha1.Client,
+	info user.Info,
+) error {
+	groups := info.GetGroups()
+	claims := maps.Clone(info.GetExtra())
+	maps.DeleteFunc(claims, func(key string, _ []string) bool {
+		return strings.HasPrefix(key, credentialExtraPrefix)
+	})
+	if len(claims) == 0 {
+		claims = nil
+	}
+
+	if slices.Equal(c.Status.Groups, groups) && maps.EqualFunc(c.Status.Claims, claims, slices.Equal[[]string]) {
+		return nil
+	}
+
+	original := client.MergeFrom(c.DeepCopy())
+	c.Status.Groups = groups
+	c.Status.Claims = claims
+	return b.client.Status().Patch(ctx, c, original)
+}
@@ -0,0 +1,105 @@
+package authorization
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBasicAuthorizerRecordsClientIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	if err := jumpstarterdevv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	jclient := &jumpstarterdevv1alpha1.Client{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "client", UID: "uid"},
+		Spec:       jumpstarterdevv1alpha1.ClientSpec{Username: ptr.To("oidc:developer")},
+	}
+	kclient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(jclient).
+		WithStatusSubresource(jclient).Build()
+	authz := NewBasicAuthorizer(kclient, "internal:", false)
+
+	testcases := []struct {
+		name     string
+		user     user.Info
+		decision authorizer.Decision
+		groups   []string
+		claims   map[string][]string
+	}{
+		{
+			name: "external identity",
+			user: &user.DefaultInfo{
+				Name:   "oidc:developer",
+				Groups: []string{"oidc:team-a"},
+				Extra: map[string][]string{
+					"example.com/site":                           {"lab-1"},
+					"authentication.kubernetes.io/credential-id": {"JTI=1234"},
+				},
+			},
+			decision: authorizer.DecisionAllow,
+			groups:   []string{"oidc:team-a"},
+			claims:   map[string][]string{"example.com/site": {"lab-1"}},
+		},
+		{
+			name:     "internal identity",
+			user:     &user.DefaultInfo{Name: "internal:" + jclient.InternalSubject()},
+			decision: authorizer.DecisionAllow,
+			groups:   []string{"oidc:team-a"},
+			claims:   map[string][]string{"example.com/site": {"lab-1"}},
+		},
+		{
+			name:     "unknown identity",
+			user:     &user.DefaultInfo{Name: "oidc:someone-else", Groups: []string{"oidc:team-b"}},
+			decision: authorizer.DecisionDeny,
+			groups:   []string{"oidc:team-a"},
+			claims:   map[string][]string{"example.com/site": {"lab-1"}},
+		},
+		{
+			name:     "external identity leaving its groups",
+			user:     &user.DefaultInfo{Name: "oidc:developer"},
+			decision: authorizer.DecisionAllow,
+		},
+	}
+	for _, testcase := range testcases {
+		decision, _, err := authz.Authorize(ctx, authorizer.AttributesRecord{
+			User:      testcase.user,
+			Namespace: jclient.Namespace,
+			Resource:  "Client",
+			Name:      jclient.Name,
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", testcase.name, err)
+		}
+		if decision != testcase.decision {
+			t.Errorf("%s: expected decision %v, got %v", testcase.name, testcase.decision, decision)
+		}
+
+		var updated jumpstarterdevv1alpha1.Client
+		if err := kclient.Get(ctx, client.ObjectKeyFromObject(jclient), &updated); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(updated.Status.Groups, testcase.groups) {
+			t.Errorf("%s: expected groups %v, got %v", testcase.name, testcase.groups, updated.Status.Groups)
+		}
+		if len(updated.Status.Claims) != len(testcase.claims) {
+			t.Errorf("%s: expected claims %v, got %v", testcase.name, testcase.claims, updated.Status.Claims)
+		}
+		for key, values := range testcase.claims {
+			if !slices.Equal(updated.Status.Claims[key], values) {
+				t.Errorf("%s: expected claims %v, got %v", testcase.name, testcase.claims, updated.Status.Claims)
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/the78mole/jumpstarter-mono/core/controller/internal/controller"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LoadRetentionConfiguration returns the garbage collector for ended leases,
+// or nil if the retention policy keeps them forever
+func LoadRetentionConfiguration(
+	c client.Client,
+	namespace string,
+	config Retention,
+) (*controller.LeaseGarbageCollector, error) {
+	if config.MaximumAge == nil && config.MaximumLeasesPerClient <= 0 {
+		return nil, nil
+	}
+
+	collector := &controller.LeaseGarbageCollector{
+		Client:                 c,
+		MaximumLeasesPerClient: config.MaximumLeasesPerClient,
+	}
+	if config.MaximumAge != nil {
+		collector.MaximumAge = config.MaximumAge.Duration
+	}
+	if config.Interval != nil {
+		collector.Interval = config.Interval.Duration
+	}
+
+	switch config.Archive.Type {
+	case ArchiveTypeNone:
+	case ArchiveTypeJSONLines:
+		if config.Archive.Path == "" {
+			return nil, fmt.Errorf("LoadRetentionConfiguration: missing path for jsonl archive")
+		}
+		collector.Archive = &controller.JSONLinesLeaseArchive{
+			Path: config.Archive.Path,
+		}
+	case ArchiveTypeConfigMap:
+		name := config.Archive.ConfigMap
+		if name == "" {
+			name = "jumpstarter-lease-archive"
+		}
+		collector.Archive = &controller.ConfigMapLeaseArchive{
+			Client:         c,
+			Key:            types.NamespacedName{Namespace: namespace, Name: name},
+			MaximumRecords: config.Archive.MaximumRecords,
+		}
+	default:
+		return nil, fmt.Errorf("LoadRetentionConfiguration: unknown archive type %q", config.Archive.Type)
+	}
+
+	return collector, nil
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
 )
 
@@ -8,6 +9,7 @@ type Config struct {
 	Authentication Authentication `json:"authentication"`
 	Provisioning   Provisioning   `json:"provisioning"`
 	Grpc           Grpc           `json:"grpc"`
+	Retention      Retention      `json:"retention"`
 }
 
 type Authentication struct {
@@ -19,6 +21,34 @@ type Provisioning struct {
 	Enabled bool `json:"enabled"`
 }
 
+type Retention struct {
+	// How long ended leases are kept for
+	MaximumAge *metav1.Duration `json:"maximumAge"`
+	// How many ended leases are kept for each client
+	MaximumLeasesPerClient int `json:"maximumLeasesPerClient"`
+	// How often ended leases are garbage collected
+	Interval *metav1.Duration `json:"interval"`
+	Archive  Archive          `json:"archive"`
+}
+
+type ArchiveType string
+
+const (
+	ArchiveTypeNone      ArchiveType = ""
+	ArchiveTypeJSONLines ArchiveType = "jsonl"
+	ArchiveTypeConfigMap ArchiveType = "configmap"
+)
+
+type Archive struct {
+	Type ArchiveType `json:"type"`
+	// Path of the file lease records are appended to, for the jsonl archive
+	Path string `json:"path"`
+	// Name of the ConfigMap lease records are kept in, for the configmap archive
+	ConfigMap string `json:"configMap"`
+	// How many lease records the configmap archive keeps
+	MaximumRecords int `json:"maximumRecords"`
+}
+
 type Internal struct {
 	Prefix string `json:"prefix"`
 }
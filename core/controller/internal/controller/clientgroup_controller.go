@@ -0,0 +1,141 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+// ClientGroupReconciler reconciles a ClientGroup object
+type ClientGroupReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=jumpstarter.dev,resources=clientgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=jumpstarter.dev,resources=clientgroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=jumpstarter.dev,resources=clientgroups/finalizers,verbs=update
+
+// Reconcile resolves the clients that are members of the group into its status
+func (r *ClientGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var group jumpstarterdevv1alpha1.ClientGroup
+	if err := r.Get(ctx, req.NamespacedName, &group); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(
+			fmt.Errorf("Reconcile: failed to get client group: %w", err),
+		)
+	}
+
+	original := client.MergeFrom(group.DeepCopy())
+
+	var clients jumpstarterdevv1alpha1.ClientList
+	if err := r.List(ctx, &clients, client.InNamespace(group.Namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("Reconcile: failed to list clients: %w", err)
+	}
+
+	var members []string
+	for _, jclient := range clients.Items {
+		if group.HasMember(&jclient) {
+			members = append(members, jclient.Name)
+		}
+	}
+	slices.Sort(members)
+	group.Status.Members = members
+
+	if err := r.Status().Patch(ctx, &group, original); err != nil {
+		return RequeueConflict(logger, ctrl.Result{}, err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// groupsForClient enqueues all of the client groups of the namespace of the client, as the
+// client may have joined or left any of them
+func (r *ClientGroupReconciler) groupsForClient(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	var groups jumpstarterdevv1alpha1.ClientGroupList
+	if err := r.List(ctx, &groups, client.InNamespace(obj.GetNamespace())); err != nil {
+		logger.Error(err, "groupsForClient: failed to list client groups", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, group := range groups.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: group.Namespace,
+			Name:      group.Name,
+		}})
+	}
+	return requests
+}
+
+// listClientGroups returns the client groups of the namespace by name
+func listClientGroups(
+	ctx context.Context,
+	c client.Reader,
+	namespace string,
+) (map[string]*jumpstarterdevv1alpha1.ClientGroup, error) {
+	var groups jumpstarterdevv1alpha1.ClientGroupList
+	if err := c.List(ctx, &groups, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listClientGroups: failed to list client groups: %w", err)
+	}
+
+	byName := make(map[string]*jumpstarterdevv1alpha1.ClientGroup, len(groups.Items))
+	for i := range groups.Items {
+		byName[groups.Items[i].Name] = &groups.Items[i]
+	}
+	return byName, nil
+}
+
+// policyWithGroupDefaults returns the policy with the defaults of the first client group it
+// references applied, if any
+func policyWithGroupDefaults(
+	policy jumpstarterdevv1alpha1.Policy,
+	groups map[string]*jumpstarterdevv1alpha1.ClientGroup,
+) jumpstarterdevv1alpha1.Policy {
+	for _, from := range policy.From {
+		if group, ok := groups[from.ClientGroupName()]; ok {
+			return group.PolicyWithDefaults(policy)
+		}
+	}
+	return policy
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClientGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&jumpstarterdevv1alpha1.ClientGroup{}).
+		Watches(
+			&jumpstarterdevv1alpha1.Client{},
+			handler.EnqueueRequestsFromMapFunc(r.groupsForClient),
+		).
+		Complete(r)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("ClientGroup", func() {
+	var outsider *jumpstarterdevv1alpha1.Client
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		createExporters(ctx, testExporter1DutA)
+		setExporterOnlineConditions(ctx, testExporter1DutA.Name, metav1.ConditionTrue)
+
+		outsider = &jumpstarterdevv1alpha1.Client{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "outsider",
+				Namespace: "default",
+			},
+			Spec: jumpstarterdevv1alpha1.ClientSpec{
+				Username: ptr.To("oidc:outsider"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, outsider)).To(Succeed())
+
+		group := &jumpstarterdevv1alpha1.ClientGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team",
+				Namespace: "default",
+			},
+			Spec: jumpstarterdevv1alpha1.ClientGroupSpec{
+				Clients:         []string{testClient.Name},
+				Usernames:       []string{"oidc:developer"},
+				MaximumDuration: &metav1.Duration{Duration: time.Second},
+			},
+		}
+		Expect(k8sClient.Create(ctx, group)).To(Succeed())
+
+		policy := &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team-access",
+				Namespace: "default",
+			},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				Policies: []jumpstarterdevv1alpha1.Policy{{
+					From: []jumpstarterdevv1alpha1.From{{
+						ClientGroupRef: &corev1.LocalObjectReference{Name: group.Name},
+					}},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+		DeferCleanup(func() {
+			ctx := context.Background()
+			Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, group)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, outsider)).To(Succeed())
+			deleteExporters(ctx, testExporter1DutA)
+			deleteLeases(ctx, "lease1")
+		})
+	})
+
+	It("should report the clients that are members of the group", func() {
+		ctx := context.Background()
+		developer := &jumpstarterdevv1alpha1.Client{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "developer",
+				Namespace: "default",
+			},
+			Spec: jumpstarterdevv1alpha1.ClientSpec{
+				Username: ptr.To("oidc:developer"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, developer)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(context.Background(), developer)).To(Succeed())
+		})
+
+		reconciler := &ClientGroupReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+		Expect(reconciler.groupsForClient(ctx, developer)).To(ConsistOf(reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: "team"},
+		}))
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: "team"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var group jumpstarterdevv1alpha1.ClientGroup
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "team"}, &group)).To(Succeed())
+		Expect(group.Status.Members).To(Equal([]string{testClient.Name, developer.Name}))
+	})
+
+	It("should apply the defaults of the group to the policies referencing it", func() {
+		ctx := context.Background()
+		lease := leaseDutA2Sec.DeepCopy()
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+
+		updatedLease := getLease(ctx, lease.Name)
+		Expect(updatedLease.Status.ExporterRef).To(BeNil())
+		condition := meta.FindStatusCondition(
+			updatedLease.Status.Conditions,
+			string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable),
+		)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("DurationExceedsPolicy"))
+	})
+
+	It("should grant access to the members of the group", func() {
+		ctx := context.Background()
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Spec.ClampDuration = true
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+
+		updatedLease := getLease(ctx, lease.Name)
+		Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+		Expect(updatedLease.Spec.Duration.Duration).To(Equal(time.Second))
+	})
+
+	It("should not grant access to the clients outside of the group", func() {
+		ctx := context.Background()
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Spec.ClientRef.Name = outsider.Name
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+
+		updatedLease := getLease(ctx, lease.Name)
+		Expect(updatedLease.Status.ExporterRef).To(BeNil())
+		condition := meta.FindStatusCondition(
+			updatedLease.Status.Conditions,
+			string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable),
+		)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("NoAccess"))
+	})
+})
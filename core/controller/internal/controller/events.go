@@ -0,0 +1,124 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// leaseEventConditions are the lease conditions recorded as events, along with the type of
+// the event recorded when the condition becomes true
+var leaseEventConditions = []struct {
+	Type      jumpstarterdevv1alpha1.LeaseConditionType
+	EventType string
+}{
+	{jumpstarterdevv1alpha1.LeaseConditionTypePending, corev1.EventTypeNormal},
+	{jumpstarterdevv1alpha1.LeaseConditionTypeReady, corev1.EventTypeNormal},
+	{jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable, corev1.EventTypeWarning},
+	{jumpstarterdevv1alpha1.LeaseConditionTypeInvalid, corev1.EventTypeWarning},
+	{jumpstarterdevv1alpha1.LeaseConditionTypePreempted, corev1.EventTypeWarning},
+	{jumpstarterdevv1alpha1.LeaseConditionTypeQuotaExceeded, corev1.EventTypeWarning},
+	{jumpstarterdevv1alpha1.LeaseConditionTypeDegraded, corev1.EventTypeWarning},
+	{jumpstarterdevv1alpha1.LeaseConditionTypeIdle, corev1.EventTypeWarning},
+}
+
+// leaseEndedNormally are the reasons of the Ready condition of leases that ended as requested
+var leaseEndedNormally = map[string]bool{
+	"Released": true,
+	"Expired":  true,
+}
+
+// recordLeaseEvents records an event for each condition of the lease whose status or reason
+// changed during the reconciliation, the reason of the event is the reason of the condition
+func recordLeaseEvents(recorder record.EventRecorder, original, lease *jumpstarterdevv1alpha1.Lease) {
+	for _, c := range leaseEventConditions {
+		condition, changed := conditionChanged(original.Status.Conditions, lease.Status.Conditions, string(c.Type))
+		if !changed {
+			continue
+		}
+
+		eventType := corev1.EventTypeNormal
+		switch {
+		case condition.Status == metav1.ConditionTrue:
+			eventType = c.EventType
+		case c.Type == jumpstarterdevv1alpha1.LeaseConditionTypeReady && !leaseEndedNormally[condition.Reason]:
+			// the lease lost its exporters, or ended before it was meant to
+			eventType = corev1.EventTypeWarning
+		}
+		recorder.Event(lease, eventType, condition.Reason, condition.Message)
+	}
+}
+
+// recordExporterEvents records an event for the exporter coming online or going offline, being
+// registered or unregistered, being put under or out of maintenance, and being done cleaning up
+func recordExporterEvents(recorder record.EventRecorder, original, exporter *jumpstarterdevv1alpha1.Exporter) {
+	// the reason of the online condition is the same either way, the events are named after the transition
+	if condition, changed := conditionChanged(original.Status.Conditions, exporter.Status.Conditions,
+		string(jumpstarterdevv1alpha1.ExporterConditionTypeOnline)); changed && conditionWasSet(original, condition) {
+		if condition.Status == metav1.ConditionTrue {
+			recorder.Event(exporter, corev1.EventTypeNormal, "Online", condition.Message)
+		} else {
+			recorder.Event(exporter, corev1.EventTypeWarning, "Offline", condition.Message)
+		}
+	}
+
+	if condition, changed := conditionChanged(original.Status.Conditions, exporter.Status.Conditions,
+		string(jumpstarterdevv1alpha1.ExporterConditionTypeRegistered)); changed && conditionWasSet(original, condition) {
+		if condition.Status == metav1.ConditionTrue {
+			recorder.Event(exporter, corev1.EventTypeNormal, condition.Reason, "The exporter has registered its devices")
+		} else {
+			recorder.Event(exporter, corev1.EventTypeWarning, condition.Reason, "The exporter has unregistered")
+		}
+	}
+
+	if condition, changed := conditionChanged(original.Status.Conditions, exporter.Status.Conditions,
+		string(jumpstarterdevv1alpha1.ExporterConditionTypeMaintenance)); changed && conditionWasSet(original, condition) {
+		message := condition.Message
+		if message == "" {
+			message = "The exporter is back in rotation"
+		}
+		recorder.Event(exporter, corev1.EventTypeNormal, condition.Reason, message)
+	}
+
+	// exporters failing to clean up are put under maintenance, which is recorded above
+	if original.Status.Phase == jumpstarterdevv1alpha1.ExporterPhaseCleaning &&
+		exporter.Status.Phase != jumpstarterdevv1alpha1.ExporterPhaseCleaning && !exporter.UnderMaintenance() {
+		recorder.Event(exporter, corev1.EventTypeNormal, "CleanedUp", "The exporter is done cleaning up after its last lease")
+	}
+}
+
+// conditionChanged returns the condition of the given type if it has been set, or its status
+// or reason changed, messages alone change too often to be recorded
+func conditionChanged(original, current []metav1.Condition, conditionType string) (*metav1.Condition, bool) {
+	condition := meta.FindStatusCondition(current, conditionType)
+	if condition == nil {
+		return nil, false
+	}
+	previous := meta.FindStatusCondition(original, conditionType)
+	return condition, previous == nil || previous.Status != condition.Status || previous.Reason != condition.Reason
+}
+
+// conditionWasSet returns true if the exporter condition was set before, or has just been set to
+// true, new exporters start offline and unregistered without it being worth an event
+func conditionWasSet(original *jumpstarterdevv1alpha1.Exporter, condition *metav1.Condition) bool {
+	return condition.Status == metav1.ConditionTrue ||
+		meta.FindStatusCondition(original.Status.Conditions, condition.Type) != nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// drainEvents returns the events recorded so far
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+var _ = Describe("Events", func() {
+	When("reconciling leases", func() {
+		BeforeEach(func() {
+			createExporters(context.Background(), testExporter1DutA)
+			setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		})
+		AfterEach(func() {
+			ctx := context.Background()
+			deleteExporters(ctx, testExporter1DutA)
+			deleteLeases(ctx, "lease1", "lease2")
+		})
+
+		It("should record the lease being acquired, pending and released", func() {
+			ctx := context.Background()
+			recorder := record.NewFakeRecorder(100)
+			reconciler := &LeaseReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+			reconcileWithEvents := func(name string) []string {
+				_, err := reconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: "default", Name: name},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				return drainEvents(recorder)
+			}
+
+			lease1 := leaseDutA2Sec.DeepCopy()
+			Expect(k8sClient.Create(ctx, lease1)).To(Succeed())
+			Expect(reconcileWithEvents(lease1.Name)).To(ContainElement(HavePrefix("Normal Ready ")))
+			// nothing changed, nothing is recorded
+			Expect(reconcileWithEvents(lease1.Name)).To(BeEmpty())
+
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			Expect(reconcileWithEvents(lease2.Name)).To(ContainElement(HavePrefix("Normal NotAvailable ")))
+
+			updatedLease := getLease(ctx, lease1.Name)
+			updatedLease.Spec.Release = true
+			Expect(k8sClient.Update(ctx, updatedLease)).To(Succeed())
+			Expect(reconcileWithEvents(lease1.Name)).To(ContainElement(HavePrefix("Normal Released ")))
+		})
+
+		It("should record spot leases being preempted as warnings", func() {
+			ctx := context.Background()
+			recorder := record.NewFakeRecorder(100)
+			reconciler := &LeaseReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+			spotClient := &jumpstarterdevv1alpha1.Client{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "spot-client",
+					Namespace: "default",
+					Labels:    map[string]string{"role": "ci"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, spotClient)).To(Succeed())
+			defer func() { Expect(k8sClient.Delete(ctx, spotClient)).To(Succeed()) }()
+			Expect(k8sClient.Create(ctx, spotAccessPolicy.DeepCopy())).To(Succeed())
+			defer func() { Expect(k8sClient.Delete(ctx, spotAccessPolicy.DeepCopy())).To(Succeed()) }()
+
+			lease1 := leaseDutA2Sec.DeepCopy()
+			lease1.Spec.ClientRef.Name = spotClient.Name
+			Expect(k8sClient.Create(ctx, lease1)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: lease1.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getLease(ctx, lease1.Name).Status.BeginTime).NotTo(BeNil())
+			drainEvents(recorder)
+
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: lease2.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(drainEvents(recorder)).To(ContainElement(
+				"Warning Preempted The spot lease was preempted by lease lease2"))
+		})
+
+		It("should record unsatisfiable leases as warnings", func() {
+			ctx := context.Background()
+			recorder := record.NewFakeRecorder(100)
+			reconciler := &LeaseReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Selector.MatchLabels["dut"] = "missing"
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: lease.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(drainEvents(recorder)).To(ContainElement(HavePrefix("Warning NoExporter ")))
+		})
+	})
+
+	It("should record exporters going online and offline", func() {
+		recorder := record.NewFakeRecorder(100)
+
+		created := testExporter1DutA.DeepCopy()
+		online := created.DeepCopy()
+		meta.SetStatusCondition(&online.Status.Conditions, metav1.Condition{
+			Type:    string(jumpstarterdevv1alpha1.ExporterConditionTypeOnline),
+			Status:  metav1.ConditionTrue,
+			Reason:  "Seen",
+			Message: "Last seen less than 1 minute ago",
+		})
+		recordExporterEvents(recorder, created, online)
+		Expect(drainEvents(recorder)).To(ConsistOf("Normal Online Last seen less than 1 minute ago"))
+
+		offline := online.DeepCopy()
+		meta.SetStatusCondition(&offline.Status.Conditions, metav1.Condition{
+			Type:    string(jumpstarterdevv1alpha1.ExporterConditionTypeOnline),
+			Status:  metav1.ConditionFalse,
+			Reason:  "Seen",
+			Message: "Last seen more than 1 minute ago",
+		})
+		recordExporterEvents(recorder, online, offline)
+		Expect(drainEvents(recorder)).To(ConsistOf("Warning Offline Last seen more than 1 minute ago"))
+	})
+
+	It("should not record new exporters being offline", func() {
+		recorder := record.NewFakeRecorder(100)
+
+		created := testExporter1DutA.DeepCopy()
+		offline := created.DeepCopy()
+		meta.SetStatusCondition(&offline.Status.Conditions, metav1.Condition{
+			Type:    string(jumpstarterdevv1alpha1.ExporterConditionTypeOnline),
+			Status:  metav1.ConditionFalse,
+			Reason:  "Seen",
+			Message: "Never seen",
+		})
+		recordExporterEvents(recorder, created, offline)
+		Expect(drainEvents(recorder)).To(BeEmpty())
+	})
+})
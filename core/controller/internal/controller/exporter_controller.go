@@ -25,8 +25,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
@@ -36,8 +38,9 @@ import (
 // ExporterReconciler reconciles a Exporter object
 type ExporterReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Signer *oidc.Signer
+	Scheme   *runtime.Scheme
+	Signer   *oidc.Signer
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=jumpstarter.dev,resources=exporters,verbs=get;list;watch;create;update;patch;delete
@@ -45,6 +48,7 @@ type ExporterReconciler struct {
 // +kubebuilder:rbac:groups=jumpstarter.dev,resources=exporters/finalizers,verbs=update
 // +kubebuilder:rbac:groups=jumpstarter.dev,resources=exporteraccesspolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -65,7 +69,12 @@ func (r *ExporterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		)
 	}
 
-	original := client.MergeFrom(exporter.DeepCopy())
+	if err := r.reconcileCleanupTimeout(ctx, &exporter); err != nil {
+		return RequeueConflict(logger, ctrl.Result{}, err)
+	}
+
+	previous := exporter.DeepCopy()
+	original := client.MergeFrom(previous)
 
 	if err := r.reconcileStatusCredential(ctx, &exporter); err != nil {
 		return ctrl.Result{}, err
@@ -84,9 +93,16 @@ func (r *ExporterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, err
 	}
 
+	r.reconcileStatusConditionsMaintenance(&exporter)
+
+	r.reconcileStatusCleanup(&exporter, &result)
+
+	r.reconcileStatusPhase(&exporter)
+
 	if err := r.Status().Patch(ctx, &exporter, original); err != nil {
 		return RequeueConflict(logger, ctrl.Result{}, err)
 	}
+	recordExporterEvents(r.Recorder, previous, &exporter)
 
 	return result, nil
 }
@@ -124,8 +140,9 @@ func (r *ExporterReconciler) reconcileStatusLeaseRef(
 
 	exporter.Status.LeaseRef = nil
 	for _, lease := range leases.Items {
-		if !lease.Status.Ended && lease.Status.ExporterRef != nil {
-			if lease.Status.ExporterRef.Name == exporter.Name {
+		// scheduled leases reserve their exporter before they begin
+		if !lease.Status.Ended && lease.Status.BeginTime != nil {
+			if lease.HoldsExporter(exporter.Name) {
 				exporter.Status.LeaseRef = &corev1.LocalObjectReference{
 					Name: lease.Name,
 				}
@@ -152,6 +169,84 @@ func (r *ExporterReconciler) reconcileStatusEndpoint(
 	return nil
 }
 
+func (r *ExporterReconciler) reconcileStatusConditionsMaintenance(exporter *jumpstarterdevv1alpha1.Exporter) {
+	if !exporter.UnderMaintenance() {
+		meta.SetStatusCondition(&exporter.Status.Conditions, metav1.Condition{
+			Type:               string(jumpstarterdevv1alpha1.ExporterConditionTypeMaintenance),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: exporter.Generation,
+			Reason:             "InRotation",
+		})
+		return
+	}
+
+	reason := "Maintenance"
+	if exporter.Spec.Maintenance.Drain {
+		reason = "Draining"
+	}
+	meta.SetStatusCondition(&exporter.Status.Conditions, metav1.Condition{
+		Type:               string(jumpstarterdevv1alpha1.ExporterConditionTypeMaintenance),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: exporter.Generation,
+		Reason:             reason,
+		Message:            exporter.MaintenanceMessage(),
+	})
+}
+
+// reconcileCleanupTimeout puts the exporter under maintenance once it failed to report its
+// cleanup done in time, the spec is patched before the status is reconciled as the patch
+// returns the status last written
+func (r *ExporterReconciler) reconcileCleanupTimeout(
+	ctx context.Context,
+	exporter *jumpstarterdevv1alpha1.Exporter,
+) error {
+	logger := log.FromContext(ctx)
+
+	if !exporter.IsCleaning() || exporter.Spec.Cleanup == nil || exporter.UnderMaintenance() ||
+		time.Now().Before(exporter.CleanupDeadline()) {
+		return nil
+	}
+
+	original := client.MergeFrom(exporter.DeepCopy())
+	exporter.FailCleanup(fmt.Sprintf("cleanup after lease %s did not complete within %s",
+		exporter.Status.Cleanup.LeaseRef.Name, exporter.CleanupTimeout()))
+	logger.Info("reconcileCleanupTimeout: putting exporter under maintenance", "reason", exporter.Spec.Maintenance.Reason)
+	if err := r.Patch(ctx, exporter, original); err != nil {
+		return fmt.Errorf("reconcileCleanupTimeout: failed to put exporter under maintenance: %w", err)
+	}
+	r.Recorder.Event(exporter, corev1.EventTypeWarning, "CleanupTimeout", exporter.Spec.Maintenance.Reason)
+	return nil
+}
+
+// reconcileStatusCleanup drops the pending cleanup once it timed out, or once the exporter no
+// longer requires cleanups, and otherwise reconciles the exporter again by the cleanup deadline
+func (r *ExporterReconciler) reconcileStatusCleanup(exporter *jumpstarterdevv1alpha1.Exporter, result *ctrl.Result) {
+	if !exporter.IsCleaning() {
+		return
+	}
+
+	now := time.Now()
+	deadline := exporter.CleanupDeadline()
+	if exporter.Spec.Cleanup == nil || !now.Before(deadline) {
+		exporter.Status.Cleanup = nil
+		return
+	}
+	if result.RequeueAfter == 0 || deadline.Sub(now) < result.RequeueAfter {
+		result.RequeueAfter = deadline.Sub(now)
+	}
+}
+
+func (r *ExporterReconciler) reconcileStatusPhase(exporter *jumpstarterdevv1alpha1.Exporter) {
+	switch {
+	case exporter.IsCleaning():
+		exporter.Status.Phase = jumpstarterdevv1alpha1.ExporterPhaseCleaning
+	case exporter.Status.LeaseRef != nil:
+		exporter.Status.Phase = jumpstarterdevv1alpha1.ExporterPhaseLeased
+	default:
+		exporter.Status.Phase = jumpstarterdevv1alpha1.ExporterPhaseAvailable
+	}
+}
+
 // nolint:unparam
 func (r *ExporterReconciler) reconcileStatusConditionsOnline(
 	_ context.Context,
@@ -214,7 +309,9 @@ func (r *ExporterReconciler) reconcileStatusConditionsOnline(
 func (r *ExporterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&jumpstarterdevv1alpha1.Exporter{}).
-		Owns(&jumpstarterdevv1alpha1.Lease{}).
+		// gang leases are owned by all of their exporters, not just the controlling one
+		Watches(&jumpstarterdevv1alpha1.Lease{}, handler.EnqueueRequestForOwner(
+			mgr.GetScheme(), mgr.GetRESTMapper(), &jumpstarterdevv1alpha1.Exporter{})).
 		Owns(&corev1.Secret{}).
 		Complete(r)
 }
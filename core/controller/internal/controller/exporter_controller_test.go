@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -83,9 +84,10 @@ var _ = Describe("Exporter Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			controllerReconciler := &ExporterReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
-				Signer: signer,
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Signer:   signer,
+				Recorder: record.NewFakeRecorder(100),
 			}
 
 			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -101,9 +103,10 @@ var _ = Describe("Exporter Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			controllerReconciler := &ExporterReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
-				Signer: signer,
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Signer:   signer,
+				Recorder: record.NewFakeRecorder(100),
 			}
 
 			// point the client to a non-existing secret
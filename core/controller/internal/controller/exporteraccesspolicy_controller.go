@@ -0,0 +1,227 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+// quotaUsageRefreshInterval is how often the usage of quotas is refreshed, as the
+// leased time keeps growing while leases are held
+const quotaUsageRefreshInterval = time.Minute
+
+// ExporterAccessPolicyReconciler reconciles a ExporterAccessPolicy object
+type ExporterAccessPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=jumpstarter.dev,resources=exporteraccesspolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=jumpstarter.dev,resources=exporteraccesspolicies/status,verbs=get;update;patch
+
+// Reconcile keeps the status of the exporter access policies up to date
+func (r *ExporterAccessPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var policy jumpstarterdevv1alpha1.ExporterAccessPolicy
+	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(
+			fmt.Errorf("Reconcile: failed to get exporter access policy: %w", err),
+		)
+	}
+
+	if err := r.reconcileStatusMatches(ctx, &policy); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var result ctrl.Result
+	if err := r.reconcileStatusQuotaUsage(ctx, &result, &policy); err != nil {
+		return result, err
+	}
+
+	if err := r.Status().Update(ctx, &policy); err != nil {
+		return RequeueConflict(logger, result, err)
+	}
+
+	return result, nil
+}
+
+// reconcileStatusQuotaUsage reports the usage of the quotas of the policies, for quotas
+// accounted per client only the clients with leases within the period are reported
+func (r *ExporterAccessPolicyReconciler) reconcileStatusQuotaUsage(
+	ctx context.Context,
+	result *ctrl.Result,
+	policy *jumpstarterdevv1alpha1.ExporterAccessPolicy,
+) error {
+	policy.Status.QuotaUsage = nil
+	if !slices.ContainsFunc(policy.Spec.Policies, func(p jumpstarterdevv1alpha1.Policy) bool {
+		return p.Quota != nil || slices.ContainsFunc(p.From, func(from jumpstarterdevv1alpha1.From) bool {
+			return from.ClientGroupRef != nil
+		})
+	}) {
+		return nil
+	}
+
+	ledger, err := newQuotaLedger(ctx, r.Client, policy.Namespace)
+	if err != nil {
+		return fmt.Errorf("reconcileStatusQuotaUsage: %w", err)
+	}
+
+	now := time.Now()
+	for i := range policy.Spec.Policies {
+		// the quota may be the default of a client group
+		defaulted := policyWithGroupDefaults(policy.Spec.Policies[i], ledger.groups)
+		p := &defaulted
+		if p.Quota == nil {
+			continue
+		}
+
+		var clientNames []string
+		if p.Quota.GetScope() == jumpstarterdevv1alpha1.QuotaScopeGroup {
+			clientNames = []string{""}
+		} else {
+			for _, lease := range ledger.leases {
+				name := lease.GetClientName()
+				if slices.Contains(clientNames, name) {
+					continue
+				}
+				matches, err := ledger.matchesPolicy(p, name)
+				if err != nil {
+					return fmt.Errorf("reconcileStatusQuotaUsage: %w", err)
+				}
+				if matches {
+					clientNames = append(clientNames, name)
+				}
+			}
+			slices.Sort(clientNames)
+		}
+
+		for _, name := range clientNames {
+			usage, err := ledger.usage(policy, p, name, "", now)
+			if err != nil {
+				return fmt.Errorf("reconcileStatusQuotaUsage: %w", err)
+			}
+			if name != "" && usage.Leases == 0 && usage.LeasedTime == 0 {
+				continue
+			}
+			policy.Status.QuotaUsage = append(policy.Status.QuotaUsage, jumpstarterdevv1alpha1.QuotaUsage{
+				Policy:     i,
+				Client:     name,
+				Leases:     usage.Leases,
+				LeasedTime: metav1.Duration{Duration: usage.LeasedTime.Truncate(time.Second)},
+			})
+		}
+	}
+
+	result.RequeueAfter = quotaUsageRefreshInterval
+	return nil
+}
+
+// policiesInNamespace enqueues the exporter access policies of the namespace of the object,
+// leases count towards their quotas, while exporters, clients, client groups and other
+// access policies change what they match
+func (r *ExporterAccessPolicyReconciler) policiesInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	var policies jumpstarterdevv1alpha1.ExporterAccessPolicyList
+	if err := r.List(ctx, &policies, client.InNamespace(obj.GetNamespace())); err != nil {
+		logger.Error(err, "policiesInNamespace: failed to list exporter access policies")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, policy := range policies.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name},
+		})
+	}
+	return requests
+}
+
+// leaseUsageChanged only lets through the lease updates that change how the lease counts towards
+// the quotas, filtering out the activity of the lease and the progress of its conditions
+var leaseUsageChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldLease, ok := e.ObjectOld.(*jumpstarterdevv1alpha1.Lease)
+		if !ok {
+			return true
+		}
+		newLease, ok := e.ObjectNew.(*jumpstarterdevv1alpha1.Lease)
+		if !ok {
+			return true
+		}
+		return oldLease.Generation != newLease.Generation ||
+			oldLease.Status.Ended != newLease.Status.Ended ||
+			!slices.Equal(oldLease.GetExporterNames(), newLease.GetExporterNames()) ||
+			!apiequality.Semantic.DeepEqual(oldLease.Status.BeginTime, newLease.Status.BeginTime)
+	},
+}
+
+// clientIdentityChanged only lets through the client updates that change which policies match the
+// client, filtering out the changes to its credential and endpoint
+var clientIdentityChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldClient, ok := e.ObjectOld.(*jumpstarterdevv1alpha1.Client)
+		if !ok {
+			return true
+		}
+		newClient, ok := e.ObjectNew.(*jumpstarterdevv1alpha1.Client)
+		if !ok {
+			return true
+		}
+		return !maps.Equal(oldClient.Labels, newClient.Labels) ||
+			!slices.Equal(oldClient.Status.Groups, newClient.Status.Groups) ||
+			!maps.EqualFunc(oldClient.Status.Claims, newClient.Status.Claims, slices.Equal)
+	},
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ExporterAccessPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&jumpstarterdevv1alpha1.ExporterAccessPolicy{}).
+		Watches(&jumpstarterdevv1alpha1.Lease{}, handler.EnqueueRequestsFromMapFunc(r.policiesInNamespace),
+			builder.WithPredicates(leaseUsageChanged)).
+		// exporters are updated as they report in, only their labels matter here
+		Watches(&jumpstarterdevv1alpha1.Exporter{}, handler.EnqueueRequestsFromMapFunc(r.policiesInNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Watches(&jumpstarterdevv1alpha1.Client{}, handler.EnqueueRequestsFromMapFunc(r.policiesInNamespace),
+			builder.WithPredicates(clientIdentityChanged)).
+		Watches(&jumpstarterdevv1alpha1.ClientGroup{}, handler.EnqueueRequestsFromMapFunc(r.policiesInNamespace)).
+		// the status of the other access policies changes with their quota usage, only their spec matters here
+		Watches(&jumpstarterdevv1alpha1.ExporterAccessPolicy{}, handler.EnqueueRequestsFromMapFunc(r.policiesInNamespace),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
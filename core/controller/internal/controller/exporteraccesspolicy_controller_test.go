@@ -0,0 +1,536 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// quotaPolicy grants all clients access to all exporters, within the given quota
+func quotaPolicy(quota jumpstarterdevv1alpha1.Quota) *jumpstarterdevv1alpha1.ExporterAccessPolicy {
+	return &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "quota",
+			Namespace: "default",
+		},
+		Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+			Policies: []jumpstarterdevv1alpha1.Policy{{
+				From:  []jumpstarterdevv1alpha1.From{{}},
+				Quota: &quota,
+			}},
+		},
+	}
+}
+
+var _ = Describe("ExporterAccessPolicy quotas", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter2DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1", "lease2", "lease3")
+	})
+
+	createPolicy := func(ctx context.Context, policy *jumpstarterdevv1alpha1.ExporterAccessPolicy) {
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+		})
+	}
+
+	When("the client already holds the maximum number of leases", func() {
+		It("should keep further leases pending until a lease ends", func() {
+			ctx := context.Background()
+			createPolicy(ctx, quotaPolicy(jumpstarterdevv1alpha1.Quota{MaximumLeases: ptr.To(1)}))
+
+			lease := leaseDutA2Sec.DeepCopy()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+
+			updatedLease := getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeQuotaExceeded),
+			)).To(BeTrue())
+			Expect(meta.FindStatusCondition(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypePending),
+			).Reason).To(Equal("QuotaExceeded"))
+
+			updatedLease = getLease(ctx, lease.Name)
+			updatedLease.Spec.Release = true
+			Expect(k8sClient.Update(ctx, updatedLease)).To(Succeed())
+			_ = reconcileLease(ctx, updatedLease)
+
+			_ = reconcileLease(ctx, lease2)
+			updatedLease = getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(meta.IsStatusConditionFalse(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeQuotaExceeded),
+			)).To(BeTrue())
+		})
+	})
+
+	When("the lease would exceed the leased time of the period", func() {
+		It("should not be granted", func() {
+			ctx := context.Background()
+			createPolicy(ctx, quotaPolicy(jumpstarterdevv1alpha1.Quota{
+				MaximumLeasedTime: &metav1.Duration{Duration: time.Hour},
+			}))
+
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Duration.Duration = 2 * time.Hour
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeQuotaExceeded),
+			)).To(BeTrue())
+		})
+
+		It("should not be extended beyond it", func() {
+			ctx := context.Background()
+			createPolicy(ctx, quotaPolicy(jumpstarterdevv1alpha1.Quota{
+				MaximumLeasedTime: &metav1.Duration{Duration: time.Hour},
+			}))
+
+			lease := leaseDutA2Sec.DeepCopy()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+
+			Expect(ValidateLeaseDuration(ctx, k8sClient, updatedLease, 30*time.Minute)).To(Succeed())
+			Expect(ValidateLeaseDuration(ctx, k8sClient, updatedLease, 2*time.Hour)).To(
+				MatchError(ErrLeaseDurationRejected))
+		})
+	})
+
+	When("reconciling a policy with quotas", func() {
+		It("should report the usage of each client", func() {
+			ctx := context.Background()
+			policy := quotaPolicy(jumpstarterdevv1alpha1.Quota{MaximumLeases: ptr.To(2)})
+			createPolicy(ctx, policy)
+
+			lease := leaseDutA2Sec.DeepCopy()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			policyReconciler := &ExporterAccessPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			result, err := policyReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(quotaUsageRefreshInterval))
+
+			var updatedPolicy jumpstarterdevv1alpha1.ExporterAccessPolicy
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: policy.Namespace, Name: policy.Name,
+			}, &updatedPolicy)).To(Succeed())
+			Expect(updatedPolicy.Status.QuotaUsage).To(HaveLen(1))
+			Expect(updatedPolicy.Status.QuotaUsage[0].Policy).To(Equal(0))
+			Expect(updatedPolicy.Status.QuotaUsage[0].Client).To(Equal(testClient.Name))
+			Expect(updatedPolicy.Status.QuotaUsage[0].Leases).To(Equal(1))
+		})
+	})
+})
+
+var _ = Describe("TimeWindows", func() {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	Expect(err).NotTo(HaveOccurred())
+	// 2024-01-02 was a Tuesday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, berlin)
+	}
+
+	When("a maintenance window is denied", func() {
+		windows := &jumpstarterdevv1alpha1.TimeWindows{
+			TimeZone: "Europe/Berlin",
+			Deny: []jumpstarterdevv1alpha1.TimeWindow{{
+				Days:  []jumpstarterdevv1alpha1.Weekday{"Tuesday"},
+				Start: "08:00",
+				End:   "10:00",
+			}},
+		}
+
+		It("should not apply during the window", func() {
+			Expect(windows.AllowedAt(at(2, 7, 59))).To(BeTrue())
+			Expect(windows.AllowedAt(at(2, 9, 0))).To(BeFalse())
+			Expect(windows.AllowedAt(at(2, 10, 0))).To(BeTrue())
+			Expect(windows.AllowedAt(at(3, 9, 0))).To(BeTrue())
+		})
+
+		It("should report when a lease crosses into it", func() {
+			Expect(windows.AllowedUntil(at(2, 7, 0), at(2, 12, 0))).To(BeTemporally("==", at(2, 8, 0)))
+			Expect(windows.AllowedUntil(at(2, 10, 0), at(9, 7, 0))).To(BeTemporally("==", at(9, 7, 0)))
+			Expect(windows.AllowedUntil(at(2, 10, 0), at(9, 9, 0))).To(BeTemporally("==", at(9, 8, 0)))
+		})
+	})
+
+	When("only the time outside business hours is allowed", func() {
+		windows := &jumpstarterdevv1alpha1.TimeWindows{
+			TimeZone: "Europe/Berlin",
+			Allow: []jumpstarterdevv1alpha1.TimeWindow{{
+				Days:  []jumpstarterdevv1alpha1.Weekday{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+				Start: "18:00",
+				End:   "08:00",
+			}, {
+				Days: []jumpstarterdevv1alpha1.Weekday{"Saturday", "Sunday"},
+			}},
+		}
+
+		It("should apply overnight", func() {
+			Expect(windows.AllowedAt(at(2, 12, 0))).To(BeFalse())
+			Expect(windows.AllowedAt(at(2, 23, 0))).To(BeTrue())
+			Expect(windows.AllowedAt(at(3, 7, 59))).To(BeTrue())
+			Expect(windows.AllowedAt(at(3, 8, 0))).To(BeFalse())
+			Expect(windows.AllowedAt(at(6, 12, 0))).To(BeTrue())
+			// the weekend windows end at midnight, there is no overnight window starting on Sunday
+			Expect(windows.AllowedUntil(at(5, 20, 0), at(8, 12, 0))).To(BeTemporally("==", at(8, 0, 0)))
+		})
+	})
+})
+
+var _ = Describe("ExporterAccessPolicy time windows", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter2DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1", "lease2", "lease3")
+	})
+
+	windowPolicy := func(windows jumpstarterdevv1alpha1.TimeWindows) *jumpstarterdevv1alpha1.ExporterAccessPolicy {
+		return &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "time-windows",
+				Namespace: "default",
+			},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				Policies: []jumpstarterdevv1alpha1.Policy{{
+					From:        []jumpstarterdevv1alpha1.From{{}},
+					TimeWindows: &windows,
+				}},
+			},
+		}
+	}
+	// alwaysDenied denies every day, all day long
+	alwaysDenied := jumpstarterdevv1alpha1.TimeWindows{
+		Deny: []jumpstarterdevv1alpha1.TimeWindow{{}},
+	}
+
+	When("the lease is requested during a forbidden window", func() {
+		It("should be unsatisfiable", func() {
+			ctx := context.Background()
+			policy := windowPolicy(alwaysDenied)
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+			})
+
+			lease := leaseDutA2Sec.DeepCopy()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			Expect(meta.FindStatusCondition(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable),
+			).Reason).To(Equal("OutsideTimeWindow"))
+		})
+	})
+
+	When("an acquired lease crosses into a forbidden window", func() {
+		It("should be ended", func() {
+			ctx := context.Background()
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Duration.Duration = time.Hour
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.BeginTime).NotTo(BeNil())
+
+			policy := windowPolicy(alwaysDenied)
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+			})
+
+			_ = reconcileLease(ctx, lease)
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.Ended).To(BeTrue())
+			Expect(meta.FindStatusCondition(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeReady),
+			).Reason).To(Equal("OutsideTimeWindow"))
+		})
+	})
+
+	When("extending a lease into a forbidden window", func() {
+		It("should be rejected", func() {
+			ctx := context.Background()
+			now := time.Now().UTC()
+			policy := windowPolicy(jumpstarterdevv1alpha1.TimeWindows{
+				Deny: []jumpstarterdevv1alpha1.TimeWindow{{
+					Start: now.Add(2 * time.Hour).Format("15:04"),
+					End:   now.Add(3 * time.Hour).Format("15:04"),
+				}},
+			})
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+			})
+
+			lease := leaseDutA2Sec.DeepCopy()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.BeginTime).NotTo(BeNil())
+
+			Expect(ValidateLeaseDuration(ctx, k8sClient, updatedLease, time.Hour)).To(Succeed())
+			Expect(ValidateLeaseDuration(ctx, k8sClient, updatedLease, 4*time.Hour)).To(
+				MatchError(ErrLeaseDurationRejected))
+		})
+	})
+})
+
+var _ = Describe("ExporterAccessPolicy match diagnostics", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter3DutB)
+	})
+	AfterEach(func() {
+		deleteExporters(context.Background(), testExporter1DutA, testExporter3DutB)
+	})
+
+	createPolicy := func(ctx context.Context, policy *jumpstarterdevv1alpha1.ExporterAccessPolicy) {
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+		})
+	}
+
+	// reconcilePolicy reconciles the policy and returns its updated status
+	reconcilePolicy := func(ctx context.Context, name string) jumpstarterdevv1alpha1.ExporterAccessPolicyStatus {
+		policyReconciler := &ExporterAccessPolicyReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+		_, err := policyReconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: name},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var updatedPolicy jumpstarterdevv1alpha1.ExporterAccessPolicy
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, &updatedPolicy)).
+			To(Succeed())
+		return updatedPolicy.Status
+	}
+
+	It("should report the exporters and clients matched, and the selectors matching nothing", func() {
+		ctx := context.Background()
+		createPolicy(ctx, &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "dut-a", Namespace: "default"},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				ExporterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"dut": "a"}},
+				Policies: []jumpstarterdevv1alpha1.Policy{{
+					From: []jumpstarterdevv1alpha1.From{{}, {
+						ClientSelector: metav1.LabelSelector{MatchLabels: map[string]string{"role": "nobody"}},
+					}},
+				}},
+			},
+		})
+
+		status := reconcilePolicy(ctx, "dut-a")
+		Expect(status.Exporters).To(Equal([]string{testExporter1DutA.Name}))
+		Expect(status.Matches).To(HaveLen(1))
+		Expect(status.Matches[0].Clients).To(ContainElement(testClient.Name))
+		Expect(status.UnmatchedSelectors).To(Equal([]string{"policies[0].from[1]"}))
+		Expect(status.Conflicts).To(BeEmpty())
+
+		ready := meta.FindStatusCondition(status.Conditions,
+			string(jumpstarterdevv1alpha1.ExporterAccessPolicyConditionTypeReady))
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+		Expect(ready.Message).To(ContainSubstring("1 selectors match nothing"))
+	})
+
+	It("should report the conflicts with other access policies", func() {
+		ctx := context.Background()
+		createPolicy(ctx, &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "everything", Namespace: "default"},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				Policies: []jumpstarterdevv1alpha1.Policy{{
+					Priority: 1,
+					From:     []jumpstarterdevv1alpha1.From{{}},
+				}},
+			},
+		})
+		createPolicy(ctx, &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "dut-b", Namespace: "default"},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				ExporterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"dut": "b"}},
+				Policies: []jumpstarterdevv1alpha1.Policy{{
+					Priority: 10,
+					From:     []jumpstarterdevv1alpha1.From{{}},
+				}},
+			},
+		})
+
+		status := reconcilePolicy(ctx, "dut-b")
+		Expect(status.Conflicts).To(HaveLen(1))
+		Expect(status.Conflicts[0].Priority).To(Equal(10))
+		Expect(status.Conflicts[0].ConflictingPolicy).To(Equal("everything"))
+		Expect(status.Conflicts[0].ConflictingPriority).To(Equal(1))
+		Expect(status.Conflicts[0].Exporters).To(Equal([]string{testExporter3DutB.Name}))
+		Expect(status.Conflicts[0].Clients).To(ContainElement(testClient.Name))
+	})
+
+	It("should report the conflicts between its own policies", func() {
+		ctx := context.Background()
+		createPolicy(ctx, &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "tiers", Namespace: "default"},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				Policies: []jumpstarterdevv1alpha1.Policy{{
+					Priority: 10,
+					From:     []jumpstarterdevv1alpha1.From{{}},
+				}, {
+					Priority: 1,
+					From:     []jumpstarterdevv1alpha1.From{{}},
+				}, {
+					Priority: 1,
+					From:     []jumpstarterdevv1alpha1.From{{}},
+				}},
+			},
+		})
+
+		status := reconcilePolicy(ctx, "tiers")
+		Expect(status.Conflicts).To(HaveLen(2))
+		for i, conflict := range status.Conflicts {
+			Expect(conflict.Policy).To(Equal(0))
+			Expect(conflict.Priority).To(Equal(10))
+			Expect(conflict.ConflictingPolicy).To(Equal("tiers"))
+			Expect(conflict.ConflictingIndex).To(Equal(i + 1))
+			Expect(conflict.ConflictingPriority).To(Equal(1))
+			Expect(conflict.Clients).To(ContainElement(testClient.Name))
+		}
+
+		ready := meta.FindStatusCondition(status.Conditions,
+			string(jumpstarterdevv1alpha1.ExporterAccessPolicyConditionTypeReady))
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Message).To(ContainSubstring("2 pairs of its policies have conflicting priorities"))
+	})
+
+	It("should only be enqueued by the lease and client updates changing quotas and matches", func() {
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Status.BeginTime = &metav1.Time{Time: time.Now()}
+		lease.Status.ExporterRef = &corev1.LocalObjectReference{Name: testExporter1DutA.Name}
+
+		active := lease.DeepCopy()
+		active.Status.LastActivityTime = &metav1.Time{Time: time.Now().Add(time.Minute)}
+		Expect(leaseUsageChanged.Update(event.UpdateEvent{ObjectOld: lease, ObjectNew: active})).To(BeFalse())
+		ended := lease.DeepCopy()
+		ended.Status.Ended = true
+		Expect(leaseUsageChanged.Update(event.UpdateEvent{ObjectOld: lease, ObjectNew: ended})).To(BeTrue())
+
+		jclient := testClient.DeepCopy()
+		endpoint := jclient.DeepCopy()
+		endpoint.Status.Endpoint = "grpc.example.com:443"
+		Expect(clientIdentityChanged.Update(event.UpdateEvent{ObjectOld: jclient, ObjectNew: endpoint})).To(BeFalse())
+		grouped := jclient.DeepCopy()
+		grouped.Status.Groups = []string{"ci"}
+		Expect(clientIdentityChanged.Update(event.UpdateEvent{ObjectOld: jclient, ObjectNew: grouped})).To(BeTrue())
+		claimed := jclient.DeepCopy()
+		claimed.Status.Claims = map[string][]string{"team": {"ci"}}
+		Expect(clientIdentityChanged.Update(event.UpdateEvent{ObjectOld: jclient, ObjectNew: claimed})).To(BeTrue())
+	})
+
+	It("should not be ready when referencing missing client groups", func() {
+		ctx := context.Background()
+		createPolicy(ctx, &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "missing-group", Namespace: "default"},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				Policies: []jumpstarterdevv1alpha1.Policy{{
+					From: []jumpstarterdevv1alpha1.From{{
+						ClientGroupRef: &corev1.LocalObjectReference{Name: "missing"},
+					}},
+				}},
+			},
+		})
+
+		status := reconcilePolicy(ctx, "missing-group")
+		ready := meta.FindStatusCondition(status.Conditions,
+			string(jumpstarterdevv1alpha1.ExporterAccessPolicyConditionTypeReady))
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("ClientGroupNotFound"))
+	})
+
+	It("should not be ready with invalid selectors", func() {
+		ctx := context.Background()
+		createPolicy(ctx, &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "default"},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				ExporterSelector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "dut",
+					Operator: "Unknown",
+				}}},
+			},
+		})
+
+		status := reconcilePolicy(ctx, "invalid")
+		ready := meta.FindStatusCondition(status.Conditions,
+			string(jumpstarterdevv1alpha1.ExporterAccessPolicyConditionTypeReady))
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("InvalidSelector"))
+	})
+})
@@ -24,3 +24,17 @@ func MatchingActiveLeases() client.ListOption {
 		Selector: labels.Everything().Add(*requirement),
 	}
 }
+
+func MatchingEndedLeases() client.ListOption {
+	requirement, err := labels.NewRequirement(
+		string(jumpstarterdevv1alpha1.LeaseLabelEnded),
+		selection.Equals,
+		[]string{jumpstarterdevv1alpha1.LeaseLabelEndedValue},
+	)
+
+	utilruntime.Must(err)
+
+	return client.MatchingLabelsSelector{
+		Selector: labels.Everything().Add(*requirement),
+	}
+}
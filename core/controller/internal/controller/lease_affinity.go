@@ -0,0 +1,126 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// topologyDomains are the values of a topology label among the exporters held by the leases
+// referenced by an affinity term
+type topologyDomains struct {
+	TopologyKey string
+	Values      map[string]bool
+}
+
+// Contains returns true if the exporter belongs to one of the topology domains
+func (d *topologyDomains) Contains(exporter *jumpstarterdevv1alpha1.Exporter) bool {
+	value, ok := exporter.Labels[d.TopologyKey]
+	return ok && d.Values[value]
+}
+
+// leaseTopologyDomains returns the topology domains of each of the affinity terms, only
+// accounting the exporters held, or reserved, by the active leases among the referenced ones
+func leaseTopologyDomains(
+	ctx context.Context,
+	c client.Reader,
+	lease *jumpstarterdevv1alpha1.Lease,
+	terms []jumpstarterdevv1alpha1.LeaseAffinityTerm,
+	activeLeases []jumpstarterdevv1alpha1.Lease,
+) ([]topologyDomains, error) {
+	exporters := make(map[string]*jumpstarterdevv1alpha1.Exporter)
+
+	var domains []topologyDomains
+	for _, term := range terms {
+		domain := topologyDomains{
+			TopologyKey: term.TopologyKey,
+			Values:      make(map[string]bool),
+		}
+		for _, other := range activeLeases {
+			if other.Name == lease.Name || !slices.Contains(term.Leases, other.Name) {
+				continue
+			}
+			for _, name := range other.GetExporterNames() {
+				exporter, ok := exporters[name]
+				if !ok {
+					exporter = &jumpstarterdevv1alpha1.Exporter{}
+					if err := c.Get(ctx, types.NamespacedName{
+						Namespace: lease.Namespace,
+						Name:      name,
+					}, exporter); err != nil {
+						if apierrors.IsNotFound(err) {
+							continue
+						}
+						return nil, fmt.Errorf("leaseTopologyDomains: failed to get exporter: %w", err)
+					}
+					exporters[name] = exporter
+				}
+				if value, ok := exporter.Labels[term.TopologyKey]; ok {
+					domain.Values[value] = true
+				}
+			}
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// attachLeaseAffinity counts the affinity terms of the lease satisfied by each of the approved
+// exporters, exporters satisfy affinity terms when they are in the same topology domain as
+// the exporters of the referenced leases, and anti-affinity terms when they are not
+func attachLeaseAffinity(
+	ctx context.Context,
+	c client.Reader,
+	lease *jumpstarterdevv1alpha1.Lease,
+	exporters []ApprovedExporter,
+	activeLeases []jumpstarterdevv1alpha1.Lease,
+) ([]ApprovedExporter, error) {
+	if len(lease.Spec.Affinity) == 0 && len(lease.Spec.AntiAffinity) == 0 {
+		return exporters, nil
+	}
+
+	affinity, err := leaseTopologyDomains(ctx, c, lease, lease.Spec.Affinity, activeLeases)
+	if err != nil {
+		return nil, err
+	}
+	antiAffinity, err := leaseTopologyDomains(ctx, c, lease, lease.Spec.AntiAffinity, activeLeases)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range exporters {
+		exporters[i].Affinity = 0
+		for _, domain := range affinity {
+			if domain.Contains(&exporters[i].Exporter) {
+				exporters[i].Affinity++
+			}
+		}
+		for _, domain := range antiAffinity {
+			if !domain.Contains(&exporters[i].Exporter) {
+				exporters[i].Affinity++
+			}
+		}
+	}
+	return exporters, nil
+}
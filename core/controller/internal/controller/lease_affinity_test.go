@@ -0,0 +1,119 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Lease affinity", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter2DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1", "lease2", "lease3")
+	})
+
+	// acquireLeaseDutB acquires lease2 on the only exporter of dut b, in rack-1
+	acquireLeaseDutB := func(ctx context.Context) {
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Name = "lease2"
+		lease.Spec.Selector.MatchLabels["dut"] = "b"
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+		Expect(getLease(ctx, lease.Name).Status.ExporterRef.Name).To(Equal(testExporter3DutB.Name))
+	}
+
+	rackTerm := []jumpstarterdevv1alpha1.LeaseAffinityTerm{{
+		Leases:      []string{"lease2"},
+		TopologyKey: "rack",
+	}}
+
+	When("the lease has affinity to another lease", func() {
+		It("should acquire an exporter in the same topology domain", func() {
+			ctx := context.Background()
+			setExporterLabels(ctx, testExporter1DutA.Name, map[string]string{"rack": "rack-2"})
+			setExporterLabels(ctx, testExporter2DutA.Name, map[string]string{"rack": "rack-1"})
+			setExporterLabels(ctx, testExporter3DutB.Name, map[string]string{"rack": "rack-1"})
+			acquireLeaseDutB(ctx)
+
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Affinity = rackTerm
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter2DutA.Name))
+		})
+
+		It("should still acquire an exporter when none is in the same topology domain", func() {
+			ctx := context.Background()
+			setExporterLabels(ctx, testExporter1DutA.Name, map[string]string{"rack": "rack-2"})
+			setExporterLabels(ctx, testExporter2DutA.Name, map[string]string{"rack": "rack-2"})
+			setExporterLabels(ctx, testExporter3DutB.Name, map[string]string{"rack": "rack-1"})
+			acquireLeaseDutB(ctx)
+
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Affinity = rackTerm
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter1DutA.Name))
+		})
+	})
+
+	When("the lease has anti-affinity to another lease", func() {
+		It("should acquire an exporter in another topology domain", func() {
+			ctx := context.Background()
+			setExporterLabels(ctx, testExporter1DutA.Name, map[string]string{"rack": "rack-1"})
+			setExporterLabels(ctx, testExporter2DutA.Name, map[string]string{"rack": "rack-2"})
+			setExporterLabels(ctx, testExporter3DutB.Name, map[string]string{"rack": "rack-1"})
+			acquireLeaseDutB(ctx)
+
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.AntiAffinity = rackTerm
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter2DutA.Name))
+		})
+	})
+})
+
+// setExporterLabels adds the labels to the exporter
+func setExporterLabels(ctx context.Context, name string, labels map[string]string) {
+	exporter := getExporter(ctx, name)
+	for key, value := range labels {
+		exporter.Labels[key] = value
+	}
+	Expect(k8sClient.Update(ctx, exporter)).To(Succeed())
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// leaseApproval is the outcome of the policy approval of the slots of a pending lease
+type leaseApproval struct {
+	// namespace is the namespace of the lease
+	namespace string
+	// generation is the generation of the lease the slots were approved for
+	generation int64
+	// epoch is the epoch of the namespace the slots were approved in
+	epoch uint64
+	// expires is when the approval must be computed again, as quotas over a period free up
+	// with time, and changes to clients are not watched
+	expires time.Time
+	// slots are the approved slots, nil if the lease cannot be satisfied
+	slots []LeaseSlot
+}
+
+// leaseApprovals caches the policy approval of pending leases, so that scheduling the queue
+// of a namespace does not approve every pending lease again on each reconciliation. The
+// approvals of a namespace are dropped whenever its exporters, leases or policies change.
+type leaseApprovals struct {
+	mu        sync.Mutex
+	epochs    map[string]uint64
+	approvals map[types.UID]leaseApproval
+}
+
+// epoch returns the current epoch of the namespace, to be read before approving a lease
+func (a *leaseApprovals) epoch(namespace string) uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.epochs[namespace]
+}
+
+// invalidate drops the approvals of the namespace, along with those still being computed
+func (a *leaseApprovals) invalidate(namespace string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.epochs == nil {
+		a.epochs = make(map[string]uint64)
+	}
+	a.epochs[namespace]++
+	for uid, approval := range a.approvals {
+		if approval.namespace == namespace {
+			delete(a.approvals, uid)
+		}
+	}
+}
+
+// get returns the approved slots of the lease, and whether they are still valid
+func (a *leaseApprovals) get(lease *jumpstarterdevv1alpha1.Lease, now time.Time) ([]LeaseSlot, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	approval, ok := a.approvals[lease.UID]
+	if !ok || approval.generation != lease.Generation ||
+		approval.epoch != a.epochs[lease.Namespace] || !now.Before(approval.expires) {
+		return nil, false
+	}
+	return approval.slots, true
+}
+
+// put records the approved slots of the lease, unless the namespace changed since the given
+// epoch was read
+func (a *leaseApprovals) put(lease *jumpstarterdevv1alpha1.Lease, epoch uint64, slots []LeaseSlot, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if epoch != a.epochs[lease.Namespace] {
+		return
+	}
+	if a.approvals == nil {
+		a.approvals = make(map[types.UID]leaseApproval)
+	}
+	a.approvals[lease.UID] = leaseApproval{
+		namespace:  lease.Namespace,
+		generation: lease.Generation,
+		epoch:      epoch,
+		expires:    now.Add(pendingLeaseResyncInterval),
+		slots:      slots,
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// requestExporterCleanup asks the exporters held by the lease to clean up as the lease ends,
+// the exporters are updated before the lease so that they are never seen available in between
+func (r *LeaseReconciler) requestExporterCleanup(
+	ctx context.Context,
+	original *jumpstarterdevv1alpha1.Lease,
+	lease *jumpstarterdevv1alpha1.Lease,
+) error {
+	logger := log.FromContext(ctx)
+
+	// leases ending before they began never handed the exporters over to their client
+	if original.Status.Ended || !lease.Status.Ended || lease.Status.BeginTime == nil {
+		return nil
+	}
+
+	now := time.Now()
+	for _, name := range lease.GetExporterNames() {
+		var exporter jumpstarterdevv1alpha1.Exporter
+		if err := r.Get(ctx, types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      name,
+		}, &exporter); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("requestExporterCleanup: failed to get exporter %s: %w", name, err)
+		}
+
+		patch := client.MergeFrom(exporter.DeepCopy())
+		if !exporter.RequestCleanup(lease.Name, now) {
+			continue
+		}
+		logger.Info("Requesting exporter cleanup", "lease", lease.Name, "exporter", name)
+		if err := r.Status().Patch(ctx, &exporter, patch); err != nil {
+			return fmt.Errorf("requestExporterCleanup: failed to update exporter %s status: %w", name, err)
+		}
+		r.Recorder.Eventf(&exporter, corev1.EventTypeNormal, "CleanupRequested",
+			"The exporter has been asked to clean up after lease %s", lease.Name)
+	}
+	return nil
+}
+
+// cleaningExporter returns the first of the exporters held by the lease that is still cleaning
+// up after its previous lease, or nil if none is
+func (r *LeaseReconciler) cleaningExporter(
+	ctx context.Context,
+	lease *jumpstarterdevv1alpha1.Lease,
+) (*jumpstarterdevv1alpha1.Exporter, error) {
+	for _, name := range lease.GetExporterNames() {
+		var exporter jumpstarterdevv1alpha1.Exporter
+		if err := r.Get(ctx, types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      name,
+		}, &exporter); err != nil {
+			if apierrors.IsNotFound(err) {
+				// the lease reports the missing exporter as lost
+				continue
+			}
+			return nil, fmt.Errorf("cleaningExporter: failed to get exporter %s: %w", name, err)
+		}
+		if exporter.IsCleaning() {
+			return &exporter, nil
+		}
+	}
+	return nil, nil
+}
+
+// leasesWaitingForCleanup enqueues the leases that have been assigned the exporter, but
+// have not begun yet, so that they begin as soon as the exporter is done cleaning up
+func (r *LeaseReconciler) leasesWaitingForCleanup(
+	ctx context.Context,
+	exporter *jumpstarterdevv1alpha1.Exporter,
+) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	leases, err := r.ListActiveLeases(ctx, exporter.Namespace)
+	if err != nil {
+		logger.Error(err, "leasesWaitingForCleanup: failed to list active leases", "namespace", exporter.Namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, lease := range leases.Items {
+		if lease.Status.Ended || lease.Status.BeginTime != nil || !lease.HoldsExporter(exporter.Name) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      lease.Name,
+		}})
+	}
+	return requests
+}
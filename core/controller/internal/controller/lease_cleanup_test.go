@@ -0,0 +1,200 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/the78mole/jumpstarter-mono/core/controller/internal/oidc"
+)
+
+var _ = Describe("Exporter cleanup", func() {
+	BeforeEach(func() {
+		ctx := context.Background()
+		exporter := testExporter1DutA.DeepCopy()
+		exporter.Spec.Cleanup = &jumpstarterdevv1alpha1.ExporterCleanup{}
+		createExporters(ctx, exporter, testExporter2DutA)
+		setExporterOnlineConditions(ctx, testExporter1DutA.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA)
+		deleteLeases(ctx, "lease1", "lease2")
+	})
+
+	// reconcileExporter reconciles the exporter on its own, as done once its cleanup deadline passes
+	reconcileExporter := func(ctx context.Context, name string) reconcile.Result {
+		signer, err := oidc.NewSignerFromSeed([]byte{}, "https://example.com", "dummy")
+		Expect(err).NotTo(HaveOccurred())
+
+		exporterReconciler := &ExporterReconciler{
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Signer:   signer,
+			Recorder: record.NewFakeRecorder(100),
+		}
+		result, err := exporterReconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: name},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	// acquireAndRelease acquires the exporter with lease1, then releases the lease
+	acquireAndRelease := func(ctx context.Context) {
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Spec.Duration = metav1.Duration{Duration: time.Hour}
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+		Expect(getLease(ctx, lease.Name).Status.ExporterRef.Name).To(Equal(testExporter1DutA.Name))
+		Expect(getExporter(ctx, testExporter1DutA.Name).Status.Phase).
+			To(Equal(jumpstarterdevv1alpha1.ExporterPhaseLeased))
+
+		updatedLease := getLease(ctx, lease.Name)
+		updatedLease.Spec.Release = true
+		Expect(k8sClient.Update(ctx, updatedLease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+		Expect(getLease(ctx, lease.Name).Status.Ended).To(BeTrue())
+	}
+
+	// reportCleanupDone clears the pending cleanup, as done by the exporter reporting it
+	reportCleanupDone := func(ctx context.Context) {
+		exporter := getExporter(ctx, testExporter1DutA.Name)
+		exporter.Status.Cleanup = nil
+		Expect(k8sClient.Status().Update(ctx, exporter)).To(Succeed())
+	}
+
+	It("should keep the exporter out of rotation until it is done cleaning up", func() {
+		ctx := context.Background()
+		acquireAndRelease(ctx)
+
+		exporter := getExporter(ctx, testExporter1DutA.Name)
+		Expect(exporter.Status.Cleanup).NotTo(BeNil())
+		Expect(exporter.Status.Cleanup.LeaseRef.Name).To(Equal("lease1"))
+		Expect(exporter.Status.Phase).To(Equal(jumpstarterdevv1alpha1.ExporterPhaseCleaning))
+
+		lease2 := leaseDutA2Sec.DeepCopy()
+		lease2.Name = "lease2"
+		Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+		_ = reconcileLease(ctx, lease2)
+		Expect(getLease(ctx, lease2.Name).Status.ExporterRef).To(BeNil())
+
+		reportCleanupDone(ctx)
+		_ = reconcileLease(ctx, lease2)
+		Expect(getLease(ctx, lease2.Name).Status.ExporterRef.Name).To(Equal(testExporter1DutA.Name))
+		Expect(getExporter(ctx, testExporter1DutA.Name).Status.Phase).
+			To(Equal(jumpstarterdevv1alpha1.ExporterPhaseLeased))
+	})
+
+	It("should hold back the bookings of the exporter until it is done cleaning up", func() {
+		ctx := context.Background()
+		acquireAndRelease(ctx)
+
+		now := time.Now()
+		lease2 := leaseDutA2Sec.DeepCopy()
+		lease2.Name = "lease2"
+		lease2.Spec.BeginTime = &metav1.Time{Time: now.Add(-time.Second)}
+		lease2.Spec.EndTime = &metav1.Time{Time: now.Add(time.Hour)}
+		Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+		result := reconcileLease(ctx, lease2)
+
+		updatedLease := getLease(ctx, lease2.Name)
+		Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter1DutA.Name))
+		Expect(updatedLease.Status.BeginTime).To(BeNil())
+		pending := meta.FindStatusCondition(updatedLease.Status.Conditions,
+			string(jumpstarterdevv1alpha1.LeaseConditionTypePending))
+		Expect(pending.Reason).To(Equal("ExporterCleaning"))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", jumpstarterdevv1alpha1.DefaultCleanupTimeout))
+
+		reportCleanupDone(ctx)
+		_ = reconcileLease(ctx, lease2)
+		Expect(getLease(ctx, lease2.Name).Status.BeginTime).NotTo(BeNil())
+	})
+
+	It("should put the exporter under maintenance once the cleanup times out", func() {
+		ctx := context.Background()
+		acquireAndRelease(ctx)
+
+		result := reconcileExporter(ctx, testExporter1DutA.Name)
+		Expect(result.RequeueAfter).To(BeNumerically("<=", jumpstarterdevv1alpha1.DefaultCleanupTimeout))
+
+		exporter := getExporter(ctx, testExporter1DutA.Name)
+		exporter.Status.Cleanup.RequestedAt = metav1.NewTime(time.Now().Add(-time.Hour))
+		Expect(k8sClient.Status().Update(ctx, exporter)).To(Succeed())
+		_ = reconcileExporter(ctx, testExporter1DutA.Name)
+
+		exporter = getExporter(ctx, testExporter1DutA.Name)
+		Expect(exporter.Status.Cleanup).To(BeNil())
+		Expect(exporter.Status.Phase).To(Equal(jumpstarterdevv1alpha1.ExporterPhaseAvailable))
+		Expect(exporter.UnderMaintenance()).To(BeTrue())
+		Expect(exporter.Spec.Maintenance.Owner).To(Equal(jumpstarterdevv1alpha1.CleanupMaintenanceOwner))
+		Expect(exporter.Spec.Maintenance.Reason).To(ContainSubstring("lease1"))
+	})
+
+	It("should not ask exporters without a cleanup to clean up", func() {
+		ctx := context.Background()
+		exporter := getExporter(ctx, testExporter1DutA.Name)
+		exporter.Spec.Cleanup = nil
+		Expect(k8sClient.Update(ctx, exporter)).To(Succeed())
+
+		acquireAndRelease(ctx)
+
+		exporter = getExporter(ctx, testExporter1DutA.Name)
+		Expect(exporter.Status.Cleanup).To(BeNil())
+		Expect(exporter.Status.Phase).To(Equal(jumpstarterdevv1alpha1.ExporterPhaseAvailable))
+	})
+
+	It("should not ask the exporters of leases that never began to clean up", func() {
+		ctx := context.Background()
+		setExporterOnlineConditions(ctx, testExporter1DutA.Name, metav1.ConditionFalse)
+
+		lease := leaseDutA2Sec.DeepCopy()
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+
+		updatedLease := getLease(ctx, lease.Name)
+		updatedLease.Spec.Release = true
+		Expect(k8sClient.Update(ctx, updatedLease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+
+		Expect(getLease(ctx, lease.Name).Status.Ended).To(BeTrue())
+		Expect(getExporter(ctx, testExporter1DutA.Name).Status.Cleanup).To(BeNil())
+	})
+
+	It("should describe the exporters cleaning up as queued", func() {
+		exporter := testExporter1DutA.DeepCopy()
+		exporter.Status.Cleanup = &jumpstarterdevv1alpha1.ExporterCleanupStatus{
+			LeaseRef:    corev1.LocalObjectReference{Name: "lease1"},
+			RequestedAt: metav1.Now(),
+		}
+		evaluation := &ExporterEvaluation{}
+		evaluation.include(leaseDutA2Sec, ApprovedExporter{Exporter: *exporter})
+		Expect(evaluation.Included).To(BeTrue())
+		Expect(evaluation.Reason).To(Equal("Cleaning"))
+	})
+})
@@ -25,21 +25,30 @@ import (
 
 	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // LeaseReconciler reconciles a Lease object
 type LeaseReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// approvals caches the policy approval of the pending leases being scheduled
+	approvals leaseApprovals
 }
 
 // ApprovedExporter represents an exporter that has been approved for leasing,
@@ -51,11 +60,18 @@ type ApprovedExporter struct {
 	ExistingLease *jumpstarterdevv1alpha1.Lease
 	// Policy represents the access policy that approved this exporter
 	Policy jumpstarterdevv1alpha1.Policy
+	// AccessPolicy is the exporter access policy the policy belongs to, or nil if no policies exist
+	AccessPolicy *jumpstarterdevv1alpha1.ExporterAccessPolicy
+	// PolicyIndex is the index of the policy among the policies of the exporter access policy
+	PolicyIndex int
+	// Affinity is the number of affinity and anti-affinity terms of the lease the exporter satisfies
+	Affinity int
 }
 
 // +kubebuilder:rbac:groups=jumpstarter.dev,resources=leases,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=jumpstarter.dev,resources=leases/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=jumpstarter.dev,resources=leases/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -77,12 +93,22 @@ func (r *LeaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		)
 	}
 
+	original := lease.DeepCopy()
+
 	var result ctrl.Result
 	if err := r.reconcileStatusExporterRef(ctx, &result, &lease); err != nil {
 		return result, err
 	}
 
-	if err := r.reconcileStatusBeginTime(ctx, &lease); err != nil {
+	if err := r.reconcileStatusMaintenance(ctx, &lease); err != nil {
+		return result, err
+	}
+
+	if err := r.reconcileStatusExporterLost(ctx, &result, &lease); err != nil {
+		return result, err
+	}
+
+	if err := r.reconcileStatusBeginTime(ctx, &result, &lease); err != nil {
 		return result, err
 	}
 
@@ -90,9 +116,22 @@ func (r *LeaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return result, err
 	}
 
+	if err := r.reconcileStatusTimeWindow(ctx, &result, &lease); err != nil {
+		return result, err
+	}
+
+	if err := r.reconcileStatusIdle(ctx, &result, &lease); err != nil {
+		return result, err
+	}
+
+	if err := r.requestExporterCleanup(ctx, original, &lease); err != nil {
+		return result, err
+	}
+
 	if err := r.Status().Update(ctx, &lease); err != nil {
 		return RequeueConflict(logger, result, err)
 	}
+	recordLeaseEvents(r.Recorder, original, &lease)
 
 	if lease.Labels == nil {
 		lease.Labels = make(map[string]string)
@@ -101,16 +140,27 @@ func (r *LeaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		lease.Labels[string(jumpstarterdevv1alpha1.LeaseLabelEnded)] = jumpstarterdevv1alpha1.LeaseLabelEndedValue
 	}
 
-	if lease.Status.ExporterRef != nil {
+	// exporters given up by the lease no longer own it
+	lease.OwnerReferences = slices.DeleteFunc(lease.OwnerReferences, func(ref metav1.OwnerReference) bool {
+		return ref.Kind == "Exporter" && !lease.HoldsExporter(ref.Name)
+	})
+
+	for i, name := range lease.GetExporterNames() {
 		var exporter jumpstarterdevv1alpha1.Exporter
 		if err := r.Get(ctx, types.NamespacedName{
 			Namespace: lease.Namespace,
-			Name:      lease.Status.ExporterRef.Name,
+			Name:      name,
 		}, &exporter); err != nil {
 			return result, err
 		}
-		if err := controllerutil.SetControllerReference(&exporter, &lease, r.Scheme); err != nil {
-			return result, fmt.Errorf("Reconcile: failed to update lease controller reference: %w", err)
+		// the first exporter controls the lease, the other members of a gang lease
+		// are plain owners so that they get notified of the lease too
+		if i == 0 {
+			if err := controllerutil.SetControllerReference(&exporter, &lease, r.Scheme); err != nil {
+				return result, fmt.Errorf("Reconcile: failed to update lease controller reference: %w", err)
+			}
+		} else if err := controllerutil.SetOwnerReference(&exporter, &lease, r.Scheme); err != nil {
+			return result, fmt.Errorf("Reconcile: failed to update lease owner reference: %w", err)
 		}
 	}
 
@@ -140,13 +190,14 @@ func (r *LeaseReconciler) reconcileStatusEnded(
 		} else if lease.Spec.Release {
 			lease.Release(ctx)
 			return nil
-		} else if lease.Status.BeginTime != nil {
-			expiration := lease.Status.BeginTime.Add(lease.Spec.Duration.Duration)
+		} else if expiration := lease.GetExpirationTime(); expiration != nil {
 			if expiration.Before(now) {
 				lease.Expire(ctx)
 				return nil
 			} else {
-				result.RequeueAfter = expiration.Sub(now)
+				if result.RequeueAfter == 0 || expiration.Sub(now) < result.RequeueAfter {
+					result.RequeueAfter = expiration.Sub(now)
+				}
 				return nil
 			}
 		}
@@ -155,15 +206,223 @@ func (r *LeaseReconciler) reconcileStatusEnded(
 	return nil
 }
 
+// reconcileStatusTimeWindow ends acquired leases once none of the policies approving them
+// applies anymore, as they have crossed into a time window the policies forbid
+func (r *LeaseReconciler) reconcileStatusTimeWindow(
+	ctx context.Context,
+	result *ctrl.Result,
+	lease *jumpstarterdevv1alpha1.Lease,
+) error {
+	if lease.Status.Ended || lease.Status.BeginTime == nil {
+		return nil
+	}
+
+	now := time.Now()
+	expiration := lease.GetExpirationTime()
+	if !expiration.After(now) {
+		return nil
+	}
+
+	var exporters []jumpstarterdevv1alpha1.Exporter
+	for _, name := range lease.GetExporterNames() {
+		var exporter jumpstarterdevv1alpha1.Exporter
+		if err := r.Get(ctx, types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      name,
+		}, &exporter); err != nil {
+			return fmt.Errorf("reconcileStatusTimeWindow: failed to get exporter: %w", err)
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	matchingExporters, err := matchingPolicies(ctx, r.Client, lease, exporters)
+	if err != nil {
+		return fmt.Errorf("reconcileStatusTimeWindow: %w", err)
+	}
+
+	// each exporter is allowed for as long as any of the policies matching it applies
+	deadline := *expiration
+	for _, exporter := range exporters {
+		var allowedUntil *time.Time
+		for _, ae := range matchingExporters {
+			if ae.Exporter.Name != exporter.Name {
+				continue
+			}
+			until := *expiration
+			if ae.Policy.TimeWindows != nil {
+				if until, err = ae.Policy.TimeWindows.AllowedUntil(now, *expiration); err != nil {
+					return fmt.Errorf("reconcileStatusTimeWindow: failed to evaluate policy time windows: %w", err)
+				}
+			}
+			if allowedUntil == nil || until.After(*allowedUntil) {
+				allowedUntil = &until
+			}
+		}
+		if allowedUntil != nil && allowedUntil.Before(deadline) {
+			deadline = *allowedUntil
+		}
+	}
+
+	if !deadline.After(now) {
+		lease.EndOutsideTimeWindow(ctx)
+		return nil
+	}
+	if deadline.Before(*expiration) && (result.RequeueAfter == 0 || deadline.Sub(now) < result.RequeueAfter) {
+		result.RequeueAfter = deadline.Sub(now)
+	}
+	return nil
+}
+
+// reconcileStatusMaintenance ends the leases of exporters put under maintenance, draining
+// exporters let acquired leases finish, but leases that have not begun yet are ended too
+func (r *LeaseReconciler) reconcileStatusMaintenance(
+	ctx context.Context,
+	lease *jumpstarterdevv1alpha1.Lease,
+) error {
+	if lease.Status.Ended || lease.Status.ExporterRef == nil {
+		return nil
+	}
+
+	for _, name := range lease.GetExporterNames() {
+		var exporter jumpstarterdevv1alpha1.Exporter
+		if err := r.Get(ctx, types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      name,
+		}, &exporter); err != nil {
+			return fmt.Errorf("reconcileStatusMaintenance: failed to get exporter: %w", err)
+		}
+		if !exporter.UnderMaintenance() {
+			continue
+		}
+		if exporter.Spec.Maintenance.Drain && lease.Status.BeginTime != nil {
+			continue
+		}
+		lease.EndForMaintenance(ctx, exporter.MaintenanceMessage())
+		return nil
+	}
+
+	return nil
+}
+
+// reconcileStatusExporterLost marks acquired leases as degraded once one of their exporters has
+// been offline for longer than the grace period, then ends the lease, or gives up its exporters
+// to re-acquire other ones, if requested. Leases get back to normal once their exporters are online.
+func (r *LeaseReconciler) reconcileStatusExporterLost(
+	ctx context.Context,
+	result *ctrl.Result,
+	lease *jumpstarterdevv1alpha1.Lease,
+) error {
+	if lease.Status.Ended || lease.Status.BeginTime == nil || lease.Status.ExporterRef == nil {
+		return nil
+	}
+
+	now := time.Now()
+	gracePeriod := lease.GetExporterLossGracePeriod()
+
+	var lost []string
+	for _, name := range lease.GetExporterNames() {
+		var exporter jumpstarterdevv1alpha1.Exporter
+		if err := r.Get(ctx, types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      name,
+		}, &exporter); err != nil {
+			if apierrors.IsNotFound(err) {
+				lost = append(lost, fmt.Sprintf("exporter %s has been deleted", name))
+				continue
+			}
+			return fmt.Errorf("reconcileStatusExporterLost: failed to get exporter: %w", err)
+		}
+
+		offlineSince, offline := exporterOfflineSince(&exporter, lease.Status.BeginTime.Time)
+		if !offline {
+			continue
+		}
+		if deadline := offlineSince.Add(gracePeriod); deadline.After(now) {
+			if result.RequeueAfter == 0 || deadline.Sub(now) < result.RequeueAfter {
+				result.RequeueAfter = deadline.Sub(now)
+			}
+			continue
+		}
+		lost = append(lost, fmt.Sprintf("exporter %s has been offline since %s",
+			name, offlineSince.Format(time.RFC3339)))
+	}
+
+	if len(lost) == 0 {
+		if meta.IsStatusConditionTrue(lease.Status.Conditions, string(jumpstarterdevv1alpha1.LeaseConditionTypeDegraded)) {
+			lease.SetStatusDegraded(false, "ExportersOnline", "All the exporters of the lease are online")
+		}
+		// the lease has been assigned other exporters after losing one
+		if ready := meta.FindStatusCondition(
+			lease.Status.Conditions,
+			string(jumpstarterdevv1alpha1.LeaseConditionTypeReady),
+		); ready != nil && ready.Status == metav1.ConditionFalse && ready.Reason == "ExporterLost" {
+			lease.SetStatusReady(true, "Reacquired", "Other exporters have been acquired for the client")
+		}
+		return nil
+	}
+
+	message := strings.Join(lost, ", ")
+	lease.SetStatusDegraded(true, "ExporterLost", "The %s", message)
+
+	switch lease.GetExporterLossAction() {
+	case jumpstarterdevv1alpha1.ExporterLossActionEnd:
+		lease.EndExporterLost(ctx, message)
+	case jumpstarterdevv1alpha1.ExporterLossActionReacquire:
+		lease.Reacquire(ctx, message)
+		result.RequeueAfter = time.Second
+	}
+	return nil
+}
+
+// exporterOfflineSince returns the time the exporter went offline at, if it is offline,
+// exporters which never reported whether they are online are offline since the fallback time
+func exporterOfflineSince(exporter *jumpstarterdevv1alpha1.Exporter, fallback time.Time) (time.Time, bool) {
+	online := meta.FindStatusCondition(
+		exporter.Status.Conditions,
+		string(jumpstarterdevv1alpha1.ExporterConditionTypeOnline),
+	)
+	if online == nil {
+		return fallback, true
+	}
+	if online.Status == metav1.ConditionTrue {
+		return time.Time{}, false
+	}
+	return online.LastTransitionTime.Time, true
+}
+
 // nolint:unparam
 func (r *LeaseReconciler) reconcileStatusBeginTime(
 	ctx context.Context,
+	result *ctrl.Result,
 	lease *jumpstarterdevv1alpha1.Lease,
 ) error {
 	logger := log.FromContext(ctx)
 
 	now := time.Now()
-	if lease.Status.BeginTime == nil && lease.Status.ExporterRef != nil {
+	if lease.Status.BeginTime == nil && lease.Status.ExporterRef != nil && !lease.Status.Ended {
+		// scheduled leases hold a reservation on the exporter until their begin time
+		if lease.IsScheduled() && now.Before(lease.Spec.BeginTime.Time) {
+			lease.SetStatusPending("Scheduled",
+				"Exporter %s is reserved for the lease, which begins at %s",
+				lease.Status.ExporterRef.Name, lease.Spec.BeginTime.Format(time.RFC3339))
+			result.RequeueAfter = lease.Spec.BeginTime.Sub(now)
+			return nil
+		}
+
+		// the exporters are handed over once they are done cleaning up after their previous lease
+		cleaning, err := r.cleaningExporter(ctx, lease)
+		if err != nil {
+			return fmt.Errorf("reconcileStatusBeginTime: %w", err)
+		}
+		if cleaning != nil {
+			lease.SetStatusPending("ExporterCleaning",
+				"Exporter %s is cleaning up after its previous lease", cleaning.Name)
+			if deadline := cleaning.CleanupDeadline(); result.RequeueAfter == 0 || deadline.Sub(now) < result.RequeueAfter {
+				result.RequeueAfter = max(deadline.Sub(now), time.Second)
+			}
+			return nil
+		}
+
 		logger.Info("Updating begin time for lease", "lease", lease.Name, "exporter", lease.GetExporterName(), "client", lease.GetClientName())
 		lease.SetStatusReady(true, "Ready", "An exporter has been acquired for the client")
 		lease.Status.BeginTime = &metav1.Time{
@@ -188,110 +447,453 @@ func (r *LeaseReconciler) reconcileStatusExporterRef(
 	}
 
 	if lease.Status.ExporterRef == nil {
-		logger.Info("Looking for a matching exporter for lease", "lease", lease.Name, "client", lease.GetClientName(), "selector", lease.Spec.Selector)
+		logger.Info("Looking for a matching exporter for lease", "lease", lease.Name, "client", lease.GetClientName(), "selector", lease.Spec.Selector, "members", len(lease.Spec.Members))
 
-		selector, err := lease.GetExporterSelector()
-		if err != nil {
-			return fmt.Errorf("reconcileStatusExporterRef: failed to get exporter selector: %w", err)
-		} else if selector.Empty() {
-			lease.SetStatusInvalid("InvalidSelector", "The selector for the lease is empty, a selector is required")
+		if !lease.IsGang() {
+			selector, err := lease.GetExporterSelector()
+			if err != nil {
+				return fmt.Errorf("reconcileStatusExporterRef: failed to get exporter selector: %w", err)
+			} else if selector.Empty() {
+				lease.SetStatusInvalid("InvalidSelector", "The selector for the lease is empty, a selector is required")
+				return nil
+			}
+		}
+
+		memberNames := make(map[string]bool)
+		for _, member := range lease.Spec.Members {
+			if member.Name == "" || memberNames[member.Name] {
+				lease.SetStatusInvalid("InvalidMembers", "Each member of the lease requires a unique name, got %q", member.Name)
+				return nil
+			}
+			memberNames[member.Name] = true
+		}
+
+		if lease.IsScheduled() && !lease.Spec.EndTime.After(time.Now()) {
+			lease.SetStatusInvalid("InvalidWindow", "The requested end time %s is in the past",
+				lease.Spec.EndTime.Format(time.RFC3339))
 			return nil
 		}
 
-		// List all Exporter matching selector
-		matchingExporters, err := r.ListMatchingExporters(ctx, lease, selector)
+		activeLeases, err := r.ListActiveLeases(ctx, lease.Namespace)
 		if err != nil {
-			return fmt.Errorf("reconcileStatusExporterRef: failed to list matching exporters: %w", err)
+			return fmt.Errorf("reconcileStatusExporterRef: failed to list active leases: %w", err)
 		}
 
-		// Filter out offline exporters
-		onlineExporters := filterOutOfflineExporters(matchingExporters.Items)
+		// Gang leases need all of their members to be satisfiable, slots of a same member
+		// share the exporters they can be filled with
+		var slots []LeaseSlot
+		availableByMember := make(map[string][]ApprovedExporter)
+		for _, slot := range leaseSlotSelectors(lease) {
+			availableExporters, ok := availableByMember[slot.Member]
+			if !ok {
+				availableExporters, err = r.reconcileLeaseSlot(ctx, result, lease, slot, activeLeases.Items)
+				if err != nil || availableExporters == nil {
+					return err
+				}
+				availableByMember[slot.Member] = availableExporters
+			}
+			slots = append(slots, LeaseSlot{
+				Name:              slot.Name,
+				Member:            slot.Member,
+				ApprovedExporters: availableExporters,
+			})
+		}
 
-		// No matching exporter online, lease unsatisfiable
-		if len(onlineExporters) == 0 {
-			lease.SetStatusUnsatisfiable(
-				"NoExporter",
-				"There are no online exporter matching the selector, but there are %d matching offline exporters",
-				len(matchingExporters.Items))
+		// Several pending leases may be contending for the same exporters, run a scheduling
+		// pass over all of them so that exporters go to the highest priority and longest
+		// waiting leases first
+		schedule := r.scheduleNamespace(ctx, LeaseRequest{
+			Lease: lease,
+			Slots: slots,
+		}, activeLeases.Items)
+
+		selection, ok := schedule.Assignments[lease.Name]
+		if !ok {
+			if lease.IsScheduled() {
+				lease.SetStatusUnsatisfiable("Conflict",
+					"The exporters approved for the lease cannot all be booked during the requested window")
+				return nil
+			}
+			lease.Status.QueuePosition = schedule.Position(lease.Name) + 1
+			lease.Status.EstimatedBeginTime = nil
+			if estimate, ok := schedule.EstimatedBeginTimes[lease.Name]; ok {
+				lease.Status.EstimatedBeginTime = &metav1.Time{Time: estimate}
+				lease.SetStatusPending("NotAvailable",
+					"There are %d approved exporters, but all of them are leased or assigned to leases ahead in the queue "+
+						"(position %d), expected to be available at %s",
+					len(slots[0].ApprovedExporters), lease.Status.QueuePosition, estimate.Format(time.RFC3339))
+			} else {
+				lease.SetStatusPending("NotAvailable",
+					"There are %d approved exporters, but all of them are leased or assigned to leases ahead in the queue "+
+						"(position %d)",
+					len(slots[0].ApprovedExporters), lease.Status.QueuePosition)
+			}
+			// the lease gets reconciled as soon as the exporters or leases ahead of it change
+			result.RequeueAfter = pendingLeaseResyncInterval
 			return nil
 		}
 
-		approvedExporters, err := r.attachMatchingPolicies(ctx, lease, onlineExporters)
-		if err != nil {
-			return fmt.Errorf("reconcileStatusExporterRef: failed to handle policy approval: %w", err)
+		lease.Status.QueuePosition = 0
+		lease.Status.EstimatedBeginTime = nil
+		if meta.IsStatusConditionTrue(lease.Status.Conditions, string(jumpstarterdevv1alpha1.LeaseConditionTypeQuotaExceeded)) {
+			lease.SetStatusQuotaExceeded(false, "QuotaAvailable", "The lease fits within the quotas of the policies")
+		}
+		lease.Status.Priority = selection[0].Policy.Priority
+		lease.Status.SpotAccess = false
+		for _, selected := range selection {
+			lease.Status.Priority = min(lease.Status.Priority, selected.Policy.Priority)
+			lease.Status.SpotAccess = lease.Status.SpotAccess || selected.Policy.SpotAccess
+		}
+		lease.Status.ExporterRef = &corev1.LocalObjectReference{
+			Name: selection[0].Exporter.Name,
+		}
+		if lease.IsGang() {
+			lease.Status.Members = nil
+			for i, slot := range slots {
+				lease.Status.Members = append(lease.Status.Members, jumpstarterdevv1alpha1.LeaseMemberExporter{
+					Name:   slot.Name,
+					Member: slot.Member,
+					ExporterRef: corev1.LocalObjectReference{
+						Name: selection[i].Exporter.Name,
+					},
+				})
+			}
 		}
 
-		if len(approvedExporters) == 0 {
-			lease.SetStatusUnsatisfiable(
-				"NoAccess",
-				"While there are %d online exporters matching the selector, none of them are approved by any policy for your client",
-				len(onlineExporters))
-			return nil
+		// Some of the exporters are held by spot leases, and we have non-spot access to them.
+		// The assignment is persisted before preempting them, so that the preemption is
+		// retried by the next reconciliations if it fails, see preemptSpotLeases
+		if slices.ContainsFunc(selection, func(selected ApprovedExporter) bool {
+			return selected.ExistingLease != nil
+		}) {
+			if err := r.Status().Update(ctx, lease); err != nil {
+				return fmt.Errorf("reconcileStatusExporterRef: failed to update lease status: %w", err)
+			}
 		}
-		// Filter out exporters that are already leased
-		activeLeases, err := r.ListActiveLeases(ctx, lease.Namespace)
-		if err != nil {
-			return fmt.Errorf("reconcileStatusExporterRef: failed to list active leases: %w", err)
+	}
+
+	if lease.Status.BeginTime == nil {
+		if err := r.preemptSpotLeases(ctx, lease); err != nil {
+			return fmt.Errorf("reconcileStatusExporterRef: %w", err)
 		}
+	}
+	return nil
+}
 
-		approvedExporters = attachExistingLeases(approvedExporters, activeLeases.Items)
-		orderedExporters := orderApprovedExporters(approvedExporters)
+// reconcileLeaseSlot returns the ordered list of exporters approved for a slot of the lease,
+// or nil after setting the lease status if fewer exporters than requested by the member of the
+// slot can ever fill it
+func (r *LeaseReconciler) reconcileLeaseSlot(
+	ctx context.Context,
+	result *ctrl.Result,
+	lease *jumpstarterdevv1alpha1.Lease,
+	slot leaseSlotSelector,
+	activeLeases []jumpstarterdevv1alpha1.Lease,
+) ([]ApprovedExporter, error) {
+	count := slot.Count
+	suffix := ""
+	if slot.Member != "" {
+		suffix = fmt.Sprintf(" for member %s", slot.Member)
+	}
 
-		if len(orderedExporters) > 0 && orderedExporters[0].Policy.SpotAccess {
-			lease.SetStatusUnsatisfiable("SpotAccess",
-				"The only possible exporters are under spot access (i.e. %s), but spot access is still not implemented",
-				orderedExporters[0].Exporter.Name)
-			return nil
+	selector, err := metav1.LabelSelectorAsSelector(&slot.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("reconcileLeaseSlot: failed to get exporter selector: %w", err)
+	} else if selector.Empty() {
+		lease.SetStatusInvalid("InvalidSelector", "The selector%s is empty, a selector is required", suffix)
+		return nil, nil
+	}
+
+	// List all Exporter matching selector
+	matchingExporters, err := r.ListMatchingExporters(ctx, lease, selector, slot.DeviceSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("reconcileLeaseSlot: failed to list matching exporters: %w", err)
+	}
+
+	// Filter out offline exporters
+	onlineExporters := filterOutOfflineExporters(matchingExporters.Items)
+
+	// No matching exporter online, lease unsatisfiable
+	if len(onlineExporters) == 0 {
+		lease.SetStatusUnsatisfiable(
+			"NoExporter",
+			"There are no online exporter matching the selector%s, but there are %d matching offline exporters",
+			suffix, len(matchingExporters.Items))
+		return nil, nil
+	}
+	if len(onlineExporters) < count {
+		lease.SetStatusUnsatisfiable(
+			"NoExporter",
+			"There are %d online exporters matching the selector%s, but %d are requested",
+			len(onlineExporters), suffix, count)
+		return nil, nil
+	}
+
+	// Filter out exporters taken out of rotation
+	inRotationExporters := filterOutMaintenanceExporters(slices.Clone(onlineExporters))
+	if len(inRotationExporters) < count {
+		var messages []string
+		for _, exporter := range onlineExporters {
+			if exporter.UnderMaintenance() {
+				messages = append(messages, exporter.MaintenanceMessage())
+			}
 		}
+		lease.SetStatusUnsatisfiable(
+			"ExporterMaintenance",
+			"There are %d online exporters matching the selector%s, but %d of them are under maintenance (%s)",
+			len(onlineExporters), suffix, len(messages), strings.Join(messages, "; "))
+		return nil, nil
+	}
+	onlineExporters = inRotationExporters
 
-		availableExporters := filterOutLeasedExporters(orderedExporters)
-		if len(availableExporters) == 0 {
-			lease.SetStatusPending("NotAvailable",
-				"There are %d approved exporters, but all of them are already leased",
-				len(approvedExporters))
-			result.RequeueAfter = time.Second
-			return nil
+	approvedExporters, rejected, err := attachMatchingPolicies(ctx, r.Client, lease, onlineExporters)
+	if err != nil {
+		return nil, fmt.Errorf("reconcileLeaseSlot: failed to handle policy approval: %w", err)
+	}
+
+	if countExporters(approvedExporters) < count && len(rejected.Duration) > 0 {
+		maximum := maximumPolicyDuration(rejected.Duration)
+		if !lease.Spec.ClampDuration {
+			lease.SetStatusUnsatisfiable(
+				"DurationExceedsPolicy",
+				"The requested duration %s exceeds the maximum duration of %s allowed by the policies for your client%s",
+				lease.Spec.Duration.Duration, maximum, suffix)
+			return nil, nil
 		}
 
-		// TODO: here there's room for improvement, i.e. we could have multiple
-		// clients trying to lease the same exporters, we should look at priorities
-		// and spot access to decide which client gets the exporter, this probably means
-		// that we will need to construct a lease scheduler with the view of all leases
-		// and exporters in the system, and (maybe) a priority queue for the leases.
+		if err := r.clampLeaseDuration(ctx, lease, maximum); err != nil {
+			return nil, fmt.Errorf("reconcileLeaseSlot: failed to clamp lease duration: %w", err)
+		}
+		approvedExporters = append(approvedExporters, slices.DeleteFunc(rejected.Duration, func(ae ApprovedExporter) bool {
+			return ae.Policy.MaximumDuration.Duration < maximum
+		})...)
+	}
 
-		// For now, we just select the best available exporter without considering other
-		// ongoing lease requests
+	if countExporters(approvedExporters) < count && len(rejected.TimeWindow) > 0 {
+		lease.SetStatusUnsatisfiable(
+			"OutsideTimeWindow",
+			"The policies for your client%s do not allow leasing during the whole requested time, "+
+				"it crosses into a time window they forbid",
+			suffix)
+		return nil, nil
+	}
 
-		selected := availableExporters[0]
+	approvedExporters, quotaExceeded, quotaMessage, err := filterOutQuotaExceeded(ctx, r.Client, lease,
+		approvedExporters, lease.Spec.Duration.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("reconcileLeaseSlot: failed to check policy quotas: %w", err)
+	}
+	if countExporters(approvedExporters) < count && len(quotaExceeded) > 0 {
+		// quotas free up as leases end, which enqueues the lease, leased time over a period
+		// only rolls off slowly and is checked again along with the other pending leases
+		lease.SetStatusQuotaExceeded(true, "QuotaExceeded", "The lease cannot be granted%s, %s", suffix, quotaMessage)
+		lease.SetStatusPending("QuotaExceeded", "Waiting for quota%s: %s", suffix, quotaMessage)
+		result.RequeueAfter = pendingLeaseResyncInterval
+		return nil, nil
+	}
 
-		if selected.ExistingLease != nil {
-			// TODO: Implement eviction of spot access leases
-			lease.SetStatusPending("NotAvailable",
-				"Exporter %s is already leased by another client under spot access, but spot access eviction still not implemented",
-				selected.Exporter.Name)
-			result.RequeueAfter = time.Second
-			return nil
-		}
+	if len(approvedExporters) == 0 {
+		lease.SetStatusUnsatisfiable(
+			"NoAccess",
+			"While there are %d online exporters matching the selector%s, none of them are approved by any policy for your client",
+			len(onlineExporters), suffix)
+		return nil, nil
+	}
+	if approved := countExporters(approvedExporters); approved < count {
+		lease.SetStatusUnsatisfiable(
+			"NoAccess",
+			"While there are %d online exporters matching the selector%s, only %d of them are approved by any policy "+
+				"for your client, but %d are requested",
+			len(onlineExporters), suffix, approved, count)
+		return nil, nil
+	}
 
-		lease.Status.Priority = selected.Policy.Priority
-		lease.Status.SpotAccess = selected.Policy.SpotAccess
-		lease.Status.ExporterRef = &corev1.LocalObjectReference{
-			Name: selected.Exporter.Name,
+	// Filter out exporters that are already leased
+	approvedExporters = attachExistingLeases(lease, approvedExporters, activeLeases)
+	approvedExporters, err = attachLeaseAffinity(ctx, r.Client, lease, approvedExporters, activeLeases)
+	if err != nil {
+		return nil, fmt.Errorf("reconcileLeaseSlot: failed to evaluate lease affinity: %w", err)
+	}
+	orderedExporters := orderApprovedExporters(approvedExporters)
+
+	if lease.IsScheduled() {
+		availableExporters := filterOutLeasedExporters(slices.Clone(orderedExporters))
+		if countExporters(availableExporters) < count {
+			// bookings are accepted or refused right away, they do not wait in the queue
+			approved := countExporters(approvedExporters)
+			lease.SetStatusUnsatisfiable("Conflict",
+				"There are %d approved exporters%s, but %d of them are booked during the requested window",
+				approved, suffix, approved-countExporters(availableExporters))
+			return nil, nil
 		}
+		return availableExporters, nil
+	}
+
+	// exporters held by other leases are kept, the lease is queued for them
+	return orderedExporters, nil
+}
+
+// preemptSpotLeases preempts the spot leases still holding, or having reserved, the exporters
+// assigned to the lease during its window, until the lease begins
+func (r *LeaseReconciler) preemptSpotLeases(ctx context.Context, lease *jumpstarterdevv1alpha1.Lease) error {
+	exporterNames := lease.GetExporterNames()
+	if len(exporterNames) == 0 {
 		return nil
 	}
 
+	activeLeases, err := r.ListActiveLeases(ctx, lease.Namespace)
+	if err != nil {
+		return fmt.Errorf("preemptSpotLeases: failed to list active leases: %w", err)
+	}
+
+	now := time.Now()
+	begin, end := lease.GetRequestedWindow(now)
+	for i := range activeLeases.Items {
+		spotLease := &activeLeases.Items[i]
+		if spotLease.Name == lease.Name || spotLease.Status.Ended || !spotLease.Status.SpotAccess ||
+			!slices.ContainsFunc(exporterNames, spotLease.HoldsExporter) ||
+			!leaseOccupies(spotLease, begin, end, now) {
+			continue
+		}
+		if err := r.preemptLease(ctx, spotLease, lease); err != nil {
+			return fmt.Errorf("preemptSpotLeases: failed to preempt spot lease %s: %w", spotLease.Name, err)
+		}
+	}
+	return nil
+}
+
+// preemptLease ends a spot lease so its exporter can be handed over to a non-spot lease,
+// the exporter is notified through the Status stream once its lease reference changes. The
+// exporter is asked to clean up after the spot lease first, the lease waits for it to be done.
+func (r *LeaseReconciler) preemptLease(
+	ctx context.Context,
+	spotLease *jumpstarterdevv1alpha1.Lease,
+	lease *jumpstarterdevv1alpha1.Lease,
+) error {
+	original := spotLease.DeepCopy()
+	spotLease.Preempt(ctx, lease.Name)
+	if err := r.requestExporterCleanup(ctx, original, spotLease); err != nil {
+		return fmt.Errorf("preemptLease: %w", err)
+	}
+	if err := r.Status().Update(ctx, spotLease); err != nil {
+		return fmt.Errorf("preemptLease: failed to update spot lease status: %w", err)
+	}
+	// the condition is set outside of the reconciliation of the spot lease, which records no event for it
+	r.Recorder.Eventf(spotLease, corev1.EventTypeWarning, "Preempted", "The spot lease was preempted by lease %s", lease.Name)
+
+	if spotLease.Labels == nil {
+		spotLease.Labels = make(map[string]string)
+	}
+	spotLease.Labels[string(jumpstarterdevv1alpha1.LeaseLabelEnded)] = jumpstarterdevv1alpha1.LeaseLabelEndedValue
+	if err := r.Update(ctx, spotLease); err != nil {
+		return fmt.Errorf("preemptLease: failed to update spot lease metadata: %w", err)
+	}
+
 	return nil
 }
 
+// clampLeaseDuration shortens the lease to the given duration, the spec is patched right away
+// as the status update done at the end of the reconciliation would overwrite it
+func (r *LeaseReconciler) clampLeaseDuration(
+	ctx context.Context,
+	lease *jumpstarterdevv1alpha1.Lease,
+	duration time.Duration,
+) error {
+	logger := log.FromContext(ctx)
+	logger.Info("Clamping lease duration to the maximum allowed by policy", "lease", lease.Name,
+		"requested", lease.Spec.Duration.Duration, "maximum", duration)
+
+	original := client.MergeFrom(lease.DeepCopy())
+	lease.Spec.Duration = metav1.Duration{Duration: duration}
+	if lease.IsScheduled() {
+		lease.Spec.EndTime = &metav1.Time{Time: lease.Spec.BeginTime.Add(duration)}
+	}
+	return r.Patch(ctx, lease, original)
+}
+
+// maximumPolicyDuration returns the longest maximum duration among the policies of the given exporters
+func maximumPolicyDuration(exporters []ApprovedExporter) time.Duration {
+	var maximum time.Duration
+	for _, ae := range exporters {
+		if ae.Policy.MaximumDuration != nil && ae.Policy.MaximumDuration.Duration > maximum {
+			maximum = ae.Policy.MaximumDuration.Duration
+		}
+	}
+	return maximum
+}
+
+// rejectedExporters are exporters matched by policies for the client, that the policies
+// do not allow leasing for the requested window
+type rejectedExporters struct {
+	// Duration are the exporters that would be approved if the lease was not longer
+	// than the maximum duration of the policy
+	Duration []ApprovedExporter
+	// TimeWindow are the exporters that would be approved if the lease did not cross
+	// into a time window the policy does not apply during
+	TimeWindow []ApprovedExporter
+}
+
 // attachMatchingPolicies attaches the matching policies to the list of online exporters
 // if the exporter matches the policy and the client matches the policy's client selector
-// the exporter is approved for leasing
-func (r *LeaseReconciler) attachMatchingPolicies(ctx context.Context, lease *jumpstarterdevv1alpha1.Lease, onlineExporters []jumpstarterdevv1alpha1.Exporter) ([]ApprovedExporter, error) {
+// the exporter is approved for leasing, exporters the policies would approve for another
+// window are returned separately
+func attachMatchingPolicies(
+	ctx context.Context,
+	c client.Client,
+	lease *jumpstarterdevv1alpha1.Lease,
+	onlineExporters []jumpstarterdevv1alpha1.Exporter,
+) ([]ApprovedExporter, rejectedExporters, error) {
 	var approvedExporters []ApprovedExporter
+	var rejected rejectedExporters
+
+	matchingExporters, err := matchingPolicies(ctx, c, lease, onlineExporters)
+	if err != nil {
+		return nil, rejected, err
+	}
+
+	// acquired leases only need to be allowed until they expire
+	now := time.Now()
+	begin, end := lease.GetRequestedWindow(now)
+	if expiration := lease.GetExpirationTime(); expiration != nil {
+		begin, end = now, *expiration
+	}
+
+	for _, ae := range matchingExporters {
+		if ae.Policy.TimeWindows != nil && end.After(begin) {
+			until, err := ae.Policy.TimeWindows.AllowedUntil(begin, end)
+			if err != nil {
+				return nil, rejected, fmt.Errorf("reconcileStatusExporterRef: failed to evaluate policy time windows: %w", err)
+			}
+			if until.Before(end) {
+				rejected.TimeWindow = append(rejected.TimeWindow, ae)
+				continue
+			}
+		}
+		if ae.Policy.MaximumDuration != nil {
+			if lease.Spec.Duration.Duration > ae.Policy.MaximumDuration.Duration {
+				// keep track of it so we can report it on the status of the
+				// lease, or clamp the lease, if no other options exist
+				rejected.Duration = append(rejected.Duration, ae)
+				continue
+			}
+		}
+		approvedExporters = append(approvedExporters, ae)
+	}
+	return approvedExporters, rejected, nil
+}
+
+// matchingPolicies returns the exporters along with each policy matching both the exporter
+// and the client of the lease, regardless of the window requested by the lease
+func matchingPolicies(
+	ctx context.Context,
+	c client.Client,
+	lease *jumpstarterdevv1alpha1.Lease,
+	onlineExporters []jumpstarterdevv1alpha1.Exporter,
+) ([]ApprovedExporter, error) {
+	var matchingExporters []ApprovedExporter
 
 	var policies jumpstarterdevv1alpha1.ExporterAccessPolicyList
-	if err := r.List(ctx, &policies,
+	if err := c.List(ctx, &policies,
 		client.InNamespace(lease.Namespace),
 	); err != nil {
 		return nil, fmt.Errorf("reconcileStatusExporterRef: failed to list exporter access policies: %w", err)
@@ -300,7 +902,7 @@ func (r *LeaseReconciler) attachMatchingPolicies(ctx context.Context, lease *jum
 	// If there are no policies, we just approve all online exporters
 	if len(policies.Items) == 0 {
 		for _, exporter := range onlineExporters {
-			approvedExporters = append(approvedExporters, ApprovedExporter{
+			matchingExporters = append(matchingExporters, ApprovedExporter{
 				Exporter: exporter,
 				Policy: jumpstarterdevv1alpha1.Policy{
 					Priority:   0,
@@ -308,17 +910,22 @@ func (r *LeaseReconciler) attachMatchingPolicies(ctx context.Context, lease *jum
 				},
 			})
 		}
-		return approvedExporters, nil
+		return matchingExporters, nil
 	}
 	// If policies exist: get the client to obtain the metadata necessary for policy matching
 	var jclient jumpstarterdevv1alpha1.Client
-	if err := r.Get(ctx, types.NamespacedName{
+	if err := c.Get(ctx, types.NamespacedName{
 		Namespace: lease.Namespace,
 		Name:      lease.Spec.ClientRef.Name,
 	}, &jclient); err != nil {
 		return nil, fmt.Errorf("reconcileStatusExporterRef: failed to get client: %w", err)
 	}
 
+	groups, err := listClientGroups(ctx, c, lease.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("reconcileStatusExporterRef: %w", err)
+	}
+
 	for _, exporter := range onlineExporters {
 		for _, policy := range policies.Items {
 			exporterSelector, err := metav1.LabelSelectorAsSelector(&policy.Spec.ExporterSelector)
@@ -326,24 +933,18 @@ func (r *LeaseReconciler) attachMatchingPolicies(ctx context.Context, lease *jum
 				return nil, fmt.Errorf("reconcileStatusExporterRef: failed to convert exporter selector: %w", err)
 			}
 			if exporterSelector.Matches(labels.Set(exporter.Labels)) {
-				for _, p := range policy.Spec.Policies {
+				for i, p := range policy.Spec.Policies {
 					for _, from := range p.From {
-						clientSelector, err := metav1.LabelSelectorAsSelector(&from.ClientSelector)
+						matches, err := from.Matches(&jclient, groups[from.ClientGroupName()])
 						if err != nil {
 							return nil, fmt.Errorf("reconcileStatusExporterRef: failed to convert client selector: %w", err)
 						}
-						if clientSelector.Matches(labels.Set(jclient.Labels)) {
-							if p.MaximumDuration != nil {
-								if lease.Spec.Duration.Duration > p.MaximumDuration.Duration {
-									// TODO: we probably should keep this on the list of approved exporters
-									// but mark as excessive duration so we can report it on the status
-									// of lease if no other options exist
-									continue
-								}
-							}
-							approvedExporters = append(approvedExporters, ApprovedExporter{
-								Exporter: exporter,
-								Policy:   p,
+						if matches {
+							matchingExporters = append(matchingExporters, ApprovedExporter{
+								Exporter:     exporter,
+								Policy:       policyWithGroupDefaults(p, groups),
+								AccessPolicy: &policy,
+								PolicyIndex:  i,
 							})
 						}
 					}
@@ -351,15 +952,22 @@ func (r *LeaseReconciler) attachMatchingPolicies(ctx context.Context, lease *jum
 			}
 		}
 	}
-	return approvedExporters, nil
+	return matchingExporters, nil
 }
 
-// ListMatchingExporters returns a list of exporters that match the selector of the lease
+// ListMatchingExporters returns a list of exporters that match the selector of the lease,
+// and report devices matching each of the device selectors
 func (r *LeaseReconciler) ListMatchingExporters(ctx context.Context, lease *jumpstarterdevv1alpha1.Lease,
-	selector labels.Selector) (*jumpstarterdevv1alpha1.ExporterList, error) {
+	selector labels.Selector, deviceSelectors []metav1.LabelSelector) (*jumpstarterdevv1alpha1.ExporterList, error) {
+	return listMatchingExporters(ctx, r.Client, lease, selector, deviceSelectors)
+}
+
+// listMatchingExporters is ListMatchingExporters for callers outside of the reconciler
+func listMatchingExporters(ctx context.Context, c client.Client, lease *jumpstarterdevv1alpha1.Lease,
+	selector labels.Selector, deviceSelectors []metav1.LabelSelector) (*jumpstarterdevv1alpha1.ExporterList, error) {
 
 	var matchingExporters jumpstarterdevv1alpha1.ExporterList
-	if err := r.List(
+	if err := c.List(
 		ctx,
 		&matchingExporters,
 		client.InNamespace(lease.Namespace),
@@ -367,13 +975,34 @@ func (r *LeaseReconciler) ListMatchingExporters(ctx context.Context, lease *jump
 	); err != nil {
 		return nil, fmt.Errorf("ListMatchingExporters: failed to list exporters matching selector: %w", err)
 	}
+
+	if len(deviceSelectors) == 0 {
+		return &matchingExporters, nil
+	}
+
+	var selectors []labels.Selector
+	for _, deviceSelector := range deviceSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(&deviceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("ListMatchingExporters: failed to get device selector: %w", err)
+		}
+		selectors = append(selectors, selector)
+	}
+	matchingExporters.Items = slices.DeleteFunc(matchingExporters.Items, func(exporter jumpstarterdevv1alpha1.Exporter) bool {
+		return !exporter.HasDevicesMatching(selectors)
+	})
 	return &matchingExporters, nil
 }
 
 // ListActiveLeases returns a list of active leases in the namespace
 func (r *LeaseReconciler) ListActiveLeases(ctx context.Context, namespace string) (*jumpstarterdevv1alpha1.LeaseList, error) {
+	return listActiveLeases(ctx, r.Client, namespace)
+}
+
+// listActiveLeases is ListActiveLeases for callers outside of the reconciler
+func listActiveLeases(ctx context.Context, c client.Client, namespace string) (*jumpstarterdevv1alpha1.LeaseList, error) {
 	var activeLeases jumpstarterdevv1alpha1.LeaseList
-	if err := r.List(
+	if err := c.List(
 		ctx,
 		&activeLeases,
 		client.InNamespace(namespace),
@@ -386,12 +1015,22 @@ func (r *LeaseReconciler) ListActiveLeases(ctx context.Context, namespace string
 
 // attachExistingLeases attaches the existing leases to the approved exporter list
 // if the activeLeases slice contains a lease that references the exporter in the
-// approved exporter list
-func attachExistingLeases(exporters []ApprovedExporter, activeLeases []jumpstarterdevv1alpha1.Lease) []ApprovedExporter {
+// approved exporter list, and holds or has reserved it during the window requested
+// by the lease
+func attachExistingLeases(
+	lease *jumpstarterdevv1alpha1.Lease,
+	exporters []ApprovedExporter,
+	activeLeases []jumpstarterdevv1alpha1.Lease,
+) []ApprovedExporter {
+	now := time.Now()
+	begin, end := lease.GetRequestedWindow(now)
 	for i, exporter := range exporters {
 		for _, existingLease := range activeLeases {
-			if existingLease.Status.ExporterRef != nil &&
-				existingLease.Status.ExporterRef.Name == exporter.Exporter.Name {
+			if existingLease.Name == lease.Name {
+				continue
+			}
+			if existingLease.HoldsExporter(exporter.Exporter.Name) &&
+				leaseOccupies(&existingLease, begin, end, now) {
 				exporters[i].ExistingLease = &existingLease
 			}
 		}
@@ -399,14 +1038,32 @@ func attachExistingLeases(exporters []ApprovedExporter, activeLeases []jumpstart
 	return exporters
 }
 
+// leaseOccupies returns true if an active lease holds, or has reserved, its exporter
+// at any point of the [begin, end) window
+func leaseOccupies(lease *jumpstarterdevv1alpha1.Lease, begin, end, now time.Time) bool {
+	if expiration := lease.GetExpirationTime(); expiration != nil {
+		// an acquired lease keeps its exporter until it has been reconciled as ended,
+		// even when past its expiration time
+		return expiration.After(begin) || !expiration.After(now)
+	}
+	reservedBegin, reservedEnd := lease.GetRequestedWindow(now)
+	return windowsOverlap(begin, end, reservedBegin, reservedEnd)
+}
+
+// windowsOverlap returns true if the [aBegin, aEnd) and [bBegin, bEnd) windows overlap
+func windowsOverlap(aBegin, aEnd, bBegin, bEnd time.Time) bool {
+	return aBegin.Before(bEnd) && bBegin.Before(aEnd)
+}
+
 // orderAvailableExporters orders the exporters in the following order
 // 1. Not being leased
-// 2. Not accessible under spot access
-// 3. Highest priority
-// 4. Alphabetically by exporter name
+// 2. Satisfying the most affinity terms of the lease
+// 3. Not accessible under spot access
+// 4. Highest priority
+// 5. Alphabetically by exporter name
 
 func orderApprovedExporters(exporters []ApprovedExporter) []ApprovedExporter {
-	// Order by lease status, priority, spot access, and name
+	// Order by lease status, affinity, priority, spot access, and name
 
 	cmpFunc := func(a, b ApprovedExporter) int {
 		// If one of the exporters has an existing lease, we want to prioritize the one that doesn't
@@ -416,6 +1073,11 @@ func orderApprovedExporters(exporters []ApprovedExporter) []ApprovedExporter {
 			return -1
 		}
 
+		// We want the exporters placed where the lease asked for to be first
+		if a.Affinity != b.Affinity {
+			return b.Affinity - a.Affinity
+		}
+
 		// We want spot access policies to be later on the returned array
 		if a.Policy.SpotAccess != b.Policy.SpotAccess {
 			if a.Policy.SpotAccess {
@@ -442,22 +1104,34 @@ func orderApprovedExporters(exporters []ApprovedExporter) []ApprovedExporter {
 func filterOutLeasedExporters(exporters []ApprovedExporter) []ApprovedExporter {
 	// Exclude exporter that are already leased and non-takeable
 	return slices.DeleteFunc(exporters, func(ae ApprovedExporter) bool {
-		existingLease := ae.ExistingLease
-		if existingLease == nil {
-			return false
-		}
+		return !exporterTakeable(ae)
+	})
+}
 
-		weHaveNonSpotAccess := !ae.Policy.SpotAccess
+// exporterTakeable returns true if the exporter is not leased, or is leased under spot
+// access while we have non-spot access to it
+func exporterTakeable(ae ApprovedExporter) bool {
+	existingLease := ae.ExistingLease
+	if existingLease == nil {
+		return true
+	}
 
-		// There is an existing lease, but, if it's spot access we can take it
-		if weHaveNonSpotAccess && ae.ExistingLease.Status.SpotAccess {
-			return false
-		}
+	weHaveNonSpotAccess := !ae.Policy.SpotAccess
 
-		// ok, there is an existing lease, and it's not spot access, we can't take it
+	// There is an existing lease, but, if it's spot access we can take it
+	if weHaveNonSpotAccess && existingLease.Status.SpotAccess {
 		return true
-	})
+	}
 
+	// ok, there is an existing lease, and it's not spot access, we can't take it
+	return false
+}
+
+// filterOutMaintenanceExporters filters out the exporters that are under maintenance
+func filterOutMaintenanceExporters(exporters []jumpstarterdevv1alpha1.Exporter) []jumpstarterdevv1alpha1.Exporter {
+	return slices.DeleteFunc(exporters, func(exporter jumpstarterdevv1alpha1.Exporter) bool {
+		return exporter.UnderMaintenance()
+	})
 }
 
 // filterOutOfflineExporters filters out the exporters that are not online
@@ -465,21 +1139,59 @@ func filterOutOfflineExporters(matchingExporters []jumpstarterdevv1alpha1.Export
 	onlineExporters := slices.DeleteFunc(
 		matchingExporters,
 		func(exporter jumpstarterdevv1alpha1.Exporter) bool {
-			return !true || !meta.IsStatusConditionTrue(
-				exporter.Status.Conditions,
-				string(jumpstarterdevv1alpha1.ExporterConditionTypeRegistered),
-			) || !meta.IsStatusConditionTrue(
-				exporter.Status.Conditions,
-				string(jumpstarterdevv1alpha1.ExporterConditionTypeOnline),
-			)
+			return !exporterOnline(&exporter)
 		},
 	)
 	return onlineExporters
 }
 
+// exporterOnline returns true if the exporter is registered and online
+func exporterOnline(exporter *jumpstarterdevv1alpha1.Exporter) bool {
+	return meta.IsStatusConditionTrue(
+		exporter.Status.Conditions,
+		string(jumpstarterdevv1alpha1.ExporterConditionTypeRegistered),
+	) && meta.IsStatusConditionTrue(
+		exporter.Status.Conditions,
+		string(jumpstarterdevv1alpha1.ExporterConditionTypeOnline),
+	)
+}
+
+// leaseForExporter enqueues the lease held by an exporter when the exporter is put under
+// maintenance without draining, so that the lease gets ended right away, and when the exporter
+// goes offline or comes back online, so that the lease gets marked as degraded or not
+func (r *LeaseReconciler) leaseForExporter(ctx context.Context, obj client.Object) []reconcile.Request {
+	exporter, ok := obj.(*jumpstarterdevv1alpha1.Exporter)
+	if !ok || exporter.Status.LeaseRef == nil {
+		return nil
+	}
+
+	key := types.NamespacedName{Namespace: exporter.Namespace, Name: exporter.Status.LeaseRef.Name}
+	if !(exporter.UnderMaintenance() && !exporter.Spec.Maintenance.Drain) {
+		_, offline := exporterOfflineSince(exporter, time.Time{})
+		var lease jumpstarterdevv1alpha1.Lease
+		if err := r.Get(ctx, key, &lease); err != nil {
+			return nil
+		}
+		degraded := meta.IsStatusConditionTrue(lease.Status.Conditions,
+			string(jumpstarterdevv1alpha1.LeaseConditionTypeDegraded))
+		if offline == degraded {
+			return nil
+		}
+	}
+	return []reconcile.Request{{NamespacedName: key}}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *LeaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&jumpstarterdevv1alpha1.Lease{}).
+		Watches(&jumpstarterdevv1alpha1.Exporter{}, handler.EnqueueRequestsFromMapFunc(r.leasesForExporter),
+			builder.WithPredicates(exporterAvailabilityChanged)).
+		Watches(&jumpstarterdevv1alpha1.Lease{}, handler.EnqueueRequestsFromMapFunc(r.pendingLeasesForLease),
+			builder.WithPredicates(leaseReleaseChanged)).
+		Watches(&jumpstarterdevv1alpha1.ExporterAccessPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.pendingLeasesForPolicy)).
+		Watches(&jumpstarterdevv1alpha1.ClientGroup{},
+			handler.EnqueueRequestsFromMapFunc(r.pendingLeasesForPolicy)).
 		Complete(r)
 }
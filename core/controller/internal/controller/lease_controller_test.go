@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -28,6 +29,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -265,6 +268,547 @@ var _ = Describe("Lease Controller", func() {
 		})
 	})
 
+	When("trying to lease exporters under spot access", func() {
+		var spotClient *jumpstarterdevv1alpha1.Client
+
+		BeforeEach(func() {
+			ctx := context.Background()
+			spotClient = &jumpstarterdevv1alpha1.Client{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "spot-client",
+					Namespace: "default",
+					Labels: map[string]string{
+						"role": "ci",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, spotClient)).To(Succeed())
+			Expect(k8sClient.Create(ctx, spotAccessPolicy.DeepCopy())).To(Succeed())
+		})
+		AfterEach(func() {
+			ctx := context.Background()
+			Expect(k8sClient.Delete(ctx, spotClient)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, spotAccessPolicy.DeepCopy())).To(Succeed())
+		})
+
+		It("should be acquired on an idle exporter", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.ClientRef.Name = spotClient.Name
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter3DutB.Name))
+			Expect(updatedLease.Status.SpotAccess).To(BeTrue())
+		})
+
+		It("should be preempted by a non-spot lease", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.ClientRef.Name = spotClient.Name
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			lease2.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+
+			preemptedLease := getLease(ctx, lease.Name)
+			Expect(preemptedLease.Status.Ended).To(BeTrue())
+			Expect(preemptedLease.Labels).To(HaveKey(string(jumpstarterdevv1alpha1.LeaseLabelEnded)))
+			Expect(meta.IsStatusConditionTrue(
+				preemptedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypePreempted),
+			)).To(BeTrue())
+
+			updatedLease := getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter3DutB.Name))
+			Expect(updatedLease.Status.SpotAccess).To(BeFalse())
+
+			updatedExporter := getExporter(ctx, testExporter3DutB.Name)
+			Expect(updatedExporter.Status.LeaseRef).NotTo(BeNil())
+			Expect(updatedExporter.Status.LeaseRef.Name).To(Equal(lease2.Name))
+		})
+
+		It("should wait for the exporter to clean up after the preempted spot lease", func() {
+			ctx := context.Background()
+			exporter := getExporter(ctx, testExporter3DutB.Name)
+			exporter.Spec.Cleanup = &jumpstarterdevv1alpha1.ExporterCleanup{}
+			Expect(k8sClient.Update(ctx, exporter)).To(Succeed())
+
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.ClientRef.Name = spotClient.Name
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.BeginTime).NotTo(BeNil())
+
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			lease2.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+
+			Expect(getLease(ctx, lease.Name).Status.Ended).To(BeTrue())
+			updatedExporter := getExporter(ctx, testExporter3DutB.Name)
+			Expect(updatedExporter.Status.Cleanup).NotTo(BeNil())
+			Expect(updatedExporter.Status.Cleanup.LeaseRef.Name).To(Equal(lease.Name))
+
+			updatedLease := getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter3DutB.Name))
+			Expect(updatedLease.Status.BeginTime).To(BeNil())
+			pending := meta.FindStatusCondition(updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypePending))
+			Expect(pending).NotTo(BeNil())
+			Expect(pending.Status).To(Equal(metav1.ConditionTrue))
+			Expect(pending.Reason).To(Equal("ExporterCleaning"))
+		})
+
+		It("should retry the preemption after failing to update the spot lease", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.ClientRef.Name = spotClient.Name
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			lease2.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+
+			failingReconciler := &LeaseReconciler{
+				Client:   &failingStatusClient{Client: k8sClient, name: lease.Name},
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := failingReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: lease2.Name},
+			})
+			Expect(err).To(HaveOccurred())
+
+			// the exporter has been assigned, but not handed over while the spot lease holds it
+			Expect(getLease(ctx, lease.Name).Status.Ended).To(BeFalse())
+			updatedLease := getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter3DutB.Name))
+			Expect(updatedLease.Status.BeginTime).To(BeNil())
+
+			_ = reconcileLease(ctx, lease2)
+
+			preemptedLease := getLease(ctx, lease.Name)
+			Expect(preemptedLease.Status.Ended).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(
+				preemptedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypePreempted),
+			)).To(BeTrue())
+
+			updatedLease = getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter3DutB.Name))
+			Expect(updatedLease.Status.BeginTime).NotTo(BeNil())
+		})
+
+		It("should not preempt another spot lease", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.ClientRef.Name = spotClient.Name
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			lease2 := lease.DeepCopy()
+			lease2.ObjectMeta = metav1.ObjectMeta{Name: "lease2", Namespace: "default"}
+			lease2.Status = jumpstarterdevv1alpha1.LeaseStatus{}
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+
+			Expect(getLease(ctx, lease.Name).Status.Ended).To(BeFalse())
+
+			updatedLease := getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypePending),
+			)).To(BeTrue())
+		})
+	})
+
+	When("trying to lease exporters under policies matching the groups and claims of clients", func() {
+		var teamClient *jumpstarterdevv1alpha1.Client
+
+		BeforeEach(func() {
+			ctx := context.Background()
+			teamClient = &jumpstarterdevv1alpha1.Client{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "team-client",
+					Namespace: "default",
+				},
+			}
+			Expect(k8sClient.Create(ctx, teamClient)).To(Succeed())
+			teamClient.Status.Groups = []string{"oidc:team-a"}
+			teamClient.Status.Claims = map[string][]string{"example.com/site": {"lab-1", "lab-2"}}
+			Expect(k8sClient.Status().Update(ctx, teamClient)).To(Succeed())
+
+			policy := &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "team-access",
+					Namespace: "default",
+				},
+				Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+					Policies: []jumpstarterdevv1alpha1.Policy{{
+						From: []jumpstarterdevv1alpha1.From{{
+							Groups: []string{"oidc:team-a", "oidc:team-b"},
+							Claims: []jumpstarterdevv1alpha1.ClaimRequirement{{
+								Claim:  "example.com/site",
+								Values: []string{"lab-2"},
+							}},
+						}},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+			})
+		})
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), teamClient)).To(Succeed())
+		})
+
+		It("should be acquired by the clients of the group", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.ClientRef.Name = teamClient.Name
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+		})
+
+		It("should be denied to the clients outside of the group", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			condition := meta.FindStatusCondition(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable),
+			)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("NoAccess"))
+		})
+	})
+
+	When("trying to lease an exporter for longer than the policies allow", func() {
+		BeforeEach(func() {
+			policy := &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "maximum-duration",
+					Namespace: "default",
+				},
+				Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+					Policies: []jumpstarterdevv1alpha1.Policy{{
+						From:            []jumpstarterdevv1alpha1.From{{}},
+						MaximumDuration: &metav1.Duration{Duration: time.Second},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), policy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+			})
+		})
+
+		It("should fail right away stating the maximum duration", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+
+			condition := meta.FindStatusCondition(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable),
+			)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("DurationExceedsPolicy"))
+			Expect(condition.Message).To(ContainSubstring("1s"))
+		})
+
+		It("should be clamped to the maximum duration when requested", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.ClampDuration = true
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Spec.Duration.Duration).To(Equal(time.Second))
+		})
+	})
+
+	When("trying to lease exporters under maintenance", func() {
+		It("should fail right away stating the maintenance", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+
+			ctx := context.Background()
+			setExporterMaintenance(ctx, testExporter1DutA.Name, false)
+			setExporterMaintenance(ctx, testExporter2DutA.Name, true)
+
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+
+			condition := meta.FindStatusCondition(updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("ExporterMaintenance"))
+			Expect(condition.Message).To(ContainSubstring("firmware upgrade"))
+		})
+
+		It("should acquire lease for the exporters in rotation", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+
+			ctx := context.Background()
+			setExporterMaintenance(ctx, testExporter1DutA.Name, false)
+
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter2DutA.Name))
+		})
+	})
+
+	When("putting a leased exporter under maintenance", func() {
+		It("should end the lease right away", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			setExporterMaintenance(ctx, testExporter3DutB.Name, false)
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.Ended).To(BeTrue())
+			condition := meta.FindStatusCondition(updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeReady))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("ExporterMaintenance"))
+
+			updatedExporter := getExporter(ctx, testExporter3DutB.Name)
+			Expect(updatedExporter.Status.LeaseRef).To(BeNil())
+		})
+
+		It("should let the lease finish when draining, but refuse new leases", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			setExporterMaintenance(ctx, testExporter3DutB.Name, true)
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.Ended).To(BeFalse())
+
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			lease2.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+
+			updatedLease2 := getLease(ctx, lease2.Name)
+			Expect(updatedLease2.Status.ExporterRef).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(
+				updatedLease2.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable),
+			)).To(BeTrue())
+		})
+	})
+
+	When("a leased exporter goes offline", func() {
+		acquire := func(ctx context.Context, action jumpstarterdevv1alpha1.ExporterLossAction) string {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Duration.Duration = time.Hour
+			lease.Spec.ExporterLossAction = action
+			lease.Spec.ExporterLossGracePeriod = &metav1.Duration{Duration: 0}
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			return updatedLease.Status.ExporterRef.Name
+		}
+
+		It("should not be degraded within the grace period", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Duration.Duration = time.Hour
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			exporterName := getLease(ctx, lease.Name).Status.ExporterRef.Name
+
+			setExporterOnlineConditions(ctx, exporterName, metav1.ConditionFalse)
+			result := reconcileLease(ctx, lease)
+			Expect(result.RequeueAfter).To(BeNumerically("<=", jumpstarterdevv1alpha1.DefaultExporterLossGracePeriod))
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(meta.IsStatusConditionTrue(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeDegraded),
+			)).To(BeFalse())
+		})
+
+		It("should be marked as degraded, and recover once the exporter is back", func() {
+			ctx := context.Background()
+			exporterName := acquire(ctx, "")
+
+			setExporterOnlineConditions(ctx, exporterName, metav1.ConditionFalse)
+			_ = reconcileLease(ctx, leaseDutA2Sec)
+
+			updatedLease := getLease(ctx, leaseDutA2Sec.Name)
+			Expect(updatedLease.Status.Ended).To(BeFalse())
+			condition := meta.FindStatusCondition(updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeDegraded))
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("ExporterLost"))
+			Expect(condition.Message).To(ContainSubstring(exporterName))
+
+			setExporterOnlineConditions(ctx, exporterName, metav1.ConditionTrue)
+			_ = reconcileLease(ctx, leaseDutA2Sec)
+
+			updatedLease = getLease(ctx, leaseDutA2Sec.Name)
+			Expect(meta.IsStatusConditionFalse(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeDegraded),
+			)).To(BeTrue())
+		})
+
+		It("should end the lease when requested", func() {
+			ctx := context.Background()
+			exporterName := acquire(ctx, jumpstarterdevv1alpha1.ExporterLossActionEnd)
+
+			setExporterOnlineConditions(ctx, exporterName, metav1.ConditionFalse)
+			_ = reconcileLease(ctx, leaseDutA2Sec)
+
+			updatedLease := getLease(ctx, leaseDutA2Sec.Name)
+			Expect(updatedLease.Status.Ended).To(BeTrue())
+			Expect(meta.FindStatusCondition(updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeReady)).Reason).To(Equal("ExporterLost"))
+		})
+
+		It("should re-acquire another exporter matching the selector when requested", func() {
+			ctx := context.Background()
+			exporterName := acquire(ctx, jumpstarterdevv1alpha1.ExporterLossActionReacquire)
+			beginTime := getLease(ctx, leaseDutA2Sec.Name).Status.BeginTime
+
+			setExporterOnlineConditions(ctx, exporterName, metav1.ConditionFalse)
+			_ = reconcileLease(ctx, leaseDutA2Sec)
+
+			updatedLease := getLease(ctx, leaseDutA2Sec.Name)
+			Expect(updatedLease.Status.Ended).To(BeFalse())
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+
+			_ = reconcileLease(ctx, leaseDutA2Sec)
+
+			updatedLease = getLease(ctx, leaseDutA2Sec.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).NotTo(Equal(exporterName))
+			Expect(updatedLease.Status.BeginTime.Equal(beginTime)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeReady),
+			)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeDegraded),
+			)).To(BeTrue())
+			Expect(updatedLease.OwnerReferences).To(HaveLen(1))
+			Expect(updatedLease.OwnerReferences[0].Name).To(Equal(updatedLease.Status.ExporterRef.Name))
+		})
+	})
+
+	When("trying to lease exporters by the labels of their devices", func() {
+		It("should acquire lease for an exporter reporting matching devices", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.DeviceSelectors = []metav1.LabelSelector{
+				{MatchLabels: map[string]string{"jumpstarter.dev/driver": "can", "bitrate": "500k"}},
+				{MatchLabels: map[string]string{"jumpstarter.dev/driver": "power"}},
+			}
+
+			ctx := context.Background()
+			setExporterDevices(ctx, testExporter1DutA.Name,
+				map[string]string{"jumpstarter.dev/driver": "can", "bitrate": "1M"},
+				map[string]string{"jumpstarter.dev/driver": "power"},
+			)
+			setExporterDevices(ctx, testExporter2DutA.Name,
+				map[string]string{"jumpstarter.dev/driver": "can", "bitrate": "500k"},
+				map[string]string{"jumpstarter.dev/driver": "power"},
+			)
+
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter2DutA.Name))
+		})
+
+		It("should fail right away if no exporter reports matching devices", func() {
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.DeviceSelectors = []metav1.LabelSelector{
+				{MatchLabels: map[string]string{"jumpstarter.dev/driver": "can"}},
+			}
+
+			ctx := context.Background()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable))).To(BeTrue())
+		})
+	})
+
 	When("releasing a lease early", func() {
 		It("should release the lease and exporter right away", func() {
 			lease := leaseDutA2Sec.DeepCopy()
@@ -297,6 +841,37 @@ var _ = Describe("Lease Controller", func() {
 	})
 })
 
+// spotAccessPolicy grants clients with the ci role spot access to all exporters,
+// while every other client gets regular access
+var spotAccessPolicy = &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "spot-access",
+		Namespace: "default",
+	},
+	Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+		Policies: []jumpstarterdevv1alpha1.Policy{
+			{
+				From: []jumpstarterdevv1alpha1.From{{
+					ClientSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"role": "ci"},
+					},
+				}},
+				SpotAccess: true,
+			},
+			{
+				From: []jumpstarterdevv1alpha1.From{{
+					ClientSelector: metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      "role",
+							Operator: metav1.LabelSelectorOpDoesNotExist,
+						}},
+					},
+				}},
+			},
+		},
+	},
+}
+
 var testExporter1DutA = &jumpstarterdevv1alpha1.Exporter{
 	ObjectMeta: metav1.ObjectMeta{
 		Name:      "exporter1-dut-a",
@@ -349,6 +924,40 @@ func setExporterOnlineConditions(ctx context.Context, name string, status metav1
 	Expect(k8sClient.Status().Update(ctx, exporter)).To(Succeed())
 }
 
+// failingStatusClient fails the status updates and patches of the object with the given name
+type failingStatusClient struct {
+	client.Client
+	name string
+}
+
+func (c *failingStatusClient) Status() client.SubResourceWriter {
+	return &failingStatusWriter{SubResourceWriter: c.Client.Status(), name: c.name}
+}
+
+type failingStatusWriter struct {
+	client.SubResourceWriter
+	name string
+}
+
+func (w *failingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if obj.GetName() == w.name {
+		return errors.New("injected status update failure")
+	}
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w *failingStatusWriter) Patch(
+	ctx context.Context,
+	obj client.Object,
+	patch client.Patch,
+	opts ...client.SubResourcePatchOption,
+) error {
+	if obj.GetName() == w.name {
+		return errors.New("injected status patch failure")
+	}
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
 func reconcileLease(ctx context.Context, lease *jumpstarterdevv1alpha1.Lease) reconcile.Result {
 
 	// reconcile the exporters
@@ -358,17 +967,19 @@ func reconcileLease(ctx context.Context, lease *jumpstarterdevv1alpha1.Lease) re
 	}
 
 	leaseReconciler := &LeaseReconciler{
-		Client: k8sClient,
-		Scheme: k8sClient.Scheme(),
+		Client:   k8sClient,
+		Scheme:   k8sClient.Scheme(),
+		Recorder: record.NewFakeRecorder(100),
 	}
 
 	signer, err := oidc.NewSignerFromSeed([]byte{}, "https://example.com", "dummy")
 	Expect(err).NotTo(HaveOccurred())
 
 	exporterReconciler := &ExporterReconciler{
-		Client: k8sClient,
-		Scheme: k8sClient.Scheme(),
-		Signer: signer,
+		Client:   k8sClient,
+		Scheme:   k8sClient.Scheme(),
+		Signer:   signer,
+		Recorder: record.NewFakeRecorder(100),
 	}
 
 	res, err := leaseReconciler.Reconcile(ctx, reconcile.Request{
@@ -505,6 +1116,32 @@ var _ = Describe("orderApprovedExporters", func() {
 		})
 	})
 
+	When("some approved exporters satisfy more affinity terms of the lease", func() {
+		It("should put them first, after the available ones", func() {
+			approvedExporters := []ApprovedExporter{
+				{
+					Policy:   jumpstarterdevv1alpha1.Policy{Priority: 10, SpotAccess: false},
+					Exporter: *testExporter1DutA,
+				},
+				{
+					Policy:        jumpstarterdevv1alpha1.Policy{Priority: 0, SpotAccess: false},
+					Exporter:      *testExporter3DutB,
+					ExistingLease: &jumpstarterdevv1alpha1.Lease{},
+					Affinity:      2,
+				},
+				{
+					Policy:   jumpstarterdevv1alpha1.Policy{Priority: 0, SpotAccess: true},
+					Exporter: *testExporter2DutA,
+					Affinity: 1,
+				},
+			}
+			ordered := orderApprovedExporters(approvedExporters)
+			Expect(ordered[0].Exporter.Name).To(Equal(testExporter2DutA.Name))
+			Expect(ordered[1].Exporter.Name).To(Equal(testExporter1DutA.Name))
+			Expect(ordered[2].Exporter.Name).To(Equal(testExporter3DutB.Name))
+		})
+	})
+
 	When("mixed priorities, spot access, lease status are in the list", func() {
 		It("should order them properly", func() {
 			approvedExporters := []ApprovedExporter{
@@ -555,3 +1192,23 @@ var _ = Describe("orderApprovedExporters", func() {
 		})
 	})
 })
+
+// setExporterMaintenance puts the exporter under maintenance, optionally draining it
+func setExporterMaintenance(ctx context.Context, name string, drain bool) {
+	exporter := getExporter(ctx, name)
+	exporter.Spec.Maintenance = &jumpstarterdevv1alpha1.ExporterMaintenance{
+		Reason: "firmware upgrade",
+		Owner:  "lab-admin",
+		Drain:  drain,
+	}
+	Expect(k8sClient.Update(ctx, exporter)).To(Succeed())
+}
+
+func setExporterDevices(ctx context.Context, name string, deviceLabels ...map[string]string) {
+	exporter := getExporter(ctx, name)
+	exporter.Status.Devices = nil
+	for _, labels := range deviceLabels {
+		exporter.Status.Devices = append(exporter.Status.Devices, jumpstarterdevv1alpha1.Device{Labels: labels})
+	}
+	Expect(k8sClient.Status().Update(ctx, exporter)).To(Succeed())
+}
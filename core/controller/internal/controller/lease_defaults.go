@@ -0,0 +1,88 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultLeaseDuration returns the default duration of the policies matching the client of the
+// lease, zero if none of them sets one. The policy with the highest priority wins, and the shortest
+// default among policies of the same priority, as the exporter the lease gets is not known yet.
+func DefaultLeaseDuration(
+	ctx context.Context,
+	c client.Reader,
+	lease *jumpstarterdevv1alpha1.Lease,
+) (time.Duration, error) {
+	var policies jumpstarterdevv1alpha1.ExporterAccessPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(lease.Namespace)); err != nil {
+		return 0, fmt.Errorf("DefaultLeaseDuration: failed to list exporter access policies: %w", err)
+	}
+	if len(policies.Items) == 0 {
+		return 0, nil
+	}
+
+	var jclient jumpstarterdevv1alpha1.Client
+	if err := c.Get(ctx, types.NamespacedName{
+		Namespace: lease.Namespace,
+		Name:      lease.Spec.ClientRef.Name,
+	}, &jclient); err != nil {
+		return 0, fmt.Errorf("DefaultLeaseDuration: failed to get client: %w", err)
+	}
+
+	groups, err := listClientGroups(ctx, c, lease.Namespace)
+	if err != nil {
+		return 0, fmt.Errorf("DefaultLeaseDuration: %w", err)
+	}
+
+	var duration time.Duration
+	priority := 0
+	for _, policy := range policies.Items {
+		for _, p := range policy.Spec.Policies {
+			if p.DefaultDuration == nil {
+				continue
+			}
+			matched := false
+			for _, from := range p.From {
+				matches, err := from.Matches(&jclient, groups[from.ClientGroupName()])
+				if err != nil {
+					return 0, fmt.Errorf("DefaultLeaseDuration: failed to convert client selector: %w", err)
+				}
+				if matches {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+			p = policyWithGroupDefaults(p, groups)
+			if duration == 0 || p.Priority > priority ||
+				(p.Priority == priority && p.DefaultDuration.Duration < duration) {
+				duration = p.DefaultDuration.Duration
+				priority = p.Priority
+			}
+		}
+	}
+	return duration, nil
+}
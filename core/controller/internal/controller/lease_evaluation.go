@@ -0,0 +1,242 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrLeaseInvalid is returned when a lease cannot be evaluated as it would be marked invalid
+var ErrLeaseInvalid = errors.New("invalid lease")
+
+// ExporterEvaluation explains why an exporter would, or would not, be assigned to a lease
+type ExporterEvaluation struct {
+	// Exporter is the evaluated exporter
+	Exporter jumpstarterdevv1alpha1.Exporter
+	// Member is the member of a gang lease the exporter has been evaluated for
+	Member string
+	// Included is true if the exporter is among the exporters the lease can be assigned,
+	// possibly once the lease holding it ends
+	Included bool
+	// Reason is a CamelCase reason the exporter has been included or excluded for
+	Reason string
+	// Message is a human readable explanation of the reason
+	Message string
+	// ApprovedExporter holds the policy that matched the exporter and the lease holding it,
+	// the policy is only set once the exporter passed the online and maintenance filters
+	ApprovedExporter *ApprovedExporter
+}
+
+// EvaluateLease runs the exporter selection of reconcileStatusExporterRef for a lease without
+// creating it, and explains for each exporter of the namespace why it has been included or
+// excluded, gang leases are evaluated once for each of their members
+func EvaluateLease(
+	ctx context.Context,
+	c client.Client,
+	lease *jumpstarterdevv1alpha1.Lease,
+) ([]ExporterEvaluation, error) {
+	memberNames := make(map[string]bool)
+	for _, member := range lease.Spec.Members {
+		if member.Name == "" || memberNames[member.Name] {
+			return nil, fmt.Errorf("%w: each member of the lease requires a unique name, got %q", ErrLeaseInvalid, member.Name)
+		}
+		memberNames[member.Name] = true
+	}
+
+	var exporters jumpstarterdevv1alpha1.ExporterList
+	if err := c.List(ctx, &exporters, client.InNamespace(lease.Namespace)); err != nil {
+		return nil, fmt.Errorf("EvaluateLease: failed to list exporters: %w", err)
+	}
+
+	activeLeases, err := listActiveLeases(ctx, c, lease.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("EvaluateLease: failed to list active leases: %w", err)
+	}
+
+	var ledger *quotaLedger
+	var evaluations []ExporterEvaluation
+	evaluated := make(map[string]bool)
+	for _, slot := range leaseSlotSelectors(lease) {
+		if evaluated[slot.Member] {
+			continue
+		}
+		evaluated[slot.Member] = true
+
+		suffix := ""
+		if slot.Member != "" {
+			suffix = fmt.Sprintf(" of member %s", slot.Member)
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&slot.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("%w: the selector%s is malformed: %w", ErrLeaseInvalid, suffix, err)
+		} else if selector.Empty() {
+			return nil, fmt.Errorf("%w: the selector%s is empty, a selector is required", ErrLeaseInvalid, suffix)
+		}
+		var deviceSelectors []labels.Selector
+		for _, deviceSelector := range slot.DeviceSelectors {
+			selector, err := metav1.LabelSelectorAsSelector(&deviceSelector)
+			if err != nil {
+				return nil, fmt.Errorf("%w: a device selector%s is malformed: %w", ErrLeaseInvalid, suffix, err)
+			}
+			deviceSelectors = append(deviceSelectors, selector)
+		}
+
+		results := make(map[string]*ExporterEvaluation)
+		var candidates []jumpstarterdevv1alpha1.Exporter
+		for _, exporter := range exporters.Items {
+			evaluation := &ExporterEvaluation{
+				Exporter: exporter,
+				Member:   slot.Member,
+			}
+			results[exporter.Name] = evaluation
+			switch {
+			case !selector.Matches(labels.Set(exporter.Labels)):
+				evaluation.Reason = "SelectorMismatch"
+				evaluation.Message = fmt.Sprintf("The labels of the exporter do not match the selector%s", suffix)
+			case !exporter.HasDevicesMatching(deviceSelectors):
+				evaluation.Reason = "DeviceMismatch"
+				evaluation.Message = fmt.Sprintf(
+					"The devices reported by the exporter do not match all of the device selectors%s", suffix)
+			case !exporterOnline(&exporter):
+				evaluation.Reason = "Offline"
+				evaluation.Message = "The exporter is not online"
+			case exporter.UnderMaintenance():
+				evaluation.Reason = "ExporterMaintenance"
+				evaluation.Message = "The " + exporter.MaintenanceMessage()
+			default:
+				evaluation.Reason = "NoAccess"
+				evaluation.Message = "The exporter is not approved by any policy for your client"
+				candidates = append(candidates, exporter)
+			}
+		}
+
+		approvedExporters, rejected, err := attachMatchingPolicies(ctx, c, lease, candidates)
+		if err != nil {
+			return nil, fmt.Errorf("EvaluateLease: failed to handle policy approval: %w", err)
+		}
+		// the reasons are recorded from the furthest to the closest to being approved,
+		// so that an exporter matched by several policies is explained by the best one
+		for _, ae := range rejected.TimeWindow {
+			results[ae.Exporter.Name].reject(ae, "OutsideTimeWindow",
+				"The policy does not allow leasing during the whole requested time, it crosses into a time window it forbids")
+		}
+		for _, ae := range rejected.Duration {
+			if lease.Spec.ClampDuration {
+				approvedExporters = append(approvedExporters, ae)
+				continue
+			}
+			results[ae.Exporter.Name].reject(ae, "DurationExceedsPolicy", fmt.Sprintf(
+				"The requested duration %s exceeds the maximum duration of %s allowed by the policy",
+				lease.Spec.Duration.Duration, ae.Policy.MaximumDuration.Duration))
+		}
+
+		if ledger == nil && len(approvedExporters) > 0 {
+			ledger, err = newQuotaLedger(ctx, c, lease.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("EvaluateLease: %w", err)
+			}
+		}
+		var allowedExporters []ApprovedExporter
+		for _, ae := range approvedExporters {
+			duration := lease.Spec.Duration.Duration
+			if ae.Policy.MaximumDuration != nil {
+				duration = min(duration, ae.Policy.MaximumDuration.Duration)
+			}
+			message, err := ledger.exceeded(ae, lease, duration, time.Now())
+			if err != nil {
+				return nil, fmt.Errorf("EvaluateLease: %w", err)
+			}
+			if message != "" {
+				results[ae.Exporter.Name].reject(ae, "QuotaExceeded", "The lease cannot be granted, "+message)
+				continue
+			}
+			allowedExporters = append(allowedExporters, ae)
+		}
+
+		allowedExporters = attachExistingLeases(lease, allowedExporters, activeLeases.Items)
+		allowedExporters, err = attachLeaseAffinity(ctx, c, lease, allowedExporters, activeLeases.Items)
+		if err != nil {
+			return nil, fmt.Errorf("EvaluateLease: failed to evaluate lease affinity: %w", err)
+		}
+		// the best policy for each exporter comes first
+		for _, ae := range orderApprovedExporters(allowedExporters) {
+			evaluation := results[ae.Exporter.Name]
+			if evaluation.Included {
+				continue
+			}
+			evaluation.include(lease, ae)
+		}
+
+		for _, exporter := range exporters.Items {
+			evaluations = append(evaluations, *results[exporter.Name])
+		}
+	}
+
+	return evaluations, nil
+}
+
+// reject records the reason the policy did not approve the exporter
+func (e *ExporterEvaluation) reject(ae ApprovedExporter, reason string, message string) {
+	e.Reason = reason
+	e.Message = message
+	e.ApprovedExporter = &ae
+}
+
+// include records that the policy approved the exporter, and whether the exporter is available
+func (e *ExporterEvaluation) include(lease *jumpstarterdevv1alpha1.Lease, ae ApprovedExporter) {
+	e.Included = true
+	e.ApprovedExporter = &ae
+
+	maximum := ae.Policy.MaximumDuration
+	clamped := maximum != nil && lease.Spec.Duration.Duration > maximum.Duration
+
+	switch {
+	case ae.ExistingLease == nil && ae.Exporter.IsCleaning() && !lease.IsScheduled():
+		e.Reason = "Cleaning"
+		e.Message = fmt.Sprintf("The exporter is cleaning up after the lease %s, the lease would be queued for it",
+			ae.Exporter.Status.Cleanup.LeaseRef.Name)
+	case ae.ExistingLease == nil && clamped:
+		e.Reason = "DurationClamped"
+		e.Message = fmt.Sprintf("The exporter is available, the lease would be shortened to the maximum duration of %s "+
+			"allowed by the policy", maximum.Duration)
+	case ae.ExistingLease == nil:
+		e.Reason = "Available"
+		e.Message = "The exporter is available"
+	case exporterTakeable(ae):
+		e.Reason = "Preemptible"
+		e.Message = fmt.Sprintf("The exporter is held by the spot lease %s, which would be preempted",
+			ae.ExistingLease.Name)
+	case lease.IsScheduled():
+		e.Included = false
+		e.Reason = "Conflict"
+		e.Message = fmt.Sprintf("The exporter is booked by the lease %s during the requested window",
+			ae.ExistingLease.Name)
+	default:
+		e.Reason = "Leased"
+		e.Message = fmt.Sprintf("The exporter is held by the lease %s, the lease would be queued for it",
+			ae.ExistingLease.Name)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("EvaluateLease", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter2DutA.Name, metav1.ConditionFalse)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1")
+	})
+
+	// evaluate evaluates lease1 without creating it, and indexes the result by exporter name
+	evaluate := func(ctx context.Context, lease *jumpstarterdevv1alpha1.Lease) map[string]ExporterEvaluation {
+		lease = lease.DeepCopy()
+		lease.Name = ""
+		evaluations, err := EvaluateLease(ctx, k8sClient, lease)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evaluations).To(HaveLen(3))
+
+		result := make(map[string]ExporterEvaluation)
+		for _, evaluation := range evaluations {
+			result[evaluation.Exporter.Name] = evaluation
+		}
+		return result
+	}
+
+	It("should explain why each exporter is included or excluded", func() {
+		ctx := context.Background()
+		evaluations := evaluate(ctx, leaseDutA2Sec)
+
+		Expect(evaluations[testExporter1DutA.Name].Included).To(BeTrue())
+		Expect(evaluations[testExporter1DutA.Name].Reason).To(Equal("Available"))
+		Expect(evaluations[testExporter2DutA.Name].Included).To(BeFalse())
+		Expect(evaluations[testExporter2DutA.Name].Reason).To(Equal("Offline"))
+		Expect(evaluations[testExporter3DutB.Name].Included).To(BeFalse())
+		Expect(evaluations[testExporter3DutB.Name].Reason).To(Equal("SelectorMismatch"))
+
+		var leases jumpstarterdevv1alpha1.LeaseList
+		Expect(k8sClient.List(ctx, &leases)).To(Succeed())
+		Expect(leases.Items).To(BeEmpty())
+	})
+
+	It("should report exporters under maintenance", func() {
+		ctx := context.Background()
+		setExporterMaintenance(ctx, testExporter1DutA.Name, false)
+
+		evaluations := evaluate(ctx, leaseDutA2Sec)
+		Expect(evaluations[testExporter1DutA.Name].Included).To(BeFalse())
+		Expect(evaluations[testExporter1DutA.Name].Reason).To(Equal("ExporterMaintenance"))
+	})
+
+	It("should report the lease holding an exporter", func() {
+		ctx := context.Background()
+		lease := leaseDutA2Sec.DeepCopy()
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+		Expect(getLease(ctx, lease.Name).Status.ExporterRef.Name).To(Equal(testExporter1DutA.Name))
+
+		evaluations := evaluate(ctx, leaseDutA2Sec)
+		Expect(evaluations[testExporter1DutA.Name].Included).To(BeTrue())
+		Expect(evaluations[testExporter1DutA.Name].Reason).To(Equal("Leased"))
+		Expect(evaluations[testExporter1DutA.Name].ApprovedExporter.ExistingLease.Name).To(Equal(lease.Name))
+	})
+
+	When("access policies exist", func() {
+		policy := &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "short-leases",
+				Namespace: "default",
+			},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				ExporterSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"dut": "a"},
+				},
+				Policies: []jumpstarterdevv1alpha1.Policy{{
+					From:            []jumpstarterdevv1alpha1.From{{}},
+					MaximumDuration: &metav1.Duration{Duration: time.Second},
+				}},
+			},
+		}
+		BeforeEach(func() {
+			Expect(k8sClient.Create(context.Background(), policy.DeepCopy())).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), policy.DeepCopy())).To(Succeed())
+		})
+
+		It("should report the policy rejecting the exporter", func() {
+			ctx := context.Background()
+			evaluations := evaluate(ctx, leaseDutA2Sec)
+
+			evaluation := evaluations[testExporter1DutA.Name]
+			Expect(evaluation.Included).To(BeFalse())
+			Expect(evaluation.Reason).To(Equal("DurationExceedsPolicy"))
+			Expect(evaluation.ApprovedExporter.AccessPolicy.Name).To(Equal(policy.Name))
+			Expect(evaluation.ApprovedExporter.PolicyIndex).To(Equal(0))
+		})
+
+		It("should report the exporters no policy approves", func() {
+			ctx := context.Background()
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+			evaluations := evaluate(ctx, lease)
+
+			evaluation := evaluations[testExporter3DutB.Name]
+			Expect(evaluation.Included).To(BeFalse())
+			Expect(evaluation.Reason).To(Equal("NoAccess"))
+			Expect(evaluation.ApprovedExporter).To(BeNil())
+		})
+
+		It("should include the exporters when the lease can be clamped", func() {
+			ctx := context.Background()
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.ClampDuration = true
+			evaluations := evaluate(ctx, lease)
+
+			evaluation := evaluations[testExporter1DutA.Name]
+			Expect(evaluation.Included).To(BeTrue())
+			Expect(evaluation.Reason).To(Equal("DurationClamped"))
+		})
+	})
+})
@@ -0,0 +1,159 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrLeaseDurationRejected is returned when a lease cannot be granted the requested duration
+var ErrLeaseDurationRejected = errors.New("lease duration rejected")
+
+// ValidateLeaseDuration checks whether a lease holding, or having reserved, an exporter can be
+// granted the requested total duration. The duration must be allowed by a policy matching the
+// client and the exporter, and the lease must not run into leases scheduled or queued for the
+// same exporter. Leases still waiting for an exporter are checked when the exporter is assigned.
+func ValidateLeaseDuration(
+	ctx context.Context,
+	c client.Client,
+	lease *jumpstarterdevv1alpha1.Lease,
+	duration time.Duration,
+) error {
+	if lease.Status.ExporterRef == nil {
+		return nil
+	}
+
+	var exporters []jumpstarterdevv1alpha1.Exporter
+	for _, name := range lease.GetExporterNames() {
+		var exporter jumpstarterdevv1alpha1.Exporter
+		if err := c.Get(ctx, types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      name,
+		}, &exporter); err != nil {
+			return fmt.Errorf("ValidateLeaseDuration: failed to get exporter: %w", err)
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	extended := lease.DeepCopy()
+	extended.Spec.Duration = metav1.Duration{Duration: duration}
+	if extended.IsScheduled() {
+		extended.Spec.EndTime = &metav1.Time{Time: extended.Spec.BeginTime.Add(duration)}
+	}
+
+	for _, exporter := range exporters {
+		approvedExporters, rejected, err := attachMatchingPolicies(ctx, c, extended,
+			[]jumpstarterdevv1alpha1.Exporter{exporter})
+		if err != nil {
+			return fmt.Errorf("ValidateLeaseDuration: failed to handle policy approval: %w", err)
+		}
+		if len(approvedExporters) == 0 && len(rejected.Duration) > 0 {
+			return fmt.Errorf("%w: the requested duration %s exceeds the maximum duration of %s allowed by the policies",
+				ErrLeaseDurationRejected, duration, maximumPolicyDuration(rejected.Duration))
+		}
+		if len(approvedExporters) == 0 && len(rejected.TimeWindow) > 0 {
+			return fmt.Errorf("%w: the lease would cross into a time window the policies forbid",
+				ErrLeaseDurationRejected)
+		}
+		if len(approvedExporters) == 0 {
+			return fmt.Errorf("%w: no policy allows leasing exporter %s for %s",
+				ErrLeaseDurationRejected, exporter.Name, duration)
+		}
+		approvedExporters, _, quotaMessage, err := filterOutQuotaExceeded(ctx, c, extended, approvedExporters, duration)
+		if err != nil {
+			return fmt.Errorf("ValidateLeaseDuration: failed to check policy quotas: %w", err)
+		}
+		if len(approvedExporters) == 0 {
+			return fmt.Errorf("%w: %s", ErrLeaseDurationRejected, quotaMessage)
+		}
+	}
+
+	// shortening a lease never conflicts with other leases
+	if duration <= lease.Spec.Duration.Duration {
+		return nil
+	}
+
+	activeLeases, err := listActiveLeases(ctx, c, lease.Namespace)
+	if err != nil {
+		return fmt.Errorf("ValidateLeaseDuration: failed to list active leases: %w", err)
+	}
+
+	now := time.Now()
+	begin, end := extended.GetRequestedWindow(now)
+	if extended.Status.BeginTime != nil {
+		begin = extended.Status.BeginTime.Time
+		end = *extended.GetExpirationTime()
+	}
+
+	for _, exporter := range exporters {
+		for _, other := range activeLeases.Items {
+			if other.Name == lease.Name || !other.HoldsExporter(exporter.Name) {
+				continue
+			}
+			if leaseOccupies(&other, begin, end, now) {
+				return fmt.Errorf("%w: exporter %s is booked by lease %s during the requested time",
+					ErrLeaseDurationRejected, exporter.Name, other.Name)
+			}
+		}
+	}
+
+	// leases queued for the exporters would otherwise have to wait for longer
+	othersLeases := slices.DeleteFunc(slices.Clone(activeLeases.Items), func(other jumpstarterdevv1alpha1.Lease) bool {
+		return other.Name == lease.Name
+	})
+	for i := range othersLeases {
+		pending := &othersLeases[i]
+		if !isLeasePending(pending) || pending.IsScheduled() {
+			continue
+		}
+		approvedSlots, err := approveSlotsForLease(ctx, c, pending)
+		if err != nil {
+			// a broken pending lease does not hold back the extension
+			continue
+		}
+		slots, err := attachSlotLeases(ctx, c, pending, approvedSlots, othersLeases)
+		if err != nil {
+			continue
+		}
+		request := LeaseRequest{Lease: pending, Slots: slots}
+		// spot leases never hold back a lease with regular access
+		if request.SpotAccess() && !lease.Status.SpotAccess {
+			continue
+		}
+		for _, slot := range request.Slots {
+			for _, exporter := range exporters {
+				if slices.ContainsFunc(slot.ApprovedExporters, func(ae ApprovedExporter) bool {
+					return ae.Exporter.Name == exporter.Name && exporterTakeable(ae)
+				}) {
+					return fmt.Errorf("%w: other leases are queued for exporter %s",
+						ErrLeaseDurationRejected, exporter.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ValidateLeaseDuration", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter2DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1", "lease2", "lease3")
+	})
+
+	acquireLeaseDutB := func(ctx context.Context) *jumpstarterdevv1alpha1.Lease {
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Spec.Selector.MatchLabels["dut"] = "b"
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+		updatedLease := getLease(ctx, lease.Name)
+		Expect(updatedLease.Status.BeginTime).NotTo(BeNil())
+		return updatedLease
+	}
+
+	When("extending a lease nobody else is waiting for", func() {
+		It("should be allowed", func() {
+			ctx := context.Background()
+			lease := acquireLeaseDutB(ctx)
+
+			Expect(ValidateLeaseDuration(ctx, k8sClient, lease, time.Hour)).To(Succeed())
+		})
+	})
+
+	When("extending a lease beyond the maximum duration of the policy", func() {
+		It("should be rejected", func() {
+			ctx := context.Background()
+			policy := &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "maximum-duration",
+					Namespace: "default",
+				},
+				Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+					Policies: []jumpstarterdevv1alpha1.Policy{{
+						From:            []jumpstarterdevv1alpha1.From{{}},
+						MaximumDuration: &metav1.Duration{Duration: 10 * time.Minute},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+			})
+
+			lease := acquireLeaseDutB(ctx)
+
+			Expect(ValidateLeaseDuration(ctx, k8sClient, lease, 10*time.Minute)).To(Succeed())
+			Expect(ValidateLeaseDuration(ctx, k8sClient, lease, time.Hour)).To(MatchError(ErrLeaseDurationRejected))
+		})
+	})
+
+	When("extending a lease into a booked window", func() {
+		It("should be rejected", func() {
+			ctx := context.Background()
+			lease := acquireLeaseDutB(ctx)
+
+			booking := leaseDutA2Sec.DeepCopy()
+			booking.Name = "lease2"
+			booking.Spec.Selector.MatchLabels["dut"] = "b"
+			booking.Spec.Duration.Duration = time.Hour
+			booking.Spec.BeginTime = &metav1.Time{Time: time.Now().Add(time.Hour)}
+			booking.Spec.EndTime = &metav1.Time{Time: time.Now().Add(2 * time.Hour)}
+			Expect(k8sClient.Create(ctx, booking)).To(Succeed())
+			_ = reconcileLease(ctx, booking)
+			Expect(getLease(ctx, booking.Name).Status.ExporterRef).NotTo(BeNil())
+
+			Expect(ValidateLeaseDuration(ctx, k8sClient, lease, 30*time.Minute)).To(Succeed())
+			Expect(ValidateLeaseDuration(ctx, k8sClient, lease, 2*time.Hour)).To(MatchError(ErrLeaseDurationRejected))
+		})
+	})
+
+	When("extending a lease other leases are queued for", func() {
+		It("should be rejected", func() {
+			ctx := context.Background()
+			lease := acquireLeaseDutB(ctx)
+
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			lease2.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+			Expect(getLease(ctx, lease2.Name).Status.ExporterRef).To(BeNil())
+
+			Expect(ValidateLeaseDuration(ctx, k8sClient, lease, time.Hour)).To(MatchError(ErrLeaseDurationRejected))
+			// shortening it is still fine
+			Expect(ValidateLeaseDuration(ctx, k8sClient, lease, time.Second)).To(Succeed())
+		})
+	})
+})
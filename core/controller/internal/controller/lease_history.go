@@ -0,0 +1,360 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// DefaultLeaseGarbageCollectionInterval is how often ended leases are garbage collected by default
+const DefaultLeaseGarbageCollectionInterval = 10 * time.Minute
+
+// LeaseRecord is the compact record of an ended lease kept by a lease archive
+type LeaseRecord struct {
+	Namespace  string     `json:"namespace"`
+	Name       string     `json:"name"`
+	Client     string     `json:"client"`
+	Exporters  []string   `json:"exporters,omitempty"`
+	BeginTime  *time.Time `json:"beginTime,omitempty"`
+	EndTime    *time.Time `json:"endTime,omitempty"`
+	EndReason  string     `json:"endReason,omitempty"`
+	Priority   int        `json:"priority"`
+	SpotAccess bool       `json:"spotAccess,omitempty"`
+}
+
+// NewLeaseRecord builds the archive record of an ended lease
+func NewLeaseRecord(lease *jumpstarterdevv1alpha1.Lease) LeaseRecord {
+	record := LeaseRecord{
+		Namespace:  lease.Namespace,
+		Name:       lease.Name,
+		Client:     lease.GetClientName(),
+		Exporters:  lease.GetExporterNames(),
+		Priority:   lease.Status.Priority,
+		SpotAccess: lease.Status.SpotAccess,
+	}
+	if lease.Status.BeginTime != nil {
+		record.BeginTime = &lease.Status.BeginTime.Time
+	}
+	if lease.Status.EndTime != nil {
+		record.EndTime = &lease.Status.EndTime.Time
+	}
+	if ready := meta.FindStatusCondition(
+		lease.Status.Conditions,
+		string(jumpstarterdevv1alpha1.LeaseConditionTypeReady),
+	); ready != nil {
+		record.EndReason = ready.Reason
+	}
+	return record
+}
+
+// LeaseArchive is a sink ended leases are written to before being garbage collected
+type LeaseArchive interface {
+	Archive(ctx context.Context, records []LeaseRecord) error
+}
+
+// JSONLinesLeaseArchive appends lease records to a file, one JSON document per line
+type JSONLinesLeaseArchive struct {
+	Path string
+
+	mutex sync.Mutex
+}
+
+func (a *JSONLinesLeaseArchive) Archive(_ context.Context, records []LeaseRecord) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	lines, err := marshalLeaseRecords(records)
+	if err != nil {
+		return fmt.Errorf("Archive: %w", err)
+	}
+
+	file, err := os.OpenFile(a.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("Archive: failed to open archive file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(lines); err != nil {
+		return fmt.Errorf("Archive: failed to write archive file: %w", err)
+	}
+	return file.Sync()
+}
+
+// ConfigMapLeaseArchive keeps the latest lease records in a ConfigMap, acting as a ring buffer
+// dropping the oldest records once the maximum number of records is reached
+type ConfigMapLeaseArchive struct {
+	Client         client.Client
+	Key            types.NamespacedName
+	MaximumRecords int
+}
+
+// ConfigMapLeaseArchiveDataKey is the key of the ConfigMap data the lease records are kept under
+const ConfigMapLeaseArchiveDataKey = "leases.jsonl"
+
+// DefaultConfigMapLeaseArchiveMaximumRecords is the default size of the ConfigMap ring buffer,
+// small enough for the records to stay well within the size limit of a ConfigMap
+const DefaultConfigMapLeaseArchiveMaximumRecords = 1000
+
+func (a *ConfigMapLeaseArchive) Archive(ctx context.Context, records []LeaseRecord) error {
+	lines, err := marshalLeaseRecords(records)
+	if err != nil {
+		return fmt.Errorf("Archive: %w", err)
+	}
+
+	maximumRecords := a.MaximumRecords
+	if maximumRecords <= 0 {
+		maximumRecords = DefaultConfigMapLeaseArchiveMaximumRecords
+	}
+
+	var configmap corev1.ConfigMap
+	if err := a.Client.Get(ctx, a.Key, &configmap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("Archive: failed to get archive configmap: %w", err)
+		}
+		configmap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: a.Key.Namespace,
+				Name:      a.Key.Name,
+			},
+			Data: map[string]string{
+				ConfigMapLeaseArchiveDataKey: trimLeaseRecordLines(string(lines), maximumRecords),
+			},
+		}
+		if err := a.Client.Create(ctx, &configmap); err != nil {
+			return fmt.Errorf("Archive: failed to create archive configmap: %w", err)
+		}
+		return nil
+	}
+
+	if configmap.Data == nil {
+		configmap.Data = make(map[string]string)
+	}
+	configmap.Data[ConfigMapLeaseArchiveDataKey] = trimLeaseRecordLines(
+		configmap.Data[ConfigMapLeaseArchiveDataKey]+string(lines),
+		maximumRecords,
+	)
+	if err := a.Client.Update(ctx, &configmap); err != nil {
+		return fmt.Errorf("Archive: failed to update archive configmap: %w", err)
+	}
+	return nil
+}
+
+// marshalLeaseRecords encodes the lease records as JSON lines
+func marshalLeaseRecords(records []LeaseRecord) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return nil, fmt.Errorf("marshalLeaseRecords: failed to encode lease record: %w", err)
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// trimLeaseRecordLines keeps the last maximum lines of the JSON lines
+func trimLeaseRecordLines(data string, maximum int) string {
+	lines := strings.SplitAfter(data, "\n")
+	lines = slices.DeleteFunc(lines, func(line string) bool {
+		return line == ""
+	})
+	if len(lines) > maximum {
+		lines = lines[len(lines)-maximum:]
+	}
+	return strings.Join(lines, "")
+}
+
+// LeaseGarbageCollector periodically deletes ended leases according to the retention policy,
+// writing them to the archive first if any. Leases are kept if they are recent enough, and
+// among the most recent leases of their client. Leases ended within the longest quota period
+// of the policies of their namespace count towards the leased time, they are always kept.
+type LeaseGarbageCollector struct {
+	Client client.Client
+	// MaximumAge is how long ended leases are kept for, zero keeps them regardless of their age,
+	// leases are kept for longer while they count towards a quota
+	MaximumAge time.Duration
+	// MaximumLeasesPerClient is how many ended leases are kept for each client, zero keeps them all,
+	// leases are kept beyond it while they count towards a quota
+	MaximumLeasesPerClient int
+	// Interval is how often ended leases are garbage collected
+	Interval time.Duration
+	// Archive is where the leases are written to before being deleted, nil to skip archival
+	Archive LeaseArchive
+}
+
+// NeedLeaderElection makes sure a single replica of the controller collects ended leases
+func (g *LeaseGarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the garbage collection loop until the context is cancelled
+func (g *LeaseGarbageCollector) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("lease-garbage-collector")
+	ctx = log.IntoContext(ctx, logger)
+
+	interval := g.Interval
+	if interval <= 0 {
+		interval = DefaultLeaseGarbageCollectionInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := g.Collect(ctx, time.Now()); err != nil {
+			logger.Error(err, "failed to garbage collect ended leases")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect archives and deletes the ended leases falling out of the retention policy
+func (g *LeaseGarbageCollector) Collect(ctx context.Context, now time.Time) error {
+	logger := log.FromContext(ctx)
+
+	var leases jumpstarterdevv1alpha1.LeaseList
+	if err := g.Client.List(ctx, &leases, MatchingEndedLeases()); err != nil {
+		return fmt.Errorf("Collect: failed to list ended leases: %w", err)
+	}
+
+	periods, err := g.quotaPeriods(ctx)
+	if err != nil {
+		return fmt.Errorf("Collect: %w", err)
+	}
+
+	expired := g.expiredLeases(leases.Items, periods, now)
+	if len(expired) == 0 {
+		return nil
+	}
+
+	if g.Archive != nil {
+		records := make([]LeaseRecord, 0, len(expired))
+		for i := range expired {
+			records = append(records, NewLeaseRecord(&expired[i]))
+		}
+		// leases are only deleted once archived, a failed deletion gets them archived again
+		if err := g.Archive.Archive(ctx, records); err != nil {
+			return fmt.Errorf("Collect: failed to archive ended leases: %w", err)
+		}
+	}
+
+	for i := range expired {
+		if err := g.Client.Delete(ctx, &expired[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("Collect: failed to delete ended lease: %w", err)
+		}
+	}
+
+	logger.Info("Garbage collected ended leases", "count", len(expired))
+	return nil
+}
+
+// quotaPeriods returns the longest period of the quotas on leased time of each namespace,
+// including the quotas client groups give their policies by default
+func (g *LeaseGarbageCollector) quotaPeriods(ctx context.Context) (map[string]time.Duration, error) {
+	periods := make(map[string]time.Duration)
+	account := func(namespace string, quota *jumpstarterdevv1alpha1.Quota) {
+		if quota != nil && quota.MaximumLeasedTime != nil && quota.GetPeriod() > periods[namespace] {
+			periods[namespace] = quota.GetPeriod()
+		}
+	}
+
+	var policies jumpstarterdevv1alpha1.ExporterAccessPolicyList
+	if err := g.Client.List(ctx, &policies); err != nil {
+		return nil, fmt.Errorf("quotaPeriods: failed to list exporter access policies: %w", err)
+	}
+	for _, policy := range policies.Items {
+		for _, p := range policy.Spec.Policies {
+			account(policy.Namespace, p.Quota)
+		}
+	}
+
+	var groups jumpstarterdevv1alpha1.ClientGroupList
+	if err := g.Client.List(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("quotaPeriods: failed to list client groups: %w", err)
+	}
+	for _, group := range groups.Items {
+		account(group.Namespace, group.Spec.Quota)
+	}
+	return periods, nil
+}
+
+// expiredLeases returns the ended leases falling out of the retention policy, leases ended
+// within the quota period of their namespace are kept as they count towards the leased time
+func (g *LeaseGarbageCollector) expiredLeases(
+	leases []jumpstarterdevv1alpha1.Lease,
+	periods map[string]time.Duration,
+	now time.Time,
+) []jumpstarterdevv1alpha1.Lease {
+	byClient := make(map[types.NamespacedName][]jumpstarterdevv1alpha1.Lease)
+	for _, lease := range leases {
+		if !lease.Status.Ended {
+			continue
+		}
+		key := types.NamespacedName{Namespace: lease.Namespace, Name: lease.GetClientName()}
+		byClient[key] = append(byClient[key], lease)
+	}
+
+	var expired []jumpstarterdevv1alpha1.Lease
+	for _, clientLeases := range byClient {
+		// most recently ended leases first
+		slices.SortStableFunc(clientLeases, func(a, b jumpstarterdevv1alpha1.Lease) int {
+			return leaseEndTime(&b).Compare(leaseEndTime(&a))
+		})
+		for i, lease := range clientLeases {
+			if now.Sub(leaseEndTime(&lease)) < periods[lease.Namespace] {
+				continue
+			}
+			tooOld := g.MaximumAge > 0 && now.Sub(leaseEndTime(&lease)) > g.MaximumAge
+			tooMany := g.MaximumLeasesPerClient > 0 && i >= g.MaximumLeasesPerClient
+			if tooOld || tooMany {
+				expired = append(expired, lease)
+			}
+		}
+	}
+	return expired
+}
+
+// leaseEndTime returns the time the lease ended at, falling back to its creation time
+func leaseEndTime(lease *jumpstarterdevv1alpha1.Lease) time.Time {
+	if lease.Status.EndTime != nil {
+		return lease.Status.EndTime.Time
+	}
+	return lease.CreationTimestamp.Time
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("LeaseGarbageCollector", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter2DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1", "lease2", "lease3")
+	})
+
+	// endLease acquires and releases a lease, setting the time it ended at
+	endLease := func(ctx context.Context, name string, endTime time.Time) {
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Name = name
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+
+		updatedLease := getLease(ctx, name)
+		Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+		updatedLease.Spec.Release = true
+		Expect(k8sClient.Update(ctx, updatedLease)).To(Succeed())
+		_ = reconcileLease(ctx, updatedLease)
+
+		updatedLease = getLease(ctx, name)
+		Expect(updatedLease.Status.Ended).To(BeTrue())
+		updatedLease.Status.EndTime = &metav1.Time{Time: endTime}
+		Expect(k8sClient.Status().Update(ctx, updatedLease)).To(Succeed())
+	}
+
+	leaseExists := func(ctx context.Context, name string) bool {
+		var lease jumpstarterdevv1alpha1.Lease
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, &lease)
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	When("a client has more ended leases than retained", func() {
+		It("should archive and delete the oldest ones", func() {
+			ctx := context.Background()
+			now := time.Now()
+			endLease(ctx, "lease1", now.Add(-time.Hour))
+			endLease(ctx, "lease2", now.Add(-time.Minute))
+
+			key := types.NamespacedName{Namespace: "default", Name: "lease-archive"}
+			collector := &LeaseGarbageCollector{
+				Client:                 k8sClient,
+				MaximumLeasesPerClient: 1,
+				Archive: &ConfigMapLeaseArchive{
+					Client: k8sClient,
+					Key:    key,
+				},
+			}
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(context.Background(), &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+				})).To(Succeed())
+			})
+			Expect(collector.Collect(ctx, now)).To(Succeed())
+
+			Expect(leaseExists(ctx, "lease1")).To(BeFalse())
+			Expect(leaseExists(ctx, "lease2")).To(BeTrue())
+
+			var configmap corev1.ConfigMap
+			Expect(k8sClient.Get(ctx, key, &configmap)).To(Succeed())
+			lines := strings.Split(strings.TrimSpace(configmap.Data[ConfigMapLeaseArchiveDataKey]), "\n")
+			Expect(lines).To(HaveLen(1))
+
+			var record LeaseRecord
+			Expect(json.Unmarshal([]byte(lines[0]), &record)).To(Succeed())
+			Expect(record.Name).To(Equal("lease1"))
+			Expect(record.Client).To(Equal(testClient.Name))
+			Expect(record.Exporters).To(HaveLen(1))
+			Expect(record.EndReason).To(Equal("Released"))
+			Expect(record.BeginTime).NotTo(BeNil())
+		})
+	})
+
+	When("ended leases are older than retained", func() {
+		It("should archive and delete them", func() {
+			ctx := context.Background()
+			now := time.Now()
+			endLease(ctx, "lease1", now.Add(-48*time.Hour))
+			endLease(ctx, "lease2", now.Add(-time.Hour))
+
+			path := filepath.Join(GinkgoT().TempDir(), "leases.jsonl")
+			collector := &LeaseGarbageCollector{
+				Client:     k8sClient,
+				MaximumAge: 24 * time.Hour,
+				Archive:    &JSONLinesLeaseArchive{Path: path},
+			}
+			Expect(collector.Collect(ctx, now)).To(Succeed())
+
+			Expect(leaseExists(ctx, "lease1")).To(BeFalse())
+			Expect(leaseExists(ctx, "lease2")).To(BeTrue())
+
+			data, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Count(string(data), "\n")).To(Equal(1))
+			Expect(string(data)).To(ContainSubstring(`"name":"lease1"`))
+		})
+	})
+
+	When("ended leases count towards a quota", func() {
+		It("should keep them until the quota period is over", func() {
+			ctx := context.Background()
+			policy := quotaPolicy(jumpstarterdevv1alpha1.Quota{
+				MaximumLeasedTime: &metav1.Duration{Duration: time.Hour},
+				Period:            &metav1.Duration{Duration: 2 * time.Hour},
+			})
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+			})
+
+			now := time.Now()
+			endLease(ctx, "lease1", now.Add(-3*time.Hour))
+			endLease(ctx, "lease2", now.Add(-time.Hour))
+			endLease(ctx, "lease3", now.Add(-time.Minute))
+
+			collector := &LeaseGarbageCollector{
+				Client:                 k8sClient,
+				MaximumAge:             time.Nanosecond,
+				MaximumLeasesPerClient: 1,
+			}
+			Expect(collector.Collect(ctx, now)).To(Succeed())
+
+			Expect(leaseExists(ctx, "lease1")).To(BeFalse())
+			Expect(leaseExists(ctx, "lease2")).To(BeTrue())
+			Expect(leaseExists(ctx, "lease3")).To(BeTrue())
+		})
+	})
+
+	When("leases are still active", func() {
+		It("should keep them", func() {
+			ctx := context.Background()
+			lease := leaseDutA2Sec.DeepCopy()
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			collector := &LeaseGarbageCollector{
+				Client:                 k8sClient,
+				MaximumAge:             time.Nanosecond,
+				MaximumLeasesPerClient: 1,
+			}
+			Expect(collector.Collect(ctx, time.Now().Add(time.Hour))).To(Succeed())
+			Expect(leaseExists(ctx, lease.Name)).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("trimLeaseRecordLines", func() {
+	It("should keep the most recent records", func() {
+		Expect(trimLeaseRecordLines("a\nb\nc\n", 2)).To(Equal("b\nc\n"))
+		Expect(trimLeaseRecordLines("a\n", 2)).To(Equal("a\n"))
+		Expect(trimLeaseRecordLines("", 2)).To(Equal(""))
+	})
+})
@@ -0,0 +1,143 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LeaseActivityResolution is how often the activity of a lease is recorded at most, activity
+// closer to the last recorded one is not written to the lease status
+const LeaseActivityResolution = 10 * time.Second
+
+// maximumIdleWarningPeriod is how long before an idle lease is released the client is warned
+// at most, shorter idle timeouts warn the client halfway through
+const maximumIdleWarningPeriod = 5 * time.Minute
+
+// RecordLeaseActivity records that the client dialed, or exchanged data with, the exporters
+// of the lease at the given time
+func RecordLeaseActivity(ctx context.Context, c client.Client, key types.NamespacedName, at time.Time) error {
+	var lease jumpstarterdevv1alpha1.Lease
+	if err := c.Get(ctx, key, &lease); err != nil {
+		return fmt.Errorf("RecordLeaseActivity: failed to get lease: %w", err)
+	}
+
+	if lease.Status.Ended {
+		return nil
+	}
+	if last := lease.Status.LastActivityTime; last != nil && at.Before(last.Add(LeaseActivityResolution)) {
+		return nil
+	}
+
+	original := client.MergeFrom(lease.DeepCopy())
+	lease.Status.LastActivityTime = &metav1.Time{Time: at}
+	if err := c.Status().Patch(ctx, &lease, original); err != nil {
+		return fmt.Errorf("RecordLeaseActivity: failed to patch lease status: %w", err)
+	}
+	return nil
+}
+
+// leaseIdleTimeout returns the idle timeout the policies approving the exporters of the lease
+// allow, each exporter is allowed to be idle for as long as the most lenient of the policies
+// matching it allows, and the lease for as long as the most lenient of its exporters
+func (r *LeaseReconciler) leaseIdleTimeout(
+	ctx context.Context,
+	lease *jumpstarterdevv1alpha1.Lease,
+) (time.Duration, bool, error) {
+	var exporters []jumpstarterdevv1alpha1.Exporter
+	for _, name := range lease.GetExporterNames() {
+		var exporter jumpstarterdevv1alpha1.Exporter
+		if err := r.Get(ctx, types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      name,
+		}, &exporter); err != nil {
+			return 0, false, fmt.Errorf("leaseIdleTimeout: failed to get exporter: %w", err)
+		}
+		exporters = append(exporters, exporter)
+	}
+
+	matchingExporters, err := matchingPolicies(ctx, r.Client, lease, exporters)
+	if err != nil {
+		return 0, false, fmt.Errorf("leaseIdleTimeout: %w", err)
+	}
+
+	var timeout time.Duration
+	for _, ae := range matchingExporters {
+		// a policy without an idle timeout lets the lease be idle forever
+		if ae.Policy.IdleTimeout == nil {
+			return 0, false, nil
+		}
+		timeout = max(timeout, ae.Policy.IdleTimeout.Duration)
+	}
+	return timeout, timeout > 0, nil
+}
+
+// reconcileStatusIdle releases acquired leases whose exporters have not been connected to for
+// longer than the idle timeout of their policies, the client is warned through the Idle
+// condition of the lease before it gets released
+func (r *LeaseReconciler) reconcileStatusIdle(
+	ctx context.Context,
+	result *ctrl.Result,
+	lease *jumpstarterdevv1alpha1.Lease,
+) error {
+	if lease.Status.Ended || lease.Status.BeginTime == nil || lease.Status.ExporterRef == nil {
+		return nil
+	}
+
+	timeout, ok, err := r.leaseIdleTimeout(ctx, lease)
+	if err != nil {
+		return fmt.Errorf("reconcileStatusIdle: %w", err)
+	}
+	if !ok {
+		meta.RemoveStatusCondition(&lease.Status.Conditions, string(jumpstarterdevv1alpha1.LeaseConditionTypeIdle))
+		return nil
+	}
+
+	now := time.Now()
+	since := *lease.GetIdleSince()
+	deadline := since.Add(timeout)
+	warning := deadline.Add(-min(maximumIdleWarningPeriod, timeout/2))
+
+	var next time.Time
+	switch {
+	case !deadline.After(now):
+		lease.EndIdle(ctx, timeout)
+		return nil
+	case !warning.After(now):
+		lease.SetStatusIdle(true, "IdleTimeoutApproaching",
+			"The lease has been idle since %s and will be released at %s unless its exporters are connected to",
+			since.Format(time.RFC3339), deadline.Format(time.RFC3339))
+		next = deadline
+	default:
+		lease.SetStatusIdle(false, "Active", "The lease will be released once idle for %s", timeout)
+		next = warning
+	}
+
+	if result.RequeueAfter == 0 || next.Sub(now) < result.RequeueAfter {
+		result.RequeueAfter = next.Sub(now)
+	}
+	return nil
+}
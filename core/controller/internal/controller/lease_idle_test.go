@@ -0,0 +1,140 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Idle leases", func() {
+	idlePolicy := &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "idle-timeout",
+			Namespace: "default",
+		},
+		Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+			Policies: []jumpstarterdevv1alpha1.Policy{{
+				From:        []jumpstarterdevv1alpha1.From{{}},
+				IdleTimeout: &metav1.Duration{Duration: time.Hour},
+			}},
+		},
+	}
+
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA)
+		deleteLeases(ctx, "lease1")
+	})
+
+	// acquireLongLease acquires lease1 for a day
+	acquireLongLease := func(ctx context.Context) *jumpstarterdevv1alpha1.Lease {
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Spec.Duration = metav1.Duration{Duration: 24 * time.Hour}
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+		Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+		return lease
+	}
+
+	// setLeaseIdleSince moves the begin time and last activity of the lease back to the given time
+	setLeaseIdleSince := func(ctx context.Context, name string, since time.Time) {
+		lease := getLease(ctx, name)
+		lease.Status.BeginTime = &metav1.Time{Time: since}
+		lease.Status.LastActivityTime = &metav1.Time{Time: since}
+		Expect(k8sClient.Status().Update(ctx, lease)).To(Succeed())
+	}
+
+	When("the policy has an idle timeout", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(context.Background(), idlePolicy.DeepCopy())).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(k8sClient.Delete(context.Background(), idlePolicy.DeepCopy())).To(Succeed())
+		})
+
+		It("should release the lease once idle for longer than the timeout", func() {
+			ctx := context.Background()
+			lease := acquireLongLease(ctx)
+			setLeaseIdleSince(ctx, lease.Name, time.Now().Add(-2*time.Hour))
+
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.Ended).To(BeTrue())
+			ready := meta.FindStatusCondition(updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeReady))
+			Expect(ready.Reason).To(Equal("IdleTimeout"))
+		})
+
+		It("should warn the client before releasing the lease", func() {
+			ctx := context.Background()
+			lease := acquireLongLease(ctx)
+			setLeaseIdleSince(ctx, lease.Name, time.Now().Add(-58*time.Minute))
+
+			result := reconcileLease(ctx, lease)
+			Expect(result.RequeueAfter).To(BeNumerically("<=", 2*time.Minute))
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.Ended).To(BeFalse())
+			Expect(meta.IsStatusConditionTrue(updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeIdle))).To(BeTrue())
+		})
+
+		It("should keep the lease once there is activity again", func() {
+			ctx := context.Background()
+			lease := acquireLongLease(ctx)
+			setLeaseIdleSince(ctx, lease.Name, time.Now().Add(-58*time.Minute))
+			_ = reconcileLease(ctx, lease)
+
+			Expect(RecordLeaseActivity(ctx, k8sClient, types.NamespacedName{
+				Namespace: lease.Namespace,
+				Name:      lease.Name,
+			}, time.Now())).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.Ended).To(BeFalse())
+			Expect(meta.IsStatusConditionFalse(updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeIdle))).To(BeTrue())
+		})
+	})
+
+	It("should not release leases when no policy has an idle timeout", func() {
+		ctx := context.Background()
+		lease := acquireLongLease(ctx)
+		setLeaseIdleSince(ctx, lease.Name, time.Now().Add(-2*time.Hour))
+
+		_ = reconcileLease(ctx, lease)
+
+		updatedLease := getLease(ctx, lease.Name)
+		Expect(updatedLease.Status.Ended).To(BeFalse())
+		Expect(meta.FindStatusCondition(updatedLease.Status.Conditions,
+			string(jumpstarterdevv1alpha1.LeaseConditionTypeIdle))).To(BeNil())
+	})
+})
@@ -0,0 +1,537 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// LeaseSlot represents an exporter requested by a pending lease, along with the exporters
+// that could fill it, in order of preference. Leases request a single exporter, unless they
+// are gang leases requesting exporters for several members.
+type LeaseSlot struct {
+	// Name is the name the exporter is addressed by within a gang lease, empty otherwise
+	Name string
+	// Member is the gang lease member the slot belongs to, empty otherwise
+	Member string
+	// ApprovedExporters are the exporters the slot could be filled with, ordered by preference
+	ApprovedExporters []ApprovedExporter
+}
+
+// LeaseRequest represents a pending lease waiting for exporters, along with
+// the slots it needs to fill to be acquired.
+type LeaseRequest struct {
+	// Lease is the pending lease
+	Lease *jumpstarterdevv1alpha1.Lease
+	// Slots are the exporters requested by the lease, all of them must be filled at once
+	Slots []LeaseSlot
+}
+
+// SpotAccess returns true if the most preferred exporter of any slot of the request
+// is only accessible under spot access
+func (lr *LeaseRequest) SpotAccess() bool {
+	return slices.ContainsFunc(lr.Slots, func(slot LeaseSlot) bool {
+		return len(slot.ApprovedExporters) > 0 && slot.ApprovedExporters[0].Policy.SpotAccess
+	})
+}
+
+// Priority returns the priority the request is scheduled with, which is the lowest
+// priority among the policies approving the most preferred exporter of each slot
+func (lr *LeaseRequest) Priority() int {
+	priority := 0
+	for i, slot := range lr.Slots {
+		if len(slot.ApprovedExporters) == 0 {
+			continue
+		}
+		if i == 0 || slot.ApprovedExporters[0].Policy.Priority < priority {
+			priority = slot.ApprovedExporters[0].Policy.Priority
+		}
+	}
+	return priority
+}
+
+// LeaseSchedule is the outcome of a scheduling pass over the pending leases of a namespace
+type LeaseSchedule struct {
+	// Queue contains the lease requests in the order they were served
+	Queue []LeaseRequest
+	// Assignments maps the name of a pending lease to the exporters it has been assigned,
+	// one for each of its slots
+	Assignments map[string][]ApprovedExporter
+	// EstimatedBeginTimes maps the name of a pending lease to the time it is expected
+	// to be assigned exporters at
+	EstimatedBeginTimes map[string]time.Time
+}
+
+// Position returns the zero based position of the lease in the scheduling queue, only
+// counting the requests ahead of it contending for some of the same exporters,
+// or -1 if the lease is not queued
+func (s *LeaseSchedule) Position(leaseName string) int {
+	index := slices.IndexFunc(s.Queue, func(lr LeaseRequest) bool {
+		return lr.Lease.Name == leaseName
+	})
+	if index < 0 {
+		return -1
+	}
+
+	candidates := s.Queue[index].candidateNames()
+	position := 0
+	for _, ahead := range s.Queue[:index] {
+		if slices.ContainsFunc(ahead.candidateNames(), func(name string) bool {
+			return slices.Contains(candidates, name)
+		}) {
+			position++
+		}
+	}
+	return position
+}
+
+// candidateNames returns the names of the exporters any slot of the request could be filled with
+func (lr *LeaseRequest) candidateNames() []string {
+	var names []string
+	for _, slot := range lr.Slots {
+		for _, candidate := range slot.ApprovedExporters {
+			names = append(names, candidate.Exporter.Name)
+		}
+	}
+	return names
+}
+
+// orderLeaseRequests orders the lease requests in the following order
+// 1. Not under spot access
+// 2. Highest priority
+// 3. Oldest creation time
+// 4. Alphabetically by lease name
+func orderLeaseRequests(requests []LeaseRequest) []LeaseRequest {
+	cmpFunc := func(a, b LeaseRequest) int {
+		// Spot access requests only get exporters nobody else is waiting for
+		if a.SpotAccess() != b.SpotAccess() {
+			if a.SpotAccess() {
+				return 1
+			}
+			return -1
+		}
+
+		// We want the highest priority to be first
+		if a.Priority() != b.Priority() {
+			return b.Priority() - a.Priority()
+		}
+
+		// Among requests with the same priority, the one waiting for longer goes first
+		if !a.Lease.CreationTimestamp.Equal(&b.Lease.CreationTimestamp) {
+			if a.Lease.CreationTimestamp.Before(&b.Lease.CreationTimestamp) {
+				return -1
+			}
+			return 1
+		}
+
+		// If the creation time is the same, we want to sort by lease name
+		return strings.Compare(a.Lease.Name, b.Lease.Name)
+	}
+
+	slices.SortStableFunc(requests, cmpFunc)
+
+	return requests
+}
+
+// scheduleLeaseRequests assigns exporters to the lease requests in a single pass,
+// serving the requests in queue order, each one getting its most preferred exporters
+// that are not held by other leases, and have not been assigned for an overlapping window
+// to a request earlier in the queue. Requests are only assigned exporters once all of their
+// slots can be filled. The time the requests left waiting are expected to be assigned
+// exporters at is estimated along the way, assuming leases last for their whole duration.
+func scheduleLeaseRequests(requests []LeaseRequest, now time.Time) *LeaseSchedule {
+	schedule := &LeaseSchedule{
+		Queue:               orderLeaseRequests(requests),
+		Assignments:         make(map[string][]ApprovedExporter),
+		EstimatedBeginTimes: make(map[string]time.Time),
+	}
+
+	assigned := make(map[string][]*jumpstarterdevv1alpha1.Lease)
+	releaseTimes := make(map[string]time.Time)
+	releaseTime := func(candidate ApprovedExporter) time.Time {
+		if t, ok := releaseTimes[candidate.Exporter.Name]; ok {
+			return t
+		}
+		return exporterReleaseTime(candidate, now)
+	}
+
+	for _, request := range schedule.Queue {
+		begin, end := request.Lease.GetRequestedWindow(now)
+		free := func(candidate ApprovedExporter) bool {
+			// bookings of exporters still cleaning up only begin once the cleanup is done
+			return exporterTakeable(candidate) && (request.Lease.IsScheduled() || !candidate.Exporter.IsCleaning()) &&
+				!slices.ContainsFunc(assigned[candidate.Exporter.Name], func(other *jumpstarterdevv1alpha1.Lease) bool {
+					otherBegin, otherEnd := other.GetRequestedWindow(now)
+					return windowsOverlap(begin, end, otherBegin, otherEnd)
+				})
+		}
+
+		if selection := fillLeaseSlots(request.Slots, free); selection != nil {
+			for _, selected := range selection {
+				assigned[selected.Exporter.Name] = append(assigned[selected.Exporter.Name], request.Lease)
+				if !request.Lease.IsScheduled() {
+					releaseTimes[selected.Exporter.Name] = end
+				}
+			}
+			schedule.Assignments[request.Lease.Name] = selection
+			continue
+		}
+
+		// scheduled leases are either booked right away or refused, they do not wait
+		if request.Lease.IsScheduled() {
+			continue
+		}
+
+		estimate, selection := estimateLeaseSlots(request.Slots, releaseTime)
+		if selection == nil {
+			continue
+		}
+		estimate = laterOf(estimate, now)
+		for _, selected := range selection {
+			releaseTimes[selected.Exporter.Name] = estimate.Add(request.Lease.Spec.Duration.Duration)
+		}
+		schedule.EstimatedBeginTimes[request.Lease.Name] = estimate
+	}
+
+	return schedule
+}
+
+// estimateLeaseSlots picks a different exporter for each slot, the one expected to be released
+// the earliest, and returns the time all of them are expected to be released at, along with the
+// picked exporters, or nil if not all the slots can be filled
+func estimateLeaseSlots(slots []LeaseSlot, releaseTime func(ApprovedExporter) time.Time) (time.Time, []ApprovedExporter) {
+	var estimate time.Time
+	if len(slots) == 0 {
+		return estimate, nil
+	}
+
+	selection := make([]ApprovedExporter, 0, len(slots))
+	used := make(map[string]bool)
+	for _, slot := range slots {
+		var earliest *ApprovedExporter
+		for i, candidate := range slot.ApprovedExporters {
+			if used[candidate.Exporter.Name] {
+				continue
+			}
+			if earliest == nil || releaseTime(candidate).Before(releaseTime(*earliest)) {
+				earliest = &slot.ApprovedExporters[i]
+			}
+		}
+		if earliest == nil {
+			return estimate, nil
+		}
+		used[earliest.Exporter.Name] = true
+		selection = append(selection, *earliest)
+		estimate = laterOf(estimate, releaseTime(*earliest))
+	}
+	return estimate, selection
+}
+
+// exporterReleaseTime returns the time an exporter is expected to be released by the lease
+// currently holding, or having reserved it, and to be done cleaning up after its previous lease
+func exporterReleaseTime(candidate ApprovedExporter, now time.Time) time.Time {
+	release := now
+	if !exporterTakeable(candidate) {
+		if expiration := candidate.ExistingLease.GetExpirationTime(); expiration != nil {
+			release = laterOf(*expiration, now)
+		} else {
+			_, release = candidate.ExistingLease.GetRequestedWindow(now)
+		}
+	}
+	if candidate.Exporter.IsCleaning() {
+		release = laterOf(release, candidate.Exporter.CleanupDeadline())
+	}
+	return release
+}
+
+// laterOf returns the latest of two times
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// fillLeaseSlots picks a different free exporter for each slot, and returns nil if not all the
+// slots can be filled. Slots are filled in order with their most preferred free exporter no other
+// slot has picked, a slot only gives up its exporter for another one when a later slot cannot be
+// filled otherwise. This is a bipartite matching of the slots to the exporters, found through
+// augmenting paths, so that interchangeable slots are not tried in every possible order.
+func fillLeaseSlots(slots []LeaseSlot, free func(ApprovedExporter) bool) []ApprovedExporter {
+	if len(slots) == 0 {
+		return nil
+	}
+
+	selection := make([]ApprovedExporter, len(slots))
+	// filled maps the name of each picked exporter to the slot it fills
+	filled := make(map[string]int)
+
+	var augment func(i int, visited map[string]bool) bool
+	augment = func(i int, visited map[string]bool) bool {
+		// an exporter no slot has picked is preferred over moving another slot
+		for _, candidate := range slots[i].ApprovedExporters {
+			if _, ok := filled[candidate.Exporter.Name]; !ok && free(candidate) {
+				filled[candidate.Exporter.Name] = i
+				selection[i] = candidate
+				return true
+			}
+		}
+		for _, candidate := range slots[i].ApprovedExporters {
+			name := candidate.Exporter.Name
+			j, ok := filled[name]
+			if !ok || visited[name] || !free(candidate) {
+				continue
+			}
+			visited[name] = true
+			if augment(j, visited) {
+				filled[name] = i
+				selection[i] = candidate
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := range slots {
+		if !augment(i, make(map[string]bool)) {
+			return nil
+		}
+	}
+	return selection
+}
+
+// countExporters returns the number of different exporters among the approved exporters, which
+// list an exporter once for each of the policies approving it
+func countExporters(exporters []ApprovedExporter) int {
+	names := make(map[string]bool)
+	for _, ae := range exporters {
+		names[ae.Exporter.Name] = true
+	}
+	return len(names)
+}
+
+// scheduleNamespace builds a view of all pending leases in the namespace of the lease being
+// reconciled and runs a scheduling pass over them. The lease being reconciled is included
+// with the slots already computed for it, other pending leases are evaluated here.
+func (r *LeaseReconciler) scheduleNamespace(
+	ctx context.Context,
+	request LeaseRequest,
+	activeLeases []jumpstarterdevv1alpha1.Lease,
+) *LeaseSchedule {
+	logger := log.FromContext(ctx)
+
+	requests := []LeaseRequest{request}
+	for i := range activeLeases {
+		pending := &activeLeases[i]
+		if pending.Name == request.Lease.Name || !isLeasePending(pending) {
+			continue
+		}
+
+		slots, err := r.approvedSlotsForLease(ctx, pending, activeLeases)
+		if err != nil {
+			// A broken lease should not prevent the others from being scheduled,
+			// it will report its own error when it gets reconciled
+			logger.Error(err, "scheduleNamespace: failed to evaluate pending lease", "lease", pending.Name)
+			continue
+		}
+		if slots == nil {
+			continue
+		}
+
+		requests = append(requests, LeaseRequest{
+			Lease: pending,
+			Slots: slots,
+		})
+	}
+
+	return scheduleLeaseRequests(requests, time.Now())
+}
+
+// approvedSlotsForLease returns the slots of a pending lease, each one with the ordered list
+// of exporters it could be filled with, including the ones held by other leases, following
+// the same rules as reconcileStatusExporterRef. It returns nil if any of the members cannot be
+// filled by as many approved exporters as it requests. The policy approval of the lease is
+// cached until the exporters, leases or policies of the namespace change.
+func (r *LeaseReconciler) approvedSlotsForLease(
+	ctx context.Context,
+	lease *jumpstarterdevv1alpha1.Lease,
+	activeLeases []jumpstarterdevv1alpha1.Lease,
+) ([]LeaseSlot, error) {
+	now := time.Now()
+	approvedSlots, ok := r.approvals.get(lease, now)
+	if !ok {
+		epoch := r.approvals.epoch(lease.Namespace)
+		var err error
+		approvedSlots, err = approveSlotsForLease(ctx, r.Client, lease)
+		if err != nil {
+			return nil, err
+		}
+		r.approvals.put(lease, epoch, approvedSlots, now)
+	}
+	return attachSlotLeases(ctx, r.Client, lease, approvedSlots, activeLeases)
+}
+
+// attachSlotLeases returns the slots approved for a pending lease with the existing leases and
+// the lease affinity attached to their exporters, ordered by preference. It returns nil if the
+// lease has no approved slots.
+func attachSlotLeases(
+	ctx context.Context,
+	c client.Client,
+	lease *jumpstarterdevv1alpha1.Lease,
+	approvedSlots []LeaseSlot,
+	activeLeases []jumpstarterdevv1alpha1.Lease,
+) ([]LeaseSlot, error) {
+	if approvedSlots == nil {
+		return nil, nil
+	}
+
+	slots := make([]LeaseSlot, 0, len(approvedSlots))
+	// slots of a same member share the exporters they can be filled with
+	approvedByMember := make(map[string][]ApprovedExporter)
+	for _, slot := range approvedSlots {
+		approvedExporters, ok := approvedByMember[slot.Member]
+		if !ok {
+			// the cached approval is shared, the existing leases are attached to a copy of it
+			approvedExporters = attachExistingLeases(lease, slices.Clone(slot.ApprovedExporters), activeLeases)
+			var err error
+			approvedExporters, err = attachLeaseAffinity(ctx, c, lease, approvedExporters, activeLeases)
+			if err != nil {
+				return nil, fmt.Errorf("attachSlotLeases: failed to evaluate lease affinity: %w", err)
+			}
+			approvedExporters = orderApprovedExporters(approvedExporters)
+			approvedByMember[slot.Member] = approvedExporters
+		}
+		slots = append(slots, LeaseSlot{
+			Name:              slot.Name,
+			Member:            slot.Member,
+			ApprovedExporters: approvedExporters,
+		})
+	}
+	return slots, nil
+}
+
+// approveSlotsForLease returns the slots of a pending lease, each one with the exporters the
+// policies approve it to be filled with, within their quotas. It returns nil if any of the
+// members cannot be filled by as many approved exporters as it requests.
+func approveSlotsForLease(
+	ctx context.Context,
+	c client.Client,
+	lease *jumpstarterdevv1alpha1.Lease,
+) ([]LeaseSlot, error) {
+	var slots []LeaseSlot
+	approvedByMember := make(map[string][]ApprovedExporter)
+	for _, slot := range leaseSlotSelectors(lease) {
+		if approvedExporters, ok := approvedByMember[slot.Member]; ok {
+			slots = append(slots, LeaseSlot{
+				Name:              slot.Name,
+				Member:            slot.Member,
+				ApprovedExporters: approvedExporters,
+			})
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&slot.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("approveSlotsForLease: failed to get exporter selector: %w", err)
+		} else if selector.Empty() {
+			return nil, nil
+		}
+
+		matchingExporters, err := listMatchingExporters(ctx, c, lease, selector, slot.DeviceSelectors)
+		if err != nil {
+			return nil, fmt.Errorf("approveSlotsForLease: failed to list matching exporters: %w", err)
+		}
+
+		onlineExporters := filterOutMaintenanceExporters(filterOutOfflineExporters(matchingExporters.Items))
+		if len(onlineExporters) < slot.Count {
+			return nil, nil
+		}
+
+		approvedExporters, _, err := attachMatchingPolicies(ctx, c, lease, onlineExporters)
+		if err != nil {
+			return nil, fmt.Errorf("approveSlotsForLease: failed to handle policy approval: %w", err)
+		}
+
+		approvedExporters, _, _, err = filterOutQuotaExceeded(ctx, c, lease,
+			approvedExporters, lease.Spec.Duration.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("approveSlotsForLease: failed to check policy quotas: %w", err)
+		}
+		if countExporters(approvedExporters) < slot.Count {
+			return nil, nil
+		}
+
+		approvedByMember[slot.Member] = approvedExporters
+		slots = append(slots, LeaseSlot{
+			Name:              slot.Name,
+			Member:            slot.Member,
+			ApprovedExporters: approvedExporters,
+		})
+	}
+	return slots, nil
+}
+
+// leaseSlotSelector is the selector for the exporter of a slot of a lease
+type leaseSlotSelector struct {
+	Name     string
+	Member   string
+	Selector metav1.LabelSelector
+	// DeviceSelectors must each be matched by a device reported by the exporter
+	DeviceSelectors []metav1.LabelSelector
+	// Count is the number of exporters requested by the member of the slot, one outside of
+	// gang leases
+	Count int
+}
+
+// leaseSlotSelectors returns the selectors for each of the exporters requested by the lease
+func leaseSlotSelectors(lease *jumpstarterdevv1alpha1.Lease) []leaseSlotSelector {
+	if !lease.IsGang() {
+		return []leaseSlotSelector{{
+			Selector:        lease.Spec.Selector,
+			DeviceSelectors: lease.Spec.DeviceSelectors,
+			Count:           1,
+		}}
+	}
+
+	var slots []leaseSlotSelector
+	for _, member := range lease.Spec.Members {
+		for i := range member.GetCount() {
+			slots = append(slots, leaseSlotSelector{
+				Name:            member.GetSlotName(i),
+				Member:          member.Name,
+				Selector:        member.Selector,
+				DeviceSelectors: member.DeviceSelectors,
+				Count:           member.GetCount(),
+			})
+		}
+	}
+	return slots
+}
+
+// isLeasePending returns true if the lease is still waiting for an exporter to be assigned
+func isLeasePending(lease *jumpstarterdevv1alpha1.Lease) bool {
+	return !lease.Status.Ended && !lease.Spec.Release && lease.Status.ExporterRef == nil
+}
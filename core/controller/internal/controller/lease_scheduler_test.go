@@ -0,0 +1,569 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testLeaseRequest(name string, created time.Time, priority int, exporters ...*jumpstarterdevv1alpha1.Exporter) LeaseRequest {
+	request := LeaseRequest{
+		Lease: &jumpstarterdevv1alpha1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: jumpstarterdevv1alpha1.LeaseSpec{
+				Duration: metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+	var slot LeaseSlot
+	for _, exporter := range exporters {
+		slot.ApprovedExporters = append(slot.ApprovedExporters, ApprovedExporter{
+			Exporter: *exporter,
+			Policy:   jumpstarterdevv1alpha1.Policy{Priority: priority},
+		})
+	}
+	request.Slots = []LeaseSlot{slot}
+	return request
+}
+
+var _ = Describe("scheduleLeaseRequests", func() {
+	now := time.Now()
+
+	When("several leases contend for the same exporter", func() {
+		It("should assign it to the highest priority lease", func() {
+			schedule := scheduleLeaseRequests([]LeaseRequest{
+				testLeaseRequest("lease1", now.Add(-time.Minute), 0, testExporter3DutB),
+				testLeaseRequest("lease2", now, 10, testExporter3DutB),
+			}, now)
+
+			Expect(schedule.Assignments).To(HaveLen(1))
+			Expect(schedule.Assignments).To(HaveKey("lease2"))
+			Expect(schedule.Position("lease2")).To(Equal(0))
+			Expect(schedule.Position("lease1")).To(Equal(1))
+		})
+
+		It("should assign it to the oldest lease when priorities are equal", func() {
+			schedule := scheduleLeaseRequests([]LeaseRequest{
+				testLeaseRequest("lease1", now, 0, testExporter3DutB),
+				testLeaseRequest("lease2", now.Add(-time.Minute), 0, testExporter3DutB),
+			}, now)
+
+			Expect(schedule.Assignments).To(HaveLen(1))
+			Expect(schedule.Assignments).To(HaveKey("lease2"))
+		})
+
+		It("should fall back to the lease name when creation times are equal", func() {
+			schedule := scheduleLeaseRequests([]LeaseRequest{
+				testLeaseRequest("lease2", now, 0, testExporter3DutB),
+				testLeaseRequest("lease1", now, 0, testExporter3DutB),
+			}, now)
+
+			Expect(schedule.Assignments).To(HaveLen(1))
+			Expect(schedule.Assignments).To(HaveKey("lease1"))
+		})
+	})
+
+	When("leases can be satisfied by several exporters", func() {
+		It("should assign a different exporter to each lease in a single pass", func() {
+			schedule := scheduleLeaseRequests([]LeaseRequest{
+				testLeaseRequest("lease1", now, 0, testExporter1DutA, testExporter2DutA),
+				testLeaseRequest("lease2", now, 5, testExporter1DutA, testExporter2DutA),
+				testLeaseRequest("lease3", now, 0, testExporter1DutA, testExporter2DutA),
+			}, now)
+
+			Expect(schedule.Assignments).To(HaveLen(2))
+			Expect(schedule.Assignments["lease2"][0].Exporter.Name).To(Equal(testExporter1DutA.Name))
+			Expect(schedule.Assignments["lease1"][0].Exporter.Name).To(Equal(testExporter2DutA.Name))
+			Expect(schedule.Assignments).NotTo(HaveKey("lease3"))
+		})
+	})
+
+	When("leases request non overlapping windows", func() {
+		It("should assign the same exporter to all of them", func() {
+			scheduled := testLeaseRequest("lease2", now, 0, testExporter3DutB)
+			scheduled.Lease.Spec.BeginTime = &metav1.Time{Time: now.Add(time.Hour)}
+			scheduled.Lease.Spec.EndTime = &metav1.Time{Time: now.Add(2 * time.Hour)}
+
+			schedule := scheduleLeaseRequests([]LeaseRequest{
+				testLeaseRequest("lease1", now, 0, testExporter3DutB),
+				scheduled,
+			}, now)
+
+			Expect(schedule.Assignments).To(HaveLen(2))
+		})
+	})
+
+	When("leases are waiting for a busy exporter", func() {
+		It("should estimate when each of them is going to get it", func() {
+			holder := &jumpstarterdevv1alpha1.Lease{
+				ObjectMeta: metav1.ObjectMeta{Name: "holder", Namespace: "default"},
+				Spec: jumpstarterdevv1alpha1.LeaseSpec{
+					Duration: metav1.Duration{Duration: 10 * time.Minute},
+				},
+				Status: jumpstarterdevv1alpha1.LeaseStatus{
+					BeginTime:   &metav1.Time{Time: now},
+					ExporterRef: &corev1.LocalObjectReference{Name: testExporter3DutB.Name},
+				},
+			}
+			lease1 := testLeaseRequest("lease1", now.Add(-time.Minute), 0, testExporter3DutB)
+			lease1.Slots[0].ApprovedExporters[0].ExistingLease = holder
+			lease2 := testLeaseRequest("lease2", now, 0, testExporter3DutB)
+			lease2.Slots[0].ApprovedExporters[0].ExistingLease = holder
+			other := testLeaseRequest("lease3", now.Add(-time.Hour), 0, testExporter1DutA)
+			other.Slots[0].ApprovedExporters[0].ExistingLease = holder
+
+			schedule := scheduleLeaseRequests([]LeaseRequest{lease2, other, lease1}, now)
+
+			Expect(schedule.Assignments).To(BeEmpty())
+			// leases waiting for other exporters do not count in the position
+			Expect(schedule.Position("lease1")).To(Equal(0))
+			Expect(schedule.Position("lease2")).To(Equal(1))
+			Expect(schedule.EstimatedBeginTimes["lease1"]).To(BeTemporally("==", now.Add(10*time.Minute)))
+			Expect(schedule.EstimatedBeginTimes["lease2"]).To(BeTemporally("==", now.Add(11*time.Minute)))
+		})
+	})
+
+	When("a gang lease needs several exporters", func() {
+		It("should only assign distinct exporters once all slots can be filled", func() {
+			gang := testLeaseRequest("lease1", now.Add(-time.Minute), 0, testExporter1DutA, testExporter2DutA)
+			gang.Slots = append(gang.Slots, gang.Slots[0])
+
+			schedule := scheduleLeaseRequests([]LeaseRequest{
+				gang,
+				testLeaseRequest("lease2", now, 0, testExporter2DutA),
+			}, now)
+
+			Expect(schedule.Assignments).To(HaveLen(1))
+			Expect(schedule.Assignments["lease1"]).To(HaveLen(2))
+			Expect(schedule.Assignments["lease1"][0].Exporter.Name).To(Equal(testExporter1DutA.Name))
+			Expect(schedule.Assignments["lease1"][1].Exporter.Name).To(Equal(testExporter2DutA.Name))
+
+			gang.Lease.CreationTimestamp = metav1.NewTime(now)
+			schedule = scheduleLeaseRequests([]LeaseRequest{
+				testLeaseRequest("lease2", now.Add(-time.Minute), 0, testExporter2DutA),
+				gang,
+			}, now)
+
+			// the gang lease does not hold exporter 1 while waiting for exporter 2
+			Expect(schedule.Assignments).To(HaveLen(1))
+			Expect(schedule.Assignments).To(HaveKey("lease2"))
+		})
+
+		It("should move an earlier slot to another exporter to fill a later one", func() {
+			gang := testLeaseRequest("lease1", now, 0, testExporter1DutA, testExporter2DutA)
+			gang.Slots = append(gang.Slots, testLeaseRequest("lease1", now, 0, testExporter1DutA).Slots[0])
+
+			schedule := scheduleLeaseRequests([]LeaseRequest{gang}, now)
+
+			Expect(schedule.Assignments["lease1"]).To(HaveLen(2))
+			Expect(schedule.Assignments["lease1"][0].Exporter.Name).To(Equal(testExporter2DutA.Name))
+			Expect(schedule.Assignments["lease1"][1].Exporter.Name).To(Equal(testExporter1DutA.Name))
+		})
+
+		It("should quickly give up when a member requests one more exporter than there are", func() {
+			var exporters []*jumpstarterdevv1alpha1.Exporter
+			for i := range 11 {
+				exporter := testExporter1DutA.DeepCopy()
+				exporter.Name = fmt.Sprintf("exporter%d", i)
+				// each exporter is approved by two policies
+				exporters = append(exporters, exporter, exporter)
+			}
+			gang := testLeaseRequest("lease1", now, 0, exporters...)
+			for range 11 {
+				gang.Slots = append(gang.Slots, gang.Slots[0])
+			}
+
+			start := time.Now()
+			schedule := scheduleLeaseRequests([]LeaseRequest{gang}, now)
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+			Expect(schedule.Assignments).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Gang leases", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter2DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1", "lease2", "lease3")
+	})
+
+	gangLease := func(name string, members ...jumpstarterdevv1alpha1.LeaseMember) *jumpstarterdevv1alpha1.Lease {
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Name = name
+		lease.Spec.Selector = metav1.LabelSelector{}
+		lease.Spec.Members = members
+		return lease
+	}
+	member := func(name, dut string, count int) jumpstarterdevv1alpha1.LeaseMember {
+		return jumpstarterdevv1alpha1.LeaseMember{
+			Name:     name,
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"dut": dut}},
+			Count:    count,
+		}
+	}
+
+	When("all members can be satisfied", func() {
+		It("should acquire an exporter for every member", func() {
+			ctx := context.Background()
+
+			lease := gangLease("lease1", member("dut", "a", 2), member("host", "b", 1))
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.BeginTime).NotTo(BeNil())
+			Expect(updatedLease.Status.Members).To(HaveLen(3))
+			Expect(updatedLease.GetExporterNames()).To(ConsistOf(
+				testExporter1DutA.Name, testExporter2DutA.Name, testExporter3DutB.Name))
+
+			exporterName, ok := updatedLease.GetMemberExporterName("host")
+			Expect(ok).To(BeTrue())
+			Expect(exporterName).To(Equal(testExporter3DutB.Name))
+
+			for _, name := range updatedLease.GetExporterNames() {
+				updatedExporter := getExporter(ctx, name)
+				Expect(updatedExporter.Status.LeaseRef).NotTo(BeNil())
+				Expect(updatedExporter.Status.LeaseRef.Name).To(Equal(lease.Name))
+			}
+		})
+	})
+
+	When("one of the members is busy", func() {
+		It("should not hold any exporter while waiting", func() {
+			ctx := context.Background()
+
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			lease2 := gangLease("lease2", member("dut", "a", 1), member("host", "b", 1))
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+
+			updatedLease := getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			Expect(updatedLease.Status.Members).To(BeEmpty())
+			Expect(meta.IsStatusConditionTrue(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypePending),
+			)).To(BeTrue())
+			Expect(getExporter(ctx, testExporter1DutA.Name).Status.LeaseRef).To(BeNil())
+		})
+	})
+
+	When("a member requests more exporters than there are", func() {
+		It("should be unsatisfiable", func() {
+			ctx := context.Background()
+
+			lease := gangLease("lease1", member("dut", "a", 3))
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			Expect(meta.FindStatusCondition(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable),
+			).Reason).To(Equal("NoExporter"))
+		})
+
+		It("should be unsatisfiable when fewer exporters are approved than requested", func() {
+			ctx := context.Background()
+
+			exporter := getExporter(ctx, testExporter1DutA.Name)
+			exporter.Labels["rack"] = "1"
+			Expect(k8sClient.Update(ctx, exporter)).To(Succeed())
+
+			// both policies approve the same exporter
+			policy := &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "rack",
+					Namespace: "default",
+				},
+				Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+					ExporterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"rack": "1"}},
+					Policies: []jumpstarterdevv1alpha1.Policy{{
+						Priority: 10,
+						From:     []jumpstarterdevv1alpha1.From{{}},
+					}, {
+						From: []jumpstarterdevv1alpha1.From{{}},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+			})
+
+			lease := gangLease("lease1", member("dut", "a", 2))
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			condition := meta.FindStatusCondition(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable),
+			)
+			Expect(condition.Reason).To(Equal("NoAccess"))
+			Expect(condition.Message).To(ContainSubstring("only 1 of them are approved"))
+		})
+	})
+})
+
+var _ = Describe("Lease scheduling", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter2DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1", "lease2", "lease3")
+	})
+
+	When("a lease is waiting for a busy exporter", func() {
+		It("should report its position in the queue and when it is expected to begin", func() {
+			ctx := context.Background()
+
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+			lease.Spec.Duration.Duration = time.Hour
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			expiration := getLease(ctx, lease.Name).GetExpirationTime()
+			Expect(expiration).NotTo(BeNil())
+
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			lease2.Spec.Selector.MatchLabels["dut"] = "b"
+			lease2.Spec.Duration.Duration = 30 * time.Minute
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+
+			lease3 := leaseDutA2Sec.DeepCopy()
+			lease3.Name = "lease3"
+			lease3.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease3)).To(Succeed())
+			_ = reconcileLease(ctx, lease3)
+
+			updatedLease := getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.QueuePosition).To(Equal(1))
+			Expect(updatedLease.Status.EstimatedBeginTime).NotTo(BeNil())
+			Expect(updatedLease.Status.EstimatedBeginTime.Time).To(BeTemporally("~", *expiration, time.Second))
+
+			updatedLease = getLease(ctx, lease3.Name)
+			Expect(updatedLease.Status.QueuePosition).To(Equal(2))
+			Expect(updatedLease.Status.EstimatedBeginTime).NotTo(BeNil())
+			Expect(updatedLease.Status.EstimatedBeginTime.Time).To(
+				BeTemporally("~", expiration.Add(30*time.Minute), time.Second))
+
+			pb := updatedLease.ToProtobuf()
+			Expect(pb.QueuePosition).NotTo(BeNil())
+			Expect(*pb.QueuePosition).To(Equal(int32(2)))
+			Expect(pb.EstimatedBeginTime).NotTo(BeNil())
+		})
+	})
+
+	When("several leases are waiting for a busy exporter", func() {
+		It("should serve the lease that has been waiting for longer first", func() {
+			ctx := context.Background()
+
+			lease := leaseDutA2Sec.DeepCopy()
+			lease.Spec.Selector.MatchLabels["dut"] = "b"
+			lease.Spec.Duration.Duration = 500 * time.Millisecond
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			lease2.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+
+			lease3 := leaseDutA2Sec.DeepCopy()
+			lease3.Name = "lease3"
+			lease3.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease3)).To(Succeed())
+			_ = reconcileLease(ctx, lease3)
+
+			Expect(getLease(ctx, lease2.Name).Status.ExporterRef).To(BeNil())
+			Expect(getLease(ctx, lease3.Name).Status.ExporterRef).To(BeNil())
+
+			time.Sleep(501 * time.Millisecond)
+			_ = reconcileLease(ctx, lease)
+
+			// the newest lease is reconciled first, but should leave the exporter
+			// to the lease ahead of it in the queue
+			_ = reconcileLease(ctx, lease3)
+			updatedLease := getLease(ctx, lease3.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypePending),
+			)).To(BeTrue())
+
+			_ = reconcileLease(ctx, lease2)
+			updatedLease = getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter3DutB.Name))
+		})
+	})
+})
+
+var _ = Describe("Scheduled leases", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter2DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1", "lease2", "lease3")
+	})
+
+	scheduledLeaseDutB := func(name string, begin time.Time, duration time.Duration) *jumpstarterdevv1alpha1.Lease {
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Name = name
+		lease.Spec.Selector.MatchLabels["dut"] = "b"
+		lease.Spec.Duration.Duration = duration
+		lease.Spec.BeginTime = &metav1.Time{Time: begin}
+		lease.Spec.EndTime = &metav1.Time{Time: begin.Add(duration)}
+		return lease
+	}
+
+	When("booking an exporter for a future window", func() {
+		It("should reserve the exporter and acquire it at the begin time", func() {
+			ctx := context.Background()
+
+			// begin and end times are stored with a precision of seconds
+			lease := scheduledLeaseDutB("lease1", time.Now().Truncate(time.Second).Add(2*time.Second), time.Second)
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			result := reconcileLease(ctx, lease)
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			updatedLease := getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter3DutB.Name))
+			Expect(updatedLease.Status.BeginTime).To(BeNil())
+			Expect(meta.FindStatusCondition(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypePending),
+			).Reason).To(Equal("Scheduled"))
+
+			// the exporter is not leased before the window begins
+			Expect(getExporter(ctx, testExporter3DutB.Name).Status.LeaseRef).To(BeNil())
+
+			time.Sleep(result.RequeueAfter)
+			_ = reconcileLease(ctx, lease)
+
+			updatedLease = getLease(ctx, lease.Name)
+			Expect(updatedLease.Status.BeginTime).NotTo(BeNil())
+			Expect(meta.IsStatusConditionTrue(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeReady),
+			)).To(BeTrue())
+
+			updatedExporter := getExporter(ctx, testExporter3DutB.Name)
+			Expect(updatedExporter.Status.LeaseRef).NotTo(BeNil())
+			Expect(updatedExporter.Status.LeaseRef.Name).To(Equal(lease.Name))
+		})
+
+		It("should refuse conflicting bookings", func() {
+			ctx := context.Background()
+			begin := time.Now().Add(time.Hour)
+
+			lease := scheduledLeaseDutB("lease1", begin, time.Hour)
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			lease2 := scheduledLeaseDutB("lease2", begin.Add(30*time.Minute), time.Hour)
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+
+			updatedLease := getLease(ctx, lease2.Name)
+			Expect(updatedLease.Status.ExporterRef).To(BeNil())
+			Expect(meta.FindStatusCondition(
+				updatedLease.Status.Conditions,
+				string(jumpstarterdevv1alpha1.LeaseConditionTypeUnsatisfiable),
+			).Reason).To(Equal("Conflict"))
+
+			// a booking right after the first one does not conflict
+			lease3 := scheduledLeaseDutB("lease3", begin.Add(time.Hour), time.Hour)
+			Expect(k8sClient.Create(ctx, lease3)).To(Succeed())
+			_ = reconcileLease(ctx, lease3)
+			Expect(getLease(ctx, lease3.Name).Status.ExporterRef).NotTo(BeNil())
+		})
+
+		It("should let other leases use the exporter until the window begins", func() {
+			ctx := context.Background()
+
+			lease := scheduledLeaseDutB("lease1", time.Now().Add(time.Hour), time.Hour)
+			Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+			_ = reconcileLease(ctx, lease)
+			Expect(getLease(ctx, lease.Name).Status.ExporterRef).NotTo(BeNil())
+
+			// this lease would run into the booked window
+			lease2 := leaseDutA2Sec.DeepCopy()
+			lease2.Name = "lease2"
+			lease2.Spec.Selector.MatchLabels["dut"] = "b"
+			lease2.Spec.Duration.Duration = 2 * time.Hour
+			Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+			_ = reconcileLease(ctx, lease2)
+			Expect(getLease(ctx, lease2.Name).Status.ExporterRef).To(BeNil())
+
+			// this one ends before the booked window begins
+			lease3 := leaseDutA2Sec.DeepCopy()
+			lease3.Name = "lease3"
+			lease3.Spec.Selector.MatchLabels["dut"] = "b"
+			Expect(k8sClient.Create(ctx, lease3)).To(Succeed())
+			_ = reconcileLease(ctx, lease3)
+
+			updatedLease := getLease(ctx, lease3.Name)
+			Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+			Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter3DutB.Name))
+		})
+	})
+})
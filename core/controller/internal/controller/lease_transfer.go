@@ -0,0 +1,173 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrLeaseTransferRejected is returned when a lease cannot be handed over to another client
+var ErrLeaseTransferRejected = errors.New("lease transfer rejected")
+
+// TransferLease hands a lease holding, or having reserved, its exporters over to another client
+// of the namespace for the time left on the lease. The time left must be allowed by a policy
+// matching the other client and each of the exporters, within the quotas of the policy. The lease
+// takes the priority and spot access the policies grant the other client, and the exporters learn
+// about their new client on the next update of their Status stream.
+func TransferLease(
+	ctx context.Context,
+	c client.Client,
+	lease *jumpstarterdevv1alpha1.Lease,
+	clientName string,
+) error {
+	if lease.Status.Ended || lease.Spec.Release {
+		return fmt.Errorf("%w: the lease has already ended", ErrLeaseTransferRejected)
+	}
+	if lease.Status.ExporterRef == nil {
+		return fmt.Errorf("%w: the lease has not been assigned its exporters yet", ErrLeaseTransferRejected)
+	}
+	if lease.Spec.ClientRef.Name == clientName {
+		return fmt.Errorf("%w: the lease is already held by client %s", ErrLeaseTransferRejected, clientName)
+	}
+
+	var jclient jumpstarterdevv1alpha1.Client
+	if err := c.Get(ctx, types.NamespacedName{
+		Namespace: lease.Namespace,
+		Name:      clientName,
+	}, &jclient); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w: client %s does not exist", ErrLeaseTransferRejected, clientName)
+		}
+		return fmt.Errorf("TransferLease: failed to get client: %w", err)
+	}
+
+	// the other client is granted the time left on the lease, as if it leased the exporters now
+	now := time.Now()
+	transferred := lease.DeepCopy()
+	transferred.Spec.ClientRef = corev1.LocalObjectReference{Name: clientName}
+	remaining := lease.Spec.Duration.Duration
+	if expiration := lease.GetExpirationTime(); expiration != nil {
+		remaining = expiration.Sub(now)
+		if remaining <= 0 {
+			return fmt.Errorf("%w: the lease has already expired", ErrLeaseTransferRejected)
+		}
+		transferred.Status.BeginTime = &metav1.Time{Time: now}
+		transferred.Spec.Duration = metav1.Duration{Duration: remaining}
+	}
+
+	var selection []ApprovedExporter
+	for _, name := range lease.GetExporterNames() {
+		var exporter jumpstarterdevv1alpha1.Exporter
+		if err := c.Get(ctx, types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      name,
+		}, &exporter); err != nil {
+			return fmt.Errorf("TransferLease: failed to get exporter: %w", err)
+		}
+
+		approvedExporters, rejected, err := attachMatchingPolicies(ctx, c, transferred,
+			[]jumpstarterdevv1alpha1.Exporter{exporter})
+		if err != nil {
+			return fmt.Errorf("TransferLease: failed to handle policy approval: %w", err)
+		}
+		if len(approvedExporters) == 0 && len(rejected.Duration) > 0 {
+			return fmt.Errorf("%w: the time left on the lease %s exceeds the maximum duration of %s allowed by the policies",
+				ErrLeaseTransferRejected, remaining.Truncate(time.Second), maximumPolicyDuration(rejected.Duration))
+		}
+		if len(approvedExporters) == 0 && len(rejected.TimeWindow) > 0 {
+			return fmt.Errorf("%w: the lease would cross into a time window the policies forbid for client %s",
+				ErrLeaseTransferRejected, clientName)
+		}
+		if len(approvedExporters) == 0 {
+			return fmt.Errorf("%w: no policy allows client %s to lease exporter %s",
+				ErrLeaseTransferRejected, clientName, exporter.Name)
+		}
+		approvedExporters, _, quotaMessage, err := filterOutQuotaExceeded(ctx, c, transferred, approvedExporters, remaining)
+		if err != nil {
+			return fmt.Errorf("TransferLease: failed to check policy quotas: %w", err)
+		}
+		if len(approvedExporters) == 0 {
+			return fmt.Errorf("%w: %s", ErrLeaseTransferRejected, quotaMessage)
+		}
+		selection = append(selection, orderApprovedExporters(approvedExporters)[0])
+	}
+
+	// the lease is only handed over as it was approved, not after it changed in the meantime
+	previous := lease.Spec.ClientRef
+	original := client.MergeFromWithOptions(lease.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	lease.Spec.ClientRef = corev1.LocalObjectReference{Name: clientName}
+	if err := c.Patch(ctx, lease, original); err != nil {
+		return fmt.Errorf("TransferLease: failed to patch lease: %w", err)
+	}
+
+	// the status is patched again when the lease controller updated it in the meantime
+	attempt := 0
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if attempt++; attempt > 1 {
+			if err := c.Get(ctx, client.ObjectKeyFromObject(lease), lease); err != nil {
+				return err
+			}
+			if lease.Spec.ClientRef.Name != clientName || lease.Status.Ended {
+				return fmt.Errorf("%w: the lease changed while being transferred", ErrLeaseTransferRejected)
+			}
+		}
+		original := client.MergeFromWithOptions(lease.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		setTransferredLeaseStatus(lease, selection, now)
+		return c.Status().Patch(ctx, lease, original)
+	})
+	if err == nil {
+		return nil
+	}
+
+	// the lease is handed back rather than left to the other client with the priority and
+	// spot access of the previous one
+	if lease.Spec.ClientRef.Name == clientName {
+		original := client.MergeFromWithOptions(lease.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		lease.Spec.ClientRef = previous
+		if revertErr := c.Patch(ctx, lease, original); revertErr != nil {
+			return fmt.Errorf("TransferLease: failed to patch lease status: %w, and to revert the transfer: %w",
+				err, revertErr)
+		}
+	}
+	return fmt.Errorf("TransferLease: failed to patch lease status: %w", err)
+}
+
+// setTransferredLeaseStatus sets the priority and spot access the policies grant the client the
+// lease was transferred to
+func setTransferredLeaseStatus(lease *jumpstarterdevv1alpha1.Lease, selection []ApprovedExporter, now time.Time) {
+	lease.Status.Priority = selection[0].Policy.Priority
+	lease.Status.SpotAccess = false
+	for _, selected := range selection {
+		lease.Status.Priority = min(lease.Status.Priority, selected.Policy.Priority)
+		lease.Status.SpotAccess = lease.Status.SpotAccess || selected.Policy.SpotAccess
+	}
+	// the other client is given the whole idle timeout to connect to the exporters
+	if lease.Status.BeginTime != nil {
+		lease.Status.LastActivityTime = &metav1.Time{Time: now}
+	}
+}
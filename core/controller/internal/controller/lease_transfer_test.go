@@ -0,0 +1,181 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("TransferLease", func() {
+	var ciClient, developerClient *jumpstarterdevv1alpha1.Client
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		createExporters(ctx, testExporter1DutA)
+		setExporterOnlineConditions(ctx, testExporter1DutA.Name, metav1.ConditionTrue)
+
+		ciClient = &jumpstarterdevv1alpha1.Client{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ci",
+				Namespace: "default",
+				Labels:    map[string]string{"team": "ci"},
+			},
+		}
+		developerClient = &jumpstarterdevv1alpha1.Client{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "developer",
+				Namespace: "default",
+				Labels:    map[string]string{"team": "dev"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ciClient)).To(Succeed())
+		Expect(k8sClient.Create(ctx, developerClient)).To(Succeed())
+
+		policy := &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "teams",
+				Namespace: "default",
+			},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				Policies: []jumpstarterdevv1alpha1.Policy{{
+					Priority: 10,
+					From: []jumpstarterdevv1alpha1.From{{
+						ClientSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "ci"}},
+					}},
+				}, {
+					Priority: 5,
+					From: []jumpstarterdevv1alpha1.From{{
+						ClientSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "dev"}},
+					}},
+					MaximumDuration: &metav1.Duration{Duration: 10 * time.Minute},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(context.Background(), policy)).To(Succeed())
+		})
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA)
+		deleteLeases(ctx, "lease1")
+		Expect(k8sClient.Delete(ctx, ciClient)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, developerClient)).To(Succeed())
+	})
+
+	// acquireLease acquires the exporter for the ci client for the given duration
+	acquireLease := func(ctx context.Context, duration time.Duration) *jumpstarterdevv1alpha1.Lease {
+		lease := leaseDutA2Sec.DeepCopy()
+		lease.Spec.ClientRef.Name = ciClient.Name
+		lease.Spec.Duration = metav1.Duration{Duration: duration}
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+		updatedLease := getLease(ctx, lease.Name)
+		Expect(updatedLease.Status.BeginTime).NotTo(BeNil())
+		Expect(updatedLease.Status.Priority).To(Equal(10))
+		return updatedLease
+	}
+
+	It("should hand the lease over to a client the policies allow the time left", func() {
+		ctx := context.Background()
+		lease := acquireLease(ctx, 5*time.Minute)
+
+		Expect(TransferLease(ctx, k8sClient, lease, developerClient.Name)).To(Succeed())
+
+		_ = reconcileLease(ctx, lease)
+		updatedLease := getLease(ctx, lease.Name)
+		Expect(updatedLease.Spec.ClientRef.Name).To(Equal(developerClient.Name))
+		Expect(updatedLease.Status.Ended).To(BeFalse())
+		Expect(updatedLease.Status.ExporterRef.Name).To(Equal(testExporter1DutA.Name))
+		Expect(updatedLease.Status.Priority).To(Equal(5))
+		Expect(updatedLease.Status.LastActivityTime).NotTo(BeNil())
+		Expect(getExporter(ctx, testExporter1DutA.Name).Status.LeaseRef.Name).To(Equal(lease.Name))
+	})
+
+	It("should not hand over a lease that changed since it was read", func() {
+		ctx := context.Background()
+		lease := acquireLease(ctx, 5*time.Minute)
+
+		updatedLease := getLease(ctx, lease.Name)
+		updatedLease.Labels = map[string]string{"changed": "true"}
+		Expect(k8sClient.Update(ctx, updatedLease)).To(Succeed())
+
+		err := TransferLease(ctx, k8sClient, lease, developerClient.Name)
+		Expect(apierrors.IsConflict(err)).To(BeTrue())
+		Expect(getLease(ctx, lease.Name).Spec.ClientRef.Name).To(Equal(ciClient.Name))
+	})
+
+	It("should hand the lease back when its status cannot be patched", func() {
+		ctx := context.Background()
+		lease := acquireLease(ctx, 5*time.Minute)
+
+		err := TransferLease(ctx, &failingStatusClient{Client: k8sClient, name: lease.Name}, lease, developerClient.Name)
+		Expect(err).To(MatchError(ContainSubstring("injected status patch failure")))
+
+		updatedLease := getLease(ctx, lease.Name)
+		Expect(updatedLease.Spec.ClientRef.Name).To(Equal(ciClient.Name))
+		Expect(updatedLease.Status.Priority).To(Equal(10))
+	})
+
+	It("should be rejected when the time left exceeds the maximum duration of the other client", func() {
+		ctx := context.Background()
+		lease := acquireLease(ctx, time.Hour)
+
+		err := TransferLease(ctx, k8sClient, lease, developerClient.Name)
+		Expect(err).To(MatchError(ErrLeaseTransferRejected))
+		Expect(err.Error()).To(ContainSubstring("maximum duration of 10m0s"))
+		Expect(getLease(ctx, lease.Name).Spec.ClientRef.Name).To(Equal(ciClient.Name))
+	})
+
+	It("should be rejected when no policy matches the other client", func() {
+		ctx := context.Background()
+		lease := acquireLease(ctx, 5*time.Minute)
+
+		err := TransferLease(ctx, k8sClient, lease, testClient.Name)
+		Expect(err).To(MatchError(ErrLeaseTransferRejected))
+		Expect(err.Error()).To(ContainSubstring("no policy allows client"))
+	})
+
+	It("should be rejected when the other client does not exist", func() {
+		ctx := context.Background()
+		lease := acquireLease(ctx, 5*time.Minute)
+
+		err := TransferLease(ctx, k8sClient, lease, "nobody")
+		Expect(err).To(MatchError(ErrLeaseTransferRejected))
+		Expect(err.Error()).To(ContainSubstring("does not exist"))
+	})
+
+	It("should be rejected once the lease has ended", func() {
+		ctx := context.Background()
+		lease := acquireLease(ctx, 5*time.Minute)
+		lease.Spec.Release = true
+		Expect(k8sClient.Update(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+
+		err := TransferLease(ctx, k8sClient, getLease(ctx, lease.Name), developerClient.Name)
+		Expect(err).To(MatchError(ErrLeaseTransferRejected))
+		Expect(err.Error()).To(ContainSubstring("already ended"))
+	})
+})
@@ -0,0 +1,210 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// pendingLeaseResyncInterval is how often pending leases are reconciled when nothing happens,
+// they are otherwise reconciled as the exporters, policies and leases they depend on change
+const pendingLeaseResyncInterval = time.Minute
+
+// pendingLeasesFor returns the pending leases of the namespace with a slot that one of the
+// exporters can fill, or all of them when exporters is nil, along with the leases waiting for
+// quota when quotaReleased is set
+func (r *LeaseReconciler) pendingLeasesFor(
+	ctx context.Context,
+	namespace string,
+	exporters []jumpstarterdevv1alpha1.Exporter,
+	skip string,
+	quotaReleased bool,
+) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	leases, err := r.ListActiveLeases(ctx, namespace)
+	if err != nil {
+		logger.Error(err, "pendingLeasesFor: failed to list active leases", "namespace", namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, lease := range leases.Items {
+		if lease.Name == skip || !isLeasePending(&lease) {
+			continue
+		}
+		waitingForQuota := quotaReleased && meta.IsStatusConditionTrue(lease.Status.Conditions,
+			string(jumpstarterdevv1alpha1.LeaseConditionTypeQuotaExceeded))
+		if exporters != nil && !waitingForQuota && !slices.ContainsFunc(exporters,
+			func(exporter jumpstarterdevv1alpha1.Exporter) bool {
+				return leaseWantsExporter(&lease, &exporter)
+			}) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      lease.Name,
+		}})
+	}
+	return requests
+}
+
+// leaseWantsExporter returns true if the exporter matches the selectors of one of the slots
+// of the lease, malformed selectors are left for the reconciliation of the lease to report
+func leaseWantsExporter(lease *jumpstarterdevv1alpha1.Lease, exporter *jumpstarterdevv1alpha1.Exporter) bool {
+	for _, slot := range leaseSlotSelectors(lease) {
+		selector, err := metav1.LabelSelectorAsSelector(&slot.Selector)
+		if err != nil {
+			return true
+		}
+		if !selector.Matches(labels.Set(exporter.Labels)) {
+			continue
+		}
+		var deviceSelectors []labels.Selector
+		for _, deviceSelector := range slot.DeviceSelectors {
+			selector, err := metav1.LabelSelectorAsSelector(&deviceSelector)
+			if err != nil {
+				return true
+			}
+			deviceSelectors = append(deviceSelectors, selector)
+		}
+		if exporter.HasDevicesMatching(deviceSelectors) {
+			return true
+		}
+	}
+	return false
+}
+
+// leasesForExporter enqueues the lease held by the exporter, see leaseForExporter, the leases
+// waiting for the exporter to be done cleaning up, along with the pending leases the exporter
+// could be assigned to
+func (r *LeaseReconciler) leasesForExporter(ctx context.Context, obj client.Object) []reconcile.Request {
+	exporter, ok := obj.(*jumpstarterdevv1alpha1.Exporter)
+	if !ok {
+		return nil
+	}
+
+	r.approvals.invalidate(exporter.Namespace)
+
+	requests := r.leaseForExporter(ctx, obj)
+	if !exporter.IsCleaning() {
+		requests = append(requests, r.leasesWaitingForCleanup(ctx, exporter)...)
+	}
+	return append(requests, r.pendingLeasesFor(ctx, exporter.Namespace,
+		[]jumpstarterdevv1alpha1.Exporter{*exporter}, "", false)...)
+}
+
+// pendingLeasesForLease enqueues the pending leases that could be assigned the exporters
+// held by the lease, as they get released, or as the lease ahead of them in the queue
+// gets assigned exporters, along with the leases waiting for the quota the lease frees
+func (r *LeaseReconciler) pendingLeasesForLease(ctx context.Context, obj client.Object) []reconcile.Request {
+	lease, ok := obj.(*jumpstarterdevv1alpha1.Lease)
+	if !ok || isLeasePending(lease) {
+		return nil
+	}
+
+	// the lease counts towards the quotas the other leases are approved within
+	r.approvals.invalidate(lease.Namespace)
+
+	var exporters []jumpstarterdevv1alpha1.Exporter
+	for _, name := range lease.GetExporterNames() {
+		var exporter jumpstarterdevv1alpha1.Exporter
+		if err := r.Get(ctx, types.NamespacedName{
+			Namespace: lease.Namespace,
+			Name:      name,
+		}, &exporter); err != nil {
+			continue
+		}
+		exporters = append(exporters, exporter)
+	}
+	if len(exporters) == 0 {
+		return nil
+	}
+	// the lease ending, going away or being shortened may free the quota other leases wait for
+	return r.pendingLeasesFor(ctx, lease.Namespace, exporters, lease.Name, true)
+}
+
+// pendingLeasesForPolicy enqueues all of the pending leases of the namespace of the policy,
+// or of the client group policies reference, as any of them may be approved, or rejected, by
+// the policy
+func (r *LeaseReconciler) pendingLeasesForPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	r.approvals.invalidate(obj.GetNamespace())
+	return r.pendingLeasesFor(ctx, obj.GetNamespace(), nil, "", false)
+}
+
+// exporterAvailabilityChanged filters out the exporter updates that cannot change which
+// leases the exporter can be assigned to, e.g. heartbeats
+var exporterAvailabilityChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldExporter, ok := e.ObjectOld.(*jumpstarterdevv1alpha1.Exporter)
+		if !ok {
+			return true
+		}
+		newExporter, ok := e.ObjectNew.(*jumpstarterdevv1alpha1.Exporter)
+		if !ok {
+			return true
+		}
+		for _, conditionType := range []jumpstarterdevv1alpha1.ExporterConditionType{
+			jumpstarterdevv1alpha1.ExporterConditionTypeRegistered,
+			jumpstarterdevv1alpha1.ExporterConditionTypeOnline,
+		} {
+			if meta.IsStatusConditionTrue(oldExporter.Status.Conditions, string(conditionType)) !=
+				meta.IsStatusConditionTrue(newExporter.Status.Conditions, string(conditionType)) {
+				return true
+			}
+		}
+		return !maps.Equal(oldExporter.Labels, newExporter.Labels) ||
+			!apiequality.Semantic.DeepEqual(oldExporter.Spec.Maintenance, newExporter.Spec.Maintenance) ||
+			!apiequality.Semantic.DeepEqual(oldExporter.Status.LeaseRef, newExporter.Status.LeaseRef) ||
+			oldExporter.IsCleaning() != newExporter.IsCleaning() ||
+			!apiequality.Semantic.DeepEqual(oldExporter.Status.Devices, newExporter.Status.Devices)
+	},
+}
+
+// leaseReleaseChanged only lets through the lease updates that change the exporters the lease
+// holds, or for how long it holds them
+var leaseReleaseChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldLease, ok := e.ObjectOld.(*jumpstarterdevv1alpha1.Lease)
+		if !ok {
+			return true
+		}
+		newLease, ok := e.ObjectNew.(*jumpstarterdevv1alpha1.Lease)
+		if !ok {
+			return true
+		}
+		return oldLease.Status.Ended != newLease.Status.Ended ||
+			!slices.Equal(oldLease.GetExporterNames(), newLease.GetExporterNames()) ||
+			oldLease.Spec.Duration != newLease.Spec.Duration ||
+			!apiequality.Semantic.DeepEqual(oldLease.Spec.EndTime, newLease.Spec.EndTime)
+	},
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Lease watches", func() {
+	reconciler := &LeaseReconciler{}
+
+	BeforeEach(func() {
+		reconciler.Client = k8sClient
+		createExporters(context.Background(), testExporter1DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1", "lease2")
+	})
+
+	lease2Request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "lease2"}}
+
+	// queueLeases acquires the only exporter of dut a with lease1, and queues lease2 for it
+	queueLeases := func(ctx context.Context) (*jumpstarterdevv1alpha1.Lease, *jumpstarterdevv1alpha1.Lease) {
+		lease1 := leaseDutA2Sec.DeepCopy()
+		lease1.Spec.Duration = metav1.Duration{Duration: time.Hour}
+		Expect(k8sClient.Create(ctx, lease1)).To(Succeed())
+		_ = reconcileLease(ctx, lease1)
+		Expect(getLease(ctx, lease1.Name).Status.ExporterRef).NotTo(BeNil())
+
+		lease2 := leaseDutA2Sec.DeepCopy()
+		lease2.Name = "lease2"
+		Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+		result := reconcileLease(ctx, lease2)
+		Expect(getLease(ctx, lease2.Name).Status.ExporterRef).To(BeNil())
+		Expect(result.RequeueAfter).To(Equal(pendingLeaseResyncInterval))
+		return lease1, lease2
+	}
+
+	It("should enqueue the pending leases an exporter matches", func() {
+		ctx := context.Background()
+		queueLeases(ctx)
+
+		Expect(reconciler.leasesForExporter(ctx, getExporter(ctx, testExporter1DutA.Name))).
+			To(ContainElement(lease2Request))
+		Expect(reconciler.leasesForExporter(ctx, getExporter(ctx, testExporter3DutB.Name))).
+			NotTo(ContainElement(lease2Request))
+	})
+
+	It("should enqueue the pending leases waiting for the exporters of an ended lease", func() {
+		ctx := context.Background()
+		lease1, _ := queueLeases(ctx)
+
+		ended := getLease(ctx, lease1.Name)
+		ended.Status.Ended = true
+		Expect(reconciler.pendingLeasesForLease(ctx, ended)).To(ConsistOf(lease2Request))
+		Expect(reconciler.pendingLeasesForLease(ctx, getLease(ctx, "lease2"))).To(BeEmpty())
+	})
+
+	It("should enqueue the leases waiting for quota as a lease ends", func() {
+		ctx := context.Background()
+		lease1, _ := queueLeases(ctx)
+
+		// a lease waiting for quota to lease an exporter lease1 does not hold
+		lease3 := leaseDutA2Sec.DeepCopy()
+		lease3.Name = "lease3"
+		lease3.Spec.Selector.MatchLabels = map[string]string{"dut": "b"}
+		Expect(k8sClient.Create(ctx, lease3)).To(Succeed())
+		DeferCleanup(func() { deleteLeases(context.Background(), lease3.Name) })
+		lease3.SetStatusQuotaExceeded(true, "QuotaExceeded", "The lease cannot be granted")
+		Expect(k8sClient.Status().Update(ctx, lease3)).To(Succeed())
+
+		ended := getLease(ctx, lease1.Name)
+		ended.Status.Ended = true
+		Expect(reconciler.pendingLeasesForLease(ctx, ended)).To(ConsistOf(lease2Request,
+			reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: lease3.Name}}))
+		Expect(reconciler.leasesForExporter(ctx, getExporter(ctx, testExporter1DutA.Name))).
+			NotTo(ContainElement(HaveField("Name", lease3.Name)))
+	})
+
+	It("should reuse the approval of a pending lease until the namespace changes", func() {
+		ctx := context.Background()
+		queueLeases(ctx)
+		lease2 := getLease(ctx, "lease2")
+
+		approvedSlots := func() []LeaseSlot {
+			activeLeases, err := reconciler.ListActiveLeases(ctx, "default")
+			Expect(err).NotTo(HaveOccurred())
+			slots, err := reconciler.approvedSlotsForLease(ctx, lease2, activeLeases.Items)
+			Expect(err).NotTo(HaveOccurred())
+			return slots
+		}
+		Expect(approvedSlots()).To(HaveLen(1))
+
+		setExporterOnlineConditions(ctx, testExporter1DutA.Name, metav1.ConditionFalse)
+		Expect(approvedSlots()).To(HaveLen(1))
+
+		reconciler.leasesForExporter(ctx, getExporter(ctx, testExporter1DutA.Name))
+		Expect(approvedSlots()).To(BeNil())
+	})
+
+	It("should enqueue all pending leases on policy changes", func() {
+		ctx := context.Background()
+		queueLeases(ctx)
+
+		Expect(reconciler.pendingLeasesForPolicy(ctx, spotAccessPolicy)).To(ConsistOf(lease2Request))
+	})
+
+	It("should only let through the exporter updates changing its availability", func() {
+		ctx := context.Background()
+		exporter := getExporter(ctx, testExporter1DutA.Name)
+
+		heartbeat := exporter.DeepCopy()
+		heartbeat.Status.LastSeen = metav1.Now()
+		Expect(exporterAvailabilityChanged.Update(event.UpdateEvent{
+			ObjectOld: exporter, ObjectNew: heartbeat,
+		})).To(BeFalse())
+
+		offline := exporter.DeepCopy()
+		meta.SetStatusCondition(&offline.Status.Conditions, metav1.Condition{
+			Type:   string(jumpstarterdevv1alpha1.ExporterConditionTypeOnline),
+			Status: metav1.ConditionFalse,
+			Reason: "Offline",
+		})
+		Expect(exporterAvailabilityChanged.Update(event.UpdateEvent{
+			ObjectOld: exporter, ObjectNew: offline,
+		})).To(BeTrue())
+	})
+})
@@ -0,0 +1,257 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+// policyMatches are the exporters and clients matched by an access policy
+type policyMatches struct {
+	// Exporters are the exporters matched by the exporter selector
+	Exporters []string
+	// Clients are the clients matched by each of the policies
+	Clients [][]string
+	// Priorities are the priorities of each of the policies, with the defaults of client groups
+	Priorities []int
+	// Unmatched are the selectors and subjects matching nothing
+	Unmatched []string
+	// MissingGroups are the subjects referencing client groups that do not exist
+	MissingGroups []string
+}
+
+// matchPolicy returns the exporters and clients matched by the access policy
+func matchPolicy(
+	policy *jumpstarterdevv1alpha1.ExporterAccessPolicy,
+	exporters []jumpstarterdevv1alpha1.Exporter,
+	clients []jumpstarterdevv1alpha1.Client,
+	groups map[string]*jumpstarterdevv1alpha1.ClientGroup,
+) (*policyMatches, error) {
+	var matches policyMatches
+
+	exporterSelector, err := metav1.LabelSelectorAsSelector(&policy.Spec.ExporterSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exporter selector: %w", err)
+	}
+	for _, exporter := range exporters {
+		if exporterSelector.Matches(labels.Set(exporter.Labels)) {
+			matches.Exporters = append(matches.Exporters, exporter.Name)
+		}
+	}
+	slices.Sort(matches.Exporters)
+	if len(matches.Exporters) == 0 {
+		matches.Unmatched = append(matches.Unmatched, "exporterSelector")
+	}
+
+	for i, p := range policy.Spec.Policies {
+		var matched []string
+		for j, from := range p.From {
+			path := fmt.Sprintf("policies[%d].from[%d]", i, j)
+			group := groups[from.ClientGroupName()]
+			if from.ClientGroupRef != nil && group == nil {
+				matches.MissingGroups = append(matches.MissingGroups,
+					fmt.Sprintf("client group %s referenced by %s does not exist", from.ClientGroupRef.Name, path))
+			}
+
+			subjectMatched := false
+			for _, jclient := range clients {
+				ok, err := from.Matches(&jclient, group)
+				if err != nil {
+					return nil, fmt.Errorf("invalid client selector of %s: %w", path, err)
+				}
+				if ok {
+					subjectMatched = true
+					if !slices.Contains(matched, jclient.Name) {
+						matched = append(matched, jclient.Name)
+					}
+				}
+			}
+			if !subjectMatched {
+				matches.Unmatched = append(matches.Unmatched, path)
+			}
+		}
+		slices.Sort(matched)
+		matches.Clients = append(matches.Clients, matched)
+		matches.Priorities = append(matches.Priorities, policyWithGroupDefaults(p, groups).Priority)
+	}
+	return &matches, nil
+}
+
+// policyConflicts returns the conflicts between the policies of the access policy and the ones of
+// another access policy, where both match the same exporters and clients at different priorities.
+// Given the access policy itself, it returns the conflicts between its own policies, once per pair.
+func policyConflicts(
+	matches *policyMatches,
+	other *jumpstarterdevv1alpha1.ExporterAccessPolicy,
+	otherMatches *policyMatches,
+) []jumpstarterdevv1alpha1.PolicyConflict {
+	exporters := intersectSorted(matches.Exporters, otherMatches.Exporters)
+	if len(exporters) == 0 {
+		return nil
+	}
+
+	var conflicts []jumpstarterdevv1alpha1.PolicyConflict
+	for i := range matches.Clients {
+		for j := range otherMatches.Clients {
+			if otherMatches == matches && j <= i {
+				continue
+			}
+			if matches.Priorities[i] == otherMatches.Priorities[j] {
+				continue
+			}
+			clients := intersectSorted(matches.Clients[i], otherMatches.Clients[j])
+			if len(clients) == 0 {
+				continue
+			}
+			conflicts = append(conflicts, jumpstarterdevv1alpha1.PolicyConflict{
+				Policy:              i,
+				Priority:            matches.Priorities[i],
+				ConflictingPolicy:   other.Name,
+				ConflictingIndex:    j,
+				ConflictingPriority: otherMatches.Priorities[j],
+				Exporters:           exporters,
+				Clients:             clients,
+			})
+		}
+	}
+	return conflicts
+}
+
+// intersectSorted returns the values present in both sorted slices
+func intersectSorted(a, b []string) []string {
+	var both []string
+	for _, value := range a {
+		if _, found := slices.BinarySearch(b, value); found {
+			both = append(both, value)
+		}
+	}
+	return both
+}
+
+// reconcileStatusMatches reports the exporters and clients matched by the policies, their conflicts
+// with other access policies of the namespace, and the selectors matching nothing
+func (r *ExporterAccessPolicyReconciler) reconcileStatusMatches(
+	ctx context.Context,
+	policy *jumpstarterdevv1alpha1.ExporterAccessPolicy,
+) error {
+	policy.Status.Exporters = nil
+	policy.Status.Matches = nil
+	policy.Status.Conflicts = nil
+	policy.Status.UnmatchedSelectors = nil
+
+	var exporters jumpstarterdevv1alpha1.ExporterList
+	if err := r.List(ctx, &exporters, client.InNamespace(policy.Namespace)); err != nil {
+		return fmt.Errorf("reconcileStatusMatches: failed to list exporters: %w", err)
+	}
+
+	var clients jumpstarterdevv1alpha1.ClientList
+	if err := r.List(ctx, &clients, client.InNamespace(policy.Namespace)); err != nil {
+		return fmt.Errorf("reconcileStatusMatches: failed to list clients: %w", err)
+	}
+
+	groups, err := listClientGroups(ctx, r.Client, policy.Namespace)
+	if err != nil {
+		return fmt.Errorf("reconcileStatusMatches: %w", err)
+	}
+
+	var policies jumpstarterdevv1alpha1.ExporterAccessPolicyList
+	if err := r.List(ctx, &policies, client.InNamespace(policy.Namespace)); err != nil {
+		return fmt.Errorf("reconcileStatusMatches: failed to list exporter access policies: %w", err)
+	}
+
+	matches, err := matchPolicy(policy, exporters.Items, clients.Items, groups)
+	if err != nil {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:               string(jumpstarterdevv1alpha1.ExporterAccessPolicyConditionTypeReady),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: policy.Generation,
+			Reason:             "InvalidSelector",
+			Message:            err.Error(),
+		})
+		return nil
+	}
+
+	policy.Status.Exporters = matches.Exporters
+	for i, matched := range matches.Clients {
+		policy.Status.Matches = append(policy.Status.Matches, jumpstarterdevv1alpha1.PolicyMatches{
+			Policy:  i,
+			Clients: matched,
+		})
+	}
+	policy.Status.UnmatchedSelectors = matches.Unmatched
+
+	// the policies of the access policy may also match the same clients at different priorities
+	policy.Status.Conflicts = policyConflicts(matches, policy, matches)
+	internal := len(policy.Status.Conflicts)
+
+	conflicting := 0
+	for _, other := range policies.Items {
+		if other.Name == policy.Name {
+			continue
+		}
+		otherMatches, err := matchPolicy(&other, exporters.Items, clients.Items, groups)
+		if err != nil {
+			// reported by the other access policy
+			continue
+		}
+		conflicts := policyConflicts(matches, &other, otherMatches)
+		if len(conflicts) > 0 {
+			conflicting++
+		}
+		policy.Status.Conflicts = append(policy.Status.Conflicts, conflicts...)
+	}
+
+	if len(matches.MissingGroups) > 0 {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:               string(jumpstarterdevv1alpha1.ExporterAccessPolicyConditionTypeReady),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: policy.Generation,
+			Reason:             "ClientGroupNotFound",
+			Message:            strings.Join(matches.MissingGroups, "; "),
+		})
+		return nil
+	}
+
+	message := fmt.Sprintf("Matches %d exporters", len(matches.Exporters))
+	if len(matches.Unmatched) > 0 {
+		message += fmt.Sprintf(", %d selectors match nothing", len(matches.Unmatched))
+	}
+	if internal > 0 {
+		message += fmt.Sprintf(", %d pairs of its policies have conflicting priorities", internal)
+	}
+	if conflicting > 0 {
+		message += fmt.Sprintf(", conflicts with the priorities of %d other access policies", conflicting)
+	}
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:               string(jumpstarterdevv1alpha1.ExporterAccessPolicyConditionTypeReady),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: policy.Generation,
+		Reason:             "Valid",
+		Message:            message,
+	})
+	return nil
+}
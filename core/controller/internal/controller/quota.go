@@ -0,0 +1,232 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// quotaLedger accounts the usage of the quotas of the policies of a namespace
+type quotaLedger struct {
+	exporters map[string]labels.Set
+	clients   map[string]*jumpstarterdevv1alpha1.Client
+	groups    map[string]*jumpstarterdevv1alpha1.ClientGroup
+	leases    []jumpstarterdevv1alpha1.Lease
+}
+
+// newQuotaLedger collects the exporters, clients and leases of the namespace, ended leases
+// included, as they count towards the leased time until they are deleted
+func newQuotaLedger(ctx context.Context, c client.Reader, namespace string) (*quotaLedger, error) {
+	var exporters jumpstarterdevv1alpha1.ExporterList
+	if err := c.List(ctx, &exporters, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("newQuotaLedger: failed to list exporters: %w", err)
+	}
+
+	var clients jumpstarterdevv1alpha1.ClientList
+	if err := c.List(ctx, &clients, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("newQuotaLedger: failed to list clients: %w", err)
+	}
+
+	var leases jumpstarterdevv1alpha1.LeaseList
+	if err := c.List(ctx, &leases, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("newQuotaLedger: failed to list leases: %w", err)
+	}
+
+	groups, err := listClientGroups(ctx, c, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("newQuotaLedger: %w", err)
+	}
+
+	ledger := &quotaLedger{
+		exporters: make(map[string]labels.Set),
+		clients:   make(map[string]*jumpstarterdevv1alpha1.Client),
+		groups:    groups,
+		leases:    leases.Items,
+	}
+	for _, exporter := range exporters.Items {
+		ledger.exporters[exporter.Name] = labels.Set(exporter.Labels)
+	}
+	for i := range clients.Items {
+		ledger.clients[clients.Items[i].Name] = &clients.Items[i]
+	}
+	return ledger, nil
+}
+
+// quotaUsage is the usage of a quota by the clients it is accounted for
+type quotaUsage struct {
+	// Leases is the number of leases currently held
+	Leases int
+	// LeasedTime is the time leases have been held for within the rolling period of the quota
+	LeasedTime time.Duration
+}
+
+// usage returns the usage of the quota of the policy by the given client, or by all the
+// clients matched by the policy for quotas with the Group scope, only accounting leases
+// holding exporters of the access policy, and ignoring the lease with the given name
+func (l *quotaLedger) usage(
+	accessPolicy *jumpstarterdevv1alpha1.ExporterAccessPolicy,
+	policy *jumpstarterdevv1alpha1.Policy,
+	clientName string,
+	ignore string,
+	now time.Time,
+) (quotaUsage, error) {
+	var usage quotaUsage
+
+	exporterSelector, err := metav1.LabelSelectorAsSelector(&accessPolicy.Spec.ExporterSelector)
+	if err != nil {
+		return usage, fmt.Errorf("usage: failed to convert exporter selector: %w", err)
+	}
+
+	periodBegin := now.Add(-policy.Quota.GetPeriod())
+	for _, lease := range l.leases {
+		if lease.Name == ignore || lease.Status.BeginTime == nil {
+			continue
+		}
+
+		accounted, err := l.accountedFor(policy, clientName, lease.GetClientName())
+		if err != nil {
+			return usage, err
+		}
+		if !accounted {
+			continue
+		}
+
+		if !slices.ContainsFunc(lease.GetExporterNames(), func(name string) bool {
+			return exporterSelector.Matches(l.exporters[name])
+		}) {
+			continue
+		}
+
+		if !lease.Status.Ended {
+			usage.Leases++
+		}
+
+		begin := laterOf(lease.Status.BeginTime.Time, periodBegin)
+		end := now
+		if lease.Status.EndTime != nil {
+			end = lease.Status.EndTime.Time
+		}
+		if end.After(begin) {
+			usage.LeasedTime += end.Sub(begin)
+		}
+	}
+	return usage, nil
+}
+
+// accountedFor returns true if the leases of the other client count towards
+// the quota of the policy accounted for the given client
+func (l *quotaLedger) accountedFor(policy *jumpstarterdevv1alpha1.Policy, clientName, other string) (bool, error) {
+	if policy.Quota.GetScope() == jumpstarterdevv1alpha1.QuotaScopeClient {
+		return other == clientName, nil
+	}
+	return l.matchesPolicy(policy, other)
+}
+
+// matchesPolicy returns true if the client is matched by the policy
+func (l *quotaLedger) matchesPolicy(policy *jumpstarterdevv1alpha1.Policy, clientName string) (bool, error) {
+	jclient, ok := l.clients[clientName]
+	if !ok {
+		return false, nil
+	}
+	for _, from := range policy.From {
+		matches, err := from.Matches(jclient, l.groups[from.ClientGroupName()])
+		if err != nil {
+			return false, fmt.Errorf("matchesPolicy: failed to convert client selector: %w", err)
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// exceeded returns a message explaining why granting the lease for the given duration would
+// exceed the quota of the policy approving the exporter, or an empty string if it would not
+func (l *quotaLedger) exceeded(
+	approved ApprovedExporter,
+	lease *jumpstarterdevv1alpha1.Lease,
+	duration time.Duration,
+	now time.Time,
+) (string, error) {
+	quota := approved.Policy.Quota
+	if quota == nil || approved.AccessPolicy == nil {
+		return "", nil
+	}
+
+	usage, err := l.usage(approved.AccessPolicy, &approved.Policy, lease.GetClientName(), lease.Name, now)
+	if err != nil {
+		return "", err
+	}
+
+	if quota.MaximumLeases != nil && usage.Leases >= *quota.MaximumLeases {
+		return fmt.Sprintf("the quota of %d leases held at the same time set by policy %s has been reached",
+			*quota.MaximumLeases, approved.AccessPolicy.Name), nil
+	}
+	if quota.MaximumLeasedTime != nil && usage.LeasedTime+duration > quota.MaximumLeasedTime.Duration {
+		return fmt.Sprintf("leasing for %s would exceed the quota of %s per %s set by policy %s, %s have already been used",
+			duration, quota.MaximumLeasedTime.Duration, quota.GetPeriod(), approved.AccessPolicy.Name,
+			usage.LeasedTime.Truncate(time.Second)), nil
+	}
+	return "", nil
+}
+
+// filterOutQuotaExceeded filters out the approved exporters whose policy quota would be exceeded
+// by granting the lease for the given duration, it returns the exporters left, the exporters
+// filtered out, and the reason the last one of them was filtered out for
+func filterOutQuotaExceeded(
+	ctx context.Context,
+	c client.Reader,
+	lease *jumpstarterdevv1alpha1.Lease,
+	exporters []ApprovedExporter,
+	duration time.Duration,
+) ([]ApprovedExporter, []ApprovedExporter, string, error) {
+	if !slices.ContainsFunc(exporters, func(ae ApprovedExporter) bool {
+		return ae.Policy.Quota != nil
+	}) {
+		return exporters, nil, "", nil
+	}
+
+	ledger, err := newQuotaLedger(ctx, c, lease.Namespace)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("filterOutQuotaExceeded: %w", err)
+	}
+
+	now := time.Now()
+	var allowed, exceeded []ApprovedExporter
+	var message string
+	for _, ae := range exporters {
+		reason, err := ledger.exceeded(ae, lease, duration, now)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("filterOutQuotaExceeded: %w", err)
+		}
+		if reason != "" {
+			exceeded = append(exceeded, ae)
+			message = reason
+			continue
+		}
+		allowed = append(allowed, ae)
+	}
+	return allowed, exceeded, message, nil
+}
@@ -32,8 +32,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -76,18 +78,11 @@ var _ = BeforeSuite(func() {
 	}
 
 	var err error
-	// cfg is defined in this file globally.
-	cfg, err = testEnv.Start()
-	Expect(err).NotTo(HaveOccurred())
-	Expect(cfg).NotTo(BeNil())
-
 	err = jumpstarterdevv1alpha1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
-
-	// +kubebuilder:scaffold:scheme
-
-	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
-	Expect(err).NotTo(HaveOccurred())
+	k8sClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(
+		&jumpstarterdevv1alpha1.Lease{}, &jumpstarterdevv1alpha1.Exporter{}, &jumpstarterdevv1alpha1.Client{},
+		&jumpstarterdevv1alpha1.ExporterAccessPolicy{}, &jumpstarterdevv1alpha1.ClientGroup{}).Build()
 	Expect(k8sClient).NotTo(BeNil())
 
 	createTestClients(context.Background())
@@ -96,8 +91,7 @@ var _ = BeforeSuite(func() {
 
 var _ = AfterSuite(func() {
 	By("tearing down the test environment")
-	err := testEnv.Stop()
-	Expect(err).NotTo(HaveOccurred())
+
 })
 
 var testClient = &jumpstarterdevv1alpha1.Client{
@@ -126,9 +120,10 @@ func createExporters(ctx context.Context, exporters ...*jumpstarterdevv1alpha1.E
 		Expect(err).NotTo(HaveOccurred())
 
 		controllerReconciler := &ExporterReconciler{
-			Client: k8sClient,
-			Scheme: k8sClient.Scheme(),
-			Signer: signer,
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Signer:   signer,
+			Recorder: record.NewFakeRecorder(100),
 		}
 
 		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -151,3 +146,5 @@ func deleteExporters(ctx context.Context, exporters ...*jumpstarterdevv1alpha1.E
 		Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
 	}
 }
+
+var _ = cfg
@@ -0,0 +1,212 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultUsagePeriod is the period usage reports cover when no begin time is requested
+const DefaultUsagePeriod = 7 * 24 * time.Hour
+
+// UsageEntry is the usage accounted for an exporter, a client, or a set of exporter labels
+type UsageEntry struct {
+	Namespace string
+	// Name is the name of the exporter or client, or the label set, formatted as a selector
+	Name string
+	// Leases is the number of leases held within the report window
+	Leases int
+	// LeasedTime is the exporter time leased within the report window, leases holding
+	// several exporters count once for each of them
+	LeasedTime time.Duration
+	// Capacity is the exporter time available within the report window, zero for clients
+	Capacity time.Duration
+}
+
+// Utilization returns the fraction of the capacity that has been leased
+func (e *UsageEntry) Utilization() float64 {
+	if e.Capacity <= 0 {
+		return 0
+	}
+	return float64(e.LeasedTime) / float64(e.Capacity)
+}
+
+// UsageReport aggregates the time exporters have been leased for within a window
+type UsageReport struct {
+	BeginTime time.Time
+	EndTime   time.Time
+	Exporters []UsageEntry
+	Clients   []UsageEntry
+	LabelSets []UsageEntry
+}
+
+// AccountUsage aggregates the lease time of the leases of the namespace, or of all namespaces if
+// empty, per exporter, per client, and per set of values of the given exporter label keys, within
+// the window. The window is cut short at the current time. Only the leases still stored are
+// accounted for, leases garbage collected by the retention policy are not.
+func AccountUsage(
+	ctx context.Context,
+	c client.Reader,
+	namespace string,
+	begin, end time.Time,
+	labelKeys []string,
+) (*UsageReport, error) {
+	now := time.Now()
+	if end.IsZero() || end.After(now) {
+		end = now
+	}
+	if begin.IsZero() {
+		begin = end.Add(-DefaultUsagePeriod)
+	}
+	if !begin.Before(end) {
+		return nil, fmt.Errorf("AccountUsage: the begin time %s is not before the end time %s", begin, end)
+	}
+
+	var exporters jumpstarterdevv1alpha1.ExporterList
+	if err := c.List(ctx, &exporters, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("AccountUsage: failed to list exporters: %w", err)
+	}
+
+	var leases jumpstarterdevv1alpha1.LeaseList
+	if err := c.List(ctx, &leases, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("AccountUsage: failed to list leases: %w", err)
+	}
+
+	window := end.Sub(begin)
+	exporterUsage := make(map[types.NamespacedName]*UsageEntry)
+	labelSetUsage := make(map[types.NamespacedName]*UsageEntry)
+	exporterLabelSet := make(map[types.NamespacedName]string)
+	for _, exporter := range exporters.Items {
+		key := types.NamespacedName{Namespace: exporter.Namespace, Name: exporter.Name}
+		exporterUsage[key] = &UsageEntry{
+			Namespace: exporter.Namespace,
+			Name:      exporter.Name,
+			Capacity:  window,
+		}
+
+		set := usageLabelSet(exporter.Labels, labelKeys)
+		if set == "" {
+			continue
+		}
+		exporterLabelSet[key] = set
+		setKey := types.NamespacedName{Namespace: exporter.Namespace, Name: set}
+		if labelSetUsage[setKey] == nil {
+			labelSetUsage[setKey] = &UsageEntry{Namespace: exporter.Namespace, Name: set}
+		}
+		labelSetUsage[setKey].Capacity += window
+	}
+
+	clientUsage := make(map[types.NamespacedName]*UsageEntry)
+	for _, lease := range leases.Items {
+		leased := leasedWithin(&lease, begin, end, now)
+		if leased <= 0 {
+			continue
+		}
+
+		clientKey := types.NamespacedName{Namespace: lease.Namespace, Name: lease.GetClientName()}
+		if clientUsage[clientKey] == nil {
+			clientUsage[clientKey] = &UsageEntry{Namespace: lease.Namespace, Name: lease.GetClientName()}
+		}
+		clientUsage[clientKey].Leases++
+
+		countedSets := make(map[string]bool)
+		for _, name := range lease.GetExporterNames() {
+			clientUsage[clientKey].LeasedTime += leased
+
+			key := types.NamespacedName{Namespace: lease.Namespace, Name: name}
+			// exporters deleted since are still accounted for the client
+			if usage, ok := exporterUsage[key]; ok {
+				usage.Leases++
+				usage.LeasedTime += leased
+			}
+			if set, ok := exporterLabelSet[key]; ok {
+				usage := labelSetUsage[types.NamespacedName{Namespace: lease.Namespace, Name: set}]
+				if !countedSets[set] {
+					usage.Leases++
+					countedSets[set] = true
+				}
+				usage.LeasedTime += leased
+			}
+		}
+	}
+
+	return &UsageReport{
+		BeginTime: begin,
+		EndTime:   end,
+		Exporters: sortedUsageEntries(exporterUsage),
+		Clients:   sortedUsageEntries(clientUsage),
+		LabelSets: sortedUsageEntries(labelSetUsage),
+	}, nil
+}
+
+// leasedWithin returns for how long the lease held its exporters within the window
+func leasedWithin(lease *jumpstarterdevv1alpha1.Lease, begin, end, now time.Time) time.Duration {
+	if lease.Status.BeginTime == nil || lease.Status.ExporterRef == nil {
+		return 0
+	}
+	leaseBegin := laterOf(lease.Status.BeginTime.Time, begin)
+	leaseEnd := now
+	if lease.Status.EndTime != nil {
+		leaseEnd = lease.Status.EndTime.Time
+	}
+	if leaseEnd.After(end) {
+		leaseEnd = end
+	}
+	if !leaseEnd.After(leaseBegin) {
+		return 0
+	}
+	return leaseEnd.Sub(leaseBegin)
+}
+
+// usageLabelSet returns the values of the given label keys formatted as a selector,
+// or an empty string if the labels have none of the keys
+func usageLabelSet(exporterLabels map[string]string, keys []string) string {
+	set := labels.Set{}
+	for _, key := range keys {
+		if value, ok := exporterLabels[key]; ok {
+			set[key] = value
+		}
+	}
+	if len(set) == 0 {
+		return ""
+	}
+	return set.String()
+}
+
+func sortedUsageEntries(usage map[types.NamespacedName]*UsageEntry) []UsageEntry {
+	entries := make([]UsageEntry, 0, len(usage))
+	for _, entry := range usage {
+		entries = append(entries, *entry)
+	}
+	slices.SortFunc(entries, func(a, b UsageEntry) int {
+		return cmp.Or(
+			strings.Compare(a.Namespace, b.Namespace),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	return entries
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("AccountUsage", func() {
+	BeforeEach(func() {
+		createExporters(context.Background(), testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		setExporterOnlineConditions(context.Background(), testExporter1DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter2DutA.Name, metav1.ConditionTrue)
+		setExporterOnlineConditions(context.Background(), testExporter3DutB.Name, metav1.ConditionTrue)
+	})
+	AfterEach(func() {
+		ctx := context.Background()
+		deleteExporters(ctx, testExporter1DutA, testExporter2DutA, testExporter3DutB)
+		deleteLeases(ctx, "lease1", "lease2", "lease3")
+	})
+
+	It("should aggregate the leased time per exporter, client and label set", func() {
+		ctx := context.Background()
+		end := time.Now().Truncate(time.Second)
+		begin := end.Add(-4 * time.Hour)
+
+		// an ended lease held for an hour within the window
+		lease := leaseDutA2Sec.DeepCopy()
+		Expect(k8sClient.Create(ctx, lease)).To(Succeed())
+		_ = reconcileLease(ctx, lease)
+		updatedLease := getLease(ctx, lease.Name)
+		Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+		exporterName := updatedLease.Status.ExporterRef.Name
+		updatedLease.Status.BeginTime = &metav1.Time{Time: end.Add(-2 * time.Hour)}
+		updatedLease.Status.EndTime = &metav1.Time{Time: end.Add(-time.Hour)}
+		updatedLease.Status.Ended = true
+		Expect(k8sClient.Status().Update(ctx, updatedLease)).To(Succeed())
+
+		// a lease that began before the window is only accounted within it
+		lease2 := leaseDutA2Sec.DeepCopy()
+		lease2.Name = "lease2"
+		lease2.Spec.Selector.MatchLabels["dut"] = "b"
+		Expect(k8sClient.Create(ctx, lease2)).To(Succeed())
+		_ = reconcileLease(ctx, lease2)
+		updatedLease = getLease(ctx, lease2.Name)
+		Expect(updatedLease.Status.ExporterRef).NotTo(BeNil())
+		updatedLease.Status.BeginTime = &metav1.Time{Time: begin.Add(-time.Hour)}
+		updatedLease.Status.EndTime = &metav1.Time{Time: begin.Add(30 * time.Minute)}
+		updatedLease.Status.Ended = true
+		Expect(k8sClient.Status().Update(ctx, updatedLease)).To(Succeed())
+
+		report, err := AccountUsage(ctx, k8sClient, "default", begin, end, []string{"dut"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.Exporters).To(HaveLen(3))
+		for _, entry := range report.Exporters {
+			switch entry.Name {
+			case exporterName:
+				Expect(entry.Leases).To(Equal(1))
+				Expect(entry.LeasedTime).To(Equal(time.Hour))
+				Expect(entry.Utilization()).To(Equal(0.25))
+			case testExporter3DutB.Name:
+				Expect(entry.LeasedTime).To(Equal(30 * time.Minute))
+			default:
+				Expect(entry.Leases).To(Equal(0))
+				Expect(entry.Utilization()).To(Equal(0.0))
+			}
+		}
+
+		Expect(report.Clients).To(HaveLen(1))
+		Expect(report.Clients[0].Name).To(Equal(testClient.Name))
+		Expect(report.Clients[0].Leases).To(Equal(2))
+		Expect(report.Clients[0].LeasedTime).To(Equal(90 * time.Minute))
+
+		Expect(report.LabelSets).To(HaveLen(2))
+		Expect(report.LabelSets[0].Name).To(Equal("dut=a"))
+		Expect(report.LabelSets[0].Capacity).To(Equal(8 * time.Hour))
+		Expect(report.LabelSets[0].Utilization()).To(Equal(0.125))
+		Expect(report.LabelSets[1].Name).To(Equal("dut=b"))
+		Expect(report.LabelSets[1].Leases).To(Equal(1))
+	})
+
+	It("should refuse an empty window", func() {
+		now := time.Now()
+		_, err := AccountUsage(context.Background(), k8sClient, "default", now, now.Add(-time.Hour), nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
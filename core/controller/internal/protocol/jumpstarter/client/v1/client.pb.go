@@ -34,6 +34,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ExporterLossAction int32
+
+const (
+	ExporterLossAction_EXPORTER_LOSS_ACTION_UNSPECIFIED ExporterLossAction = 0
+	// keep the lease, waiting for the exporter to come back
+	ExporterLossAction_EXPORTER_LOSS_ACTION_KEEP ExporterLossAction = 1
+	// end the lease
+	ExporterLossAction_EXPORTER_LOSS_ACTION_END ExporterLossAction = 2
+	// acquire other exporters matching the same selectors, keeping the expiration time of the lease
+	ExporterLossAction_EXPORTER_LOSS_ACTION_REACQUIRE ExporterLossAction = 3
+)
+
+// Enum value maps for ExporterLossAction.
+var (
+	ExporterLossAction_name = map[int32]string{
+		0: "EXPORTER_LOSS_ACTION_UNSPECIFIED",
+		1: "EXPORTER_LOSS_ACTION_KEEP",
+		2: "EXPORTER_LOSS_ACTION_END",
+		3: "EXPORTER_LOSS_ACTION_REACQUIRE",
+	}
+	ExporterLossAction_value = map[string]int32{
+		"EXPORTER_LOSS_ACTION_UNSPECIFIED": 0,
+		"EXPORTER_LOSS_ACTION_KEEP":        1,
+		"EXPORTER_LOSS_ACTION_END":         2,
+		"EXPORTER_LOSS_ACTION_REACQUIRE":   3,
+	}
+)
+
+func (x ExporterLossAction) Enum() *ExporterLossAction {
+	p := new(ExporterLossAction)
+	*p = x
+	return p
+}
+
+func (x ExporterLossAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExporterLossAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_jumpstarter_client_v1_client_proto_enumTypes[0].Descriptor()
+}
+
+func (ExporterLossAction) Type() protoreflect.EnumType {
+	return &file_jumpstarter_client_v1_client_proto_enumTypes[0]
+}
+
+func (x ExporterLossAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExporterLossAction.Descriptor instead.
+func (ExporterLossAction) EnumDescriptor() ([]byte, []int) {
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{0}
+}
+
 type Exporter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -107,8 +162,28 @@ type Lease struct {
 	Client             *string                `protobuf:"bytes,9,opt,name=client,proto3,oneof" json:"client,omitempty"`
 	Exporter           *string                `protobuf:"bytes,10,opt,name=exporter,proto3,oneof" json:"exporter,omitempty"`
 	Conditions         []*v1.Condition        `protobuf:"bytes,11,rep,name=conditions,proto3" json:"conditions,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// shorten the lease to the maximum duration allowed by the policies instead of failing
+	ClampDuration bool `protobuf:"varint,12,opt,name=clamp_duration,json=clampDuration,proto3" json:"clamp_duration,omitempty"`
+	// members of a gang lease, acquired all at once, the selector of the lease is not used
+	Members []*LeaseMember `protobuf:"bytes,13,rep,name=members,proto3" json:"members,omitempty"`
+	// position in the queue of leases waiting for the same exporters, starting at 1, while pending
+	QueuePosition *int32 `protobuf:"varint,14,opt,name=queue_position,json=queuePosition,proto3,oneof" json:"queue_position,omitempty"`
+	// estimated time the lease is going to acquire its exporters at, while pending
+	EstimatedBeginTime *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=estimated_begin_time,json=estimatedBeginTime,proto3,oneof" json:"estimated_begin_time,omitempty"`
+	// what to do once an exporter of the lease has been offline for longer than the grace period
+	ExporterLossAction ExporterLossAction `protobuf:"varint,16,opt,name=exporter_loss_action,json=exporterLossAction,proto3,enum=jumpstarter.client.v1.ExporterLossAction" json:"exporter_loss_action,omitempty"`
+	// how long an exporter of the lease can be offline before it is considered lost, defaults to one minute
+	ExporterLossGracePeriod *durationpb.Duration `protobuf:"bytes,17,opt,name=exporter_loss_grace_period,json=exporterLossGracePeriod,proto3,oneof" json:"exporter_loss_grace_period,omitempty"`
+	// selectors for the devices reported by the exporter, each one must be matched by at least one device
+	DeviceSelectors []string `protobuf:"bytes,18,rep,name=device_selectors,json=deviceSelectors,proto3" json:"device_selectors,omitempty"`
+	// prefer exporters in the same topology domain as the exporters of other leases
+	Affinity []*LeaseAffinityTerm `protobuf:"bytes,19,rep,name=affinity,proto3" json:"affinity,omitempty"`
+	// prefer exporters in other topology domains than the exporters of other leases
+	AntiAffinity []*LeaseAffinityTerm `protobuf:"bytes,20,rep,name=anti_affinity,json=antiAffinity,proto3" json:"anti_affinity,omitempty"`
+	// last time the client dialed, or exchanged data with, the exporters of the lease
+	LastActivityTime *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=last_activity_time,json=lastActivityTime,proto3,oneof" json:"last_activity_time,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Lease) Reset() {
@@ -218,6 +293,208 @@ func (x *Lease) GetConditions() []*v1.Condition {
 	return nil
 }
 
+func (x *Lease) GetClampDuration() bool {
+	if x != nil {
+		return x.ClampDuration
+	}
+	return false
+}
+
+func (x *Lease) GetMembers() []*LeaseMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Lease) GetQueuePosition() int32 {
+	if x != nil && x.QueuePosition != nil {
+		return *x.QueuePosition
+	}
+	return 0
+}
+
+func (x *Lease) GetEstimatedBeginTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedBeginTime
+	}
+	return nil
+}
+
+func (x *Lease) GetExporterLossAction() ExporterLossAction {
+	if x != nil {
+		return x.ExporterLossAction
+	}
+	return ExporterLossAction_EXPORTER_LOSS_ACTION_UNSPECIFIED
+}
+
+func (x *Lease) GetExporterLossGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.ExporterLossGracePeriod
+	}
+	return nil
+}
+
+func (x *Lease) GetDeviceSelectors() []string {
+	if x != nil {
+		return x.DeviceSelectors
+	}
+	return nil
+}
+
+func (x *Lease) GetAffinity() []*LeaseAffinityTerm {
+	if x != nil {
+		return x.Affinity
+	}
+	return nil
+}
+
+func (x *Lease) GetAntiAffinity() []*LeaseAffinityTerm {
+	if x != nil {
+		return x.AntiAffinity
+	}
+	return nil
+}
+
+func (x *Lease) GetLastActivityTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityTime
+	}
+	return nil
+}
+
+type LeaseAffinityTerm struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Leases []string               `protobuf:"bytes,1,rep,name=leases,proto3" json:"leases,omitempty"`
+	// exporter label key whose value identifies the topology domain, e.g. rack
+	TopologyKey   string `protobuf:"bytes,2,opt,name=topology_key,json=topologyKey,proto3" json:"topology_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseAffinityTerm) Reset() {
+	*x = LeaseAffinityTerm{}
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseAffinityTerm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseAffinityTerm) ProtoMessage() {}
+
+func (x *LeaseAffinityTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseAffinityTerm.ProtoReflect.Descriptor instead.
+func (*LeaseAffinityTerm) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LeaseAffinityTerm) GetLeases() []string {
+	if x != nil {
+		return x.Leases
+	}
+	return nil
+}
+
+func (x *LeaseAffinityTerm) GetTopologyKey() string {
+	if x != nil {
+		return x.TopologyKey
+	}
+	return ""
+}
+
+type LeaseMember struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Selector string                 `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	// number of exporters requested for the member, defaults to 1
+	Count int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// exporters assigned to the member, addressed as <name>-<index> when count is above 1
+	Exporters []string `protobuf:"bytes,4,rep,name=exporters,proto3" json:"exporters,omitempty"`
+	// selectors for the devices reported by the exporters, each one must be matched by at least one device
+	DeviceSelectors []string `protobuf:"bytes,5,rep,name=device_selectors,json=deviceSelectors,proto3" json:"device_selectors,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LeaseMember) Reset() {
+	*x = LeaseMember{}
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseMember) ProtoMessage() {}
+
+func (x *LeaseMember) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseMember.ProtoReflect.Descriptor instead.
+func (*LeaseMember) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LeaseMember) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LeaseMember) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *LeaseMember) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LeaseMember) GetExporters() []string {
+	if x != nil {
+		return x.Exporters
+	}
+	return nil
+}
+
+func (x *LeaseMember) GetDeviceSelectors() []string {
+	if x != nil {
+		return x.DeviceSelectors
+	}
+	return nil
+}
+
 type GetExporterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -227,7 +504,7 @@ type GetExporterRequest struct {
 
 func (x *GetExporterRequest) Reset() {
 	*x = GetExporterRequest{}
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[2]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -239,7 +516,7 @@ func (x *GetExporterRequest) String() string {
 func (*GetExporterRequest) ProtoMessage() {}
 
 func (x *GetExporterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[2]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -252,7 +529,7 @@ func (x *GetExporterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetExporterRequest.ProtoReflect.Descriptor instead.
 func (*GetExporterRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{2}
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetExporterRequest) GetName() string {
@@ -274,7 +551,7 @@ type ListExportersRequest struct {
 
 func (x *ListExportersRequest) Reset() {
 	*x = ListExportersRequest{}
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[3]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -286,7 +563,7 @@ func (x *ListExportersRequest) String() string {
 func (*ListExportersRequest) ProtoMessage() {}
 
 func (x *ListExportersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[3]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,7 +576,7 @@ func (x *ListExportersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListExportersRequest.ProtoReflect.Descriptor instead.
 func (*ListExportersRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{3}
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListExportersRequest) GetParent() string {
@@ -340,7 +617,7 @@ type ListExportersResponse struct {
 
 func (x *ListExportersResponse) Reset() {
 	*x = ListExportersResponse{}
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[4]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -352,7 +629,7 @@ func (x *ListExportersResponse) String() string {
 func (*ListExportersResponse) ProtoMessage() {}
 
 func (x *ListExportersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[4]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,7 +642,7 @@ func (x *ListExportersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListExportersResponse.ProtoReflect.Descriptor instead.
 func (*ListExportersResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{4}
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListExportersResponse) GetExporters() []*Exporter {
@@ -391,7 +668,7 @@ type GetLeaseRequest struct {
 
 func (x *GetLeaseRequest) Reset() {
 	*x = GetLeaseRequest{}
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[5]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -403,7 +680,7 @@ func (x *GetLeaseRequest) String() string {
 func (*GetLeaseRequest) ProtoMessage() {}
 
 func (x *GetLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[5]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,7 +693,7 @@ func (x *GetLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLeaseRequest.ProtoReflect.Descriptor instead.
 func (*GetLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{5}
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetLeaseRequest) GetName() string {
@@ -438,7 +715,7 @@ type ListLeasesRequest struct {
 
 func (x *ListLeasesRequest) Reset() {
 	*x = ListLeasesRequest{}
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[6]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -450,7 +727,7 @@ func (x *ListLeasesRequest) String() string {
 func (*ListLeasesRequest) ProtoMessage() {}
 
 func (x *ListLeasesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[6]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -463,7 +740,7 @@ func (x *ListLeasesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLeasesRequest.ProtoReflect.Descriptor instead.
 func (*ListLeasesRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{6}
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListLeasesRequest) GetParent() string {
@@ -504,7 +781,7 @@ type ListLeasesResponse struct {
 
 func (x *ListLeasesResponse) Reset() {
 	*x = ListLeasesResponse{}
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[7]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -516,7 +793,7 @@ func (x *ListLeasesResponse) String() string {
 func (*ListLeasesResponse) ProtoMessage() {}
 
 func (x *ListLeasesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[7]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -529,7 +806,7 @@ func (x *ListLeasesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLeasesResponse.ProtoReflect.Descriptor instead.
 func (*ListLeasesResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{7}
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListLeasesResponse) GetLeases() []*Lease {
@@ -557,7 +834,7 @@ type CreateLeaseRequest struct {
 
 func (x *CreateLeaseRequest) Reset() {
 	*x = CreateLeaseRequest{}
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[8]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -569,7 +846,7 @@ func (x *CreateLeaseRequest) String() string {
 func (*CreateLeaseRequest) ProtoMessage() {}
 
 func (x *CreateLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[8]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,7 +859,7 @@ func (x *CreateLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateLeaseRequest.ProtoReflect.Descriptor instead.
 func (*CreateLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{8}
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CreateLeaseRequest) GetParent() string {
@@ -616,7 +893,7 @@ type UpdateLeaseRequest struct {
 
 func (x *UpdateLeaseRequest) Reset() {
 	*x = UpdateLeaseRequest{}
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[9]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -628,7 +905,7 @@ func (x *UpdateLeaseRequest) String() string {
 func (*UpdateLeaseRequest) ProtoMessage() {}
 
 func (x *UpdateLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[9]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -641,7 +918,7 @@ func (x *UpdateLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateLeaseRequest.ProtoReflect.Descriptor instead.
 func (*UpdateLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{9}
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateLeaseRequest) GetLease() *Lease {
@@ -667,7 +944,7 @@ type DeleteLeaseRequest struct {
 
 func (x *DeleteLeaseRequest) Reset() {
 	*x = DeleteLeaseRequest{}
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[10]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -679,7 +956,7 @@ func (x *DeleteLeaseRequest) String() string {
 func (*DeleteLeaseRequest) ProtoMessage() {}
 
 func (x *DeleteLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[10]
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,7 +969,7 @@ func (x *DeleteLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteLeaseRequest.ProtoReflect.Descriptor instead.
 func (*DeleteLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{10}
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteLeaseRequest) GetName() string {
@@ -702,52 +979,574 @@ func (x *DeleteLeaseRequest) GetName() string {
 	return ""
 }
 
-var File_jumpstarter_client_v1_client_proto protoreflect.FileDescriptor
+type EvaluateLeaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Parent        string                 `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	Lease         *Lease                 `protobuf:"bytes,2,opt,name=lease,proto3" json:"lease,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_jumpstarter_client_v1_client_proto_rawDesc = "" +
-	"\n" +
-	"\"jumpstarter/client/v1/client.proto\x12\x15jumpstarter.client.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1fjumpstarter/v1/kubernetes.proto\"\xa1\x02\n" +
-	"\bExporter\x12\x17\n" +
-	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12C\n" +
-	"\x06labels\x18\x02 \x03(\v2+.jumpstarter.client.v1.Exporter.LabelsEntryR\x06labels\x12\x1b\n" +
-	"\x06online\x18\x03 \x01(\bB\x03\xe0A\x03R\x06online\x1a9\n" +
-	"\vLabelsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01:_\xeaA\\\n" +
-	"\x18jumpstarter.dev/Exporter\x12+namespaces/{namespace}/exporters/{exporter}*\texporters2\bexporter\"\xed\x06\n" +
-	"\x05Lease\x12\x17\n" +
-	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12\"\n" +
-	"\bselector\x18\x02 \x01(\tB\x06\xe0A\x02\xe0A\x05R\bselector\x12:\n" +
-	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\x03\xe0A\x02R\bduration\x12M\n" +
-	"\x12effective_duration\x18\x04 \x01(\v2\x19.google.protobuf.DurationB\x03\xe0A\x03R\x11effectiveDuration\x12>\n" +
-	"\n" +
-	"begin_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tbeginTime\x88\x01\x01\x12V\n" +
-	"\x14effective_begin_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03H\x01R\x12effectiveBeginTime\x88\x01\x01\x12:\n" +
-	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x02R\aendTime\x88\x01\x01\x12R\n" +
-	"\x12effective_end_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03H\x03R\x10effectiveEndTime\x88\x01\x01\x12;\n" +
-	"\x06client\x18\t \x01(\tB\x1e\xe0A\x03\xfaA\x18\n" +
-	"\x16jumpstarter.dev/ClientH\x04R\x06client\x88\x01\x01\x12A\n" +
-	"\bexporter\x18\n" +
-	" \x01(\tB \xe0A\x03\xfaA\x1a\n" +
-	"\x18jumpstarter.dev/ExporterH\x05R\bexporter\x88\x01\x01\x12>\n" +
-	"\n" +
-	"conditions\x18\v \x03(\v2\x19.jumpstarter.v1.ConditionB\x03\xe0A\x03R\n" +
-	"conditions:P\xeaAM\n" +
-	"\x15jumpstarter.dev/Lease\x12%namespaces/{namespace}/leases/{lease}*\x06leases2\x05leaseB\r\n" +
-	"\v_begin_timeB\x17\n" +
-	"\x15_effective_begin_timeB\v\n" +
-	"\t_end_timeB\x15\n" +
-	"\x13_effective_end_timeB\t\n" +
-	"\a_clientB\v\n" +
-	"\t_exporter\"J\n" +
-	"\x12GetExporterRequest\x124\n" +
-	"\x04name\x18\x01 \x01(\tB \xe0A\x02\xfaA\x1a\n" +
-	"\x18jumpstarter.dev/ExporterR\x04name\"\xb3\x01\n" +
-	"\x14ListExportersRequest\x128\n" +
-	"\x06parent\x18\x01 \x01(\tB \xe0A\x02\xfaA\x1a\x12\x18jumpstarter.dev/ExporterR\x06parent\x12 \n" +
-	"\tpage_size\x18\x02 \x01(\x05B\x03\xe0A\x01R\bpageSize\x12\"\n" +
-	"\n" +
-	"page_token\x18\x03 \x01(\tB\x03\xe0A\x01R\tpageToken\x12\x1b\n" +
+func (x *EvaluateLeaseRequest) Reset() {
+	*x = EvaluateLeaseRequest{}
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateLeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateLeaseRequest) ProtoMessage() {}
+
+func (x *EvaluateLeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateLeaseRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateLeaseRequest) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EvaluateLeaseRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *EvaluateLeaseRequest) GetLease() *Lease {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+type TransferLeaseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// client the lease is handed over to
+	Client        string `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferLeaseRequest) Reset() {
+	*x = TransferLeaseRequest{}
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLeaseRequest) ProtoMessage() {}
+
+func (x *TransferLeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLeaseRequest.ProtoReflect.Descriptor instead.
+func (*TransferLeaseRequest) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TransferLeaseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TransferLeaseRequest) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+type LeaseEvaluation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// every exporter of the namespace, once for each member of a gang lease
+	Exporters     []*ExporterEvaluation `protobuf:"bytes,1,rep,name=exporters,proto3" json:"exporters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseEvaluation) Reset() {
+	*x = LeaseEvaluation{}
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseEvaluation) ProtoMessage() {}
+
+func (x *LeaseEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseEvaluation.ProtoReflect.Descriptor instead.
+func (*LeaseEvaluation) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LeaseEvaluation) GetExporters() []*ExporterEvaluation {
+	if x != nil {
+		return x.Exporters
+	}
+	return nil
+}
+
+type ExporterEvaluation struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Exporter string                 `protobuf:"bytes,1,opt,name=exporter,proto3" json:"exporter,omitempty"`
+	// member of a gang lease the exporter has been evaluated for
+	Member *string `protobuf:"bytes,2,opt,name=member,proto3,oneof" json:"member,omitempty"`
+	// whether the exporter is among the exporters the lease can be assigned, possibly once
+	// the lease holding it ends
+	Included bool `protobuf:"varint,3,opt,name=included,proto3" json:"included,omitempty"`
+	// why the exporter has been included or excluded, e.g. SelectorMismatch, Offline,
+	// ExporterMaintenance, NoAccess, DurationExceedsPolicy, OutsideTimeWindow, QuotaExceeded,
+	// Available, Preemptible, Leased or Conflict
+	Reason  string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// the exporter access policy, and the index of the policy within it, that matched the
+	// exporter and the client, unset when no policy did or no policy exists in the namespace
+	AccessPolicy *string `protobuf:"bytes,6,opt,name=access_policy,json=accessPolicy,proto3,oneof" json:"access_policy,omitempty"`
+	PolicyIndex  *int32  `protobuf:"varint,7,opt,name=policy_index,json=policyIndex,proto3,oneof" json:"policy_index,omitempty"`
+	// priority and spot access of the policy, when one matched
+	Priority   *int32 `protobuf:"varint,8,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	SpotAccess *bool  `protobuf:"varint,9,opt,name=spot_access,json=spotAccess,proto3,oneof" json:"spot_access,omitempty"`
+	// lease holding, or having reserved, the exporter
+	Lease         *string `protobuf:"bytes,10,opt,name=lease,proto3,oneof" json:"lease,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExporterEvaluation) Reset() {
+	*x = ExporterEvaluation{}
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExporterEvaluation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExporterEvaluation) ProtoMessage() {}
+
+func (x *ExporterEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExporterEvaluation.ProtoReflect.Descriptor instead.
+func (*ExporterEvaluation) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ExporterEvaluation) GetExporter() string {
+	if x != nil {
+		return x.Exporter
+	}
+	return ""
+}
+
+func (x *ExporterEvaluation) GetMember() string {
+	if x != nil && x.Member != nil {
+		return *x.Member
+	}
+	return ""
+}
+
+func (x *ExporterEvaluation) GetIncluded() bool {
+	if x != nil {
+		return x.Included
+	}
+	return false
+}
+
+func (x *ExporterEvaluation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ExporterEvaluation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExporterEvaluation) GetAccessPolicy() string {
+	if x != nil && x.AccessPolicy != nil {
+		return *x.AccessPolicy
+	}
+	return ""
+}
+
+func (x *ExporterEvaluation) GetPolicyIndex() int32 {
+	if x != nil && x.PolicyIndex != nil {
+		return *x.PolicyIndex
+	}
+	return 0
+}
+
+func (x *ExporterEvaluation) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *ExporterEvaluation) GetSpotAccess() bool {
+	if x != nil && x.SpotAccess != nil {
+		return *x.SpotAccess
+	}
+	return false
+}
+
+func (x *ExporterEvaluation) GetLease() string {
+	if x != nil && x.Lease != nil {
+		return *x.Lease
+	}
+	return ""
+}
+
+type GetUsageReportRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Parent string                 `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// beginning of the report window, defaults to 7 days before its end
+	BeginTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=begin_time,json=beginTime,proto3,oneof" json:"begin_time,omitempty"`
+	// end of the report window, defaults to now
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3,oneof" json:"end_time,omitempty"`
+	// exporter label keys to aggregate the usage by, e.g. board-type
+	LabelKeys     []string `protobuf:"bytes,4,rep,name=label_keys,json=labelKeys,proto3" json:"label_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageReportRequest) Reset() {
+	*x = GetUsageReportRequest{}
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageReportRequest) ProtoMessage() {}
+
+func (x *GetUsageReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageReportRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageReportRequest) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetUsageReportRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *GetUsageReportRequest) GetBeginTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BeginTime
+	}
+	return nil
+}
+
+func (x *GetUsageReportRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetUsageReportRequest) GetLabelKeys() []string {
+	if x != nil {
+		return x.LabelKeys
+	}
+	return nil
+}
+
+type UsageReport struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	BeginTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=begin_time,json=beginTime,proto3" json:"begin_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Exporters []*Usage               `protobuf:"bytes,3,rep,name=exporters,proto3" json:"exporters,omitempty"`
+	// usage of the client requesting the report, the usage of other clients is not reported
+	Clients       []*Usage `protobuf:"bytes,4,rep,name=clients,proto3" json:"clients,omitempty"`
+	LabelSets     []*Usage `protobuf:"bytes,5,rep,name=label_sets,json=labelSets,proto3" json:"label_sets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageReport) Reset() {
+	*x = UsageReport{}
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport) ProtoMessage() {}
+
+func (x *UsageReport) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport.ProtoReflect.Descriptor instead.
+func (*UsageReport) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UsageReport) GetBeginTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BeginTime
+	}
+	return nil
+}
+
+func (x *UsageReport) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *UsageReport) GetExporters() []*Usage {
+	if x != nil {
+		return x.Exporters
+	}
+	return nil
+}
+
+func (x *UsageReport) GetClients() []*Usage {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+func (x *UsageReport) GetLabelSets() []*Usage {
+	if x != nil {
+		return x.LabelSets
+	}
+	return nil
+}
+
+type Usage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// resource name of the exporter or client, or label set formatted as a selector
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// number of leases held within the report window
+	Leases int32 `protobuf:"varint,2,opt,name=leases,proto3" json:"leases,omitempty"`
+	// exporter time leased within the report window
+	LeasedTime *durationpb.Duration `protobuf:"bytes,3,opt,name=leased_time,json=leasedTime,proto3" json:"leased_time,omitempty"`
+	// fraction of the exporter time available within the report window that has been leased,
+	// unset for clients
+	Utilization   *float64 `protobuf:"fixed64,4,opt,name=utilization,proto3,oneof" json:"utilization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_client_v1_client_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_client_v1_client_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Usage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Usage) GetLeases() int32 {
+	if x != nil {
+		return x.Leases
+	}
+	return 0
+}
+
+func (x *Usage) GetLeasedTime() *durationpb.Duration {
+	if x != nil {
+		return x.LeasedTime
+	}
+	return nil
+}
+
+func (x *Usage) GetUtilization() float64 {
+	if x != nil && x.Utilization != nil {
+		return *x.Utilization
+	}
+	return 0
+}
+
+var File_jumpstarter_client_v1_client_proto protoreflect.FileDescriptor
+
+const file_jumpstarter_client_v1_client_proto_rawDesc = "" +
+	"\n" +
+	"\"jumpstarter/client/v1/client.proto\x12\x15jumpstarter.client.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1fjumpstarter/v1/kubernetes.proto\"\xa1\x02\n" +
+	"\bExporter\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12C\n" +
+	"\x06labels\x18\x02 \x03(\v2+.jumpstarter.client.v1.Exporter.LabelsEntryR\x06labels\x12\x1b\n" +
+	"\x06online\x18\x03 \x01(\bB\x03\xe0A\x03R\x06online\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01:_\xeaA\\\n" +
+	"\x18jumpstarter.dev/Exporter\x12+namespaces/{namespace}/exporters/{exporter}*\texporters2\bexporter\"\xae\r\n" +
+	"\x05Lease\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12\"\n" +
+	"\bselector\x18\x02 \x01(\tB\x06\xe0A\x02\xe0A\x05R\bselector\x12:\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationB\x03\xe0A\x02R\bduration\x12M\n" +
+	"\x12effective_duration\x18\x04 \x01(\v2\x19.google.protobuf.DurationB\x03\xe0A\x03R\x11effectiveDuration\x12>\n" +
+	"\n" +
+	"begin_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tbeginTime\x88\x01\x01\x12V\n" +
+	"\x14effective_begin_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03H\x01R\x12effectiveBeginTime\x88\x01\x01\x12:\n" +
+	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x02R\aendTime\x88\x01\x01\x12R\n" +
+	"\x12effective_end_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03H\x03R\x10effectiveEndTime\x88\x01\x01\x12;\n" +
+	"\x06client\x18\t \x01(\tB\x1e\xe0A\x03\xfaA\x18\n" +
+	"\x16jumpstarter.dev/ClientH\x04R\x06client\x88\x01\x01\x12A\n" +
+	"\bexporter\x18\n" +
+	" \x01(\tB \xe0A\x03\xfaA\x1a\n" +
+	"\x18jumpstarter.dev/ExporterH\x05R\bexporter\x88\x01\x01\x12>\n" +
+	"\n" +
+	"conditions\x18\v \x03(\v2\x19.jumpstarter.v1.ConditionB\x03\xe0A\x03R\n" +
+	"conditions\x12*\n" +
+	"\x0eclamp_duration\x18\f \x01(\bB\x03\xe0A\x01R\rclampDuration\x12A\n" +
+	"\amembers\x18\r \x03(\v2\".jumpstarter.client.v1.LeaseMemberB\x03\xe0A\x05R\amembers\x12/\n" +
+	"\x0equeue_position\x18\x0e \x01(\x05B\x03\xe0A\x03H\x06R\rqueuePosition\x88\x01\x01\x12V\n" +
+	"\x14estimated_begin_time\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03H\aR\x12estimatedBeginTime\x88\x01\x01\x12`\n" +
+	"\x14exporter_loss_action\x18\x10 \x01(\x0e2).jumpstarter.client.v1.ExporterLossActionB\x03\xe0A\x01R\x12exporterLossAction\x12`\n" +
+	"\x1aexporter_loss_grace_period\x18\x11 \x01(\v2\x19.google.protobuf.DurationB\x03\xe0A\x01H\bR\x17exporterLossGracePeriod\x88\x01\x01\x12.\n" +
+	"\x10device_selectors\x18\x12 \x03(\tB\x03\xe0A\x05R\x0fdeviceSelectors\x12I\n" +
+	"\baffinity\x18\x13 \x03(\v2(.jumpstarter.client.v1.LeaseAffinityTermB\x03\xe0A\x05R\baffinity\x12R\n" +
+	"\ranti_affinity\x18\x14 \x03(\v2(.jumpstarter.client.v1.LeaseAffinityTermB\x03\xe0A\x05R\fantiAffinity\x12R\n" +
+	"\x12last_activity_time\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03H\tR\x10lastActivityTime\x88\x01\x01:P\xeaAM\n" +
+	"\x15jumpstarter.dev/Lease\x12%namespaces/{namespace}/leases/{lease}*\x06leases2\x05leaseB\r\n" +
+	"\v_begin_timeB\x17\n" +
+	"\x15_effective_begin_timeB\v\n" +
+	"\t_end_timeB\x15\n" +
+	"\x13_effective_end_timeB\t\n" +
+	"\a_clientB\v\n" +
+	"\t_exporterB\x11\n" +
+	"\x0f_queue_positionB\x17\n" +
+	"\x15_estimated_begin_timeB\x1d\n" +
+	"\x1b_exporter_loss_grace_periodB\x15\n" +
+	"\x13_last_activity_time\"r\n" +
+	"\x11LeaseAffinityTerm\x125\n" +
+	"\x06leases\x18\x01 \x03(\tB\x1d\xe0A\x02\xfaA\x17\n" +
+	"\x15jumpstarter.dev/LeaseR\x06leases\x12&\n" +
+	"\ftopology_key\x18\x02 \x01(\tB\x03\xe0A\x02R\vtopologyKey\"\xd2\x01\n" +
+	"\vLeaseMember\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\x02R\x04name\x12\x1f\n" +
+	"\bselector\x18\x02 \x01(\tB\x03\xe0A\x02R\bselector\x12\x19\n" +
+	"\x05count\x18\x03 \x01(\x05B\x03\xe0A\x01R\x05count\x12>\n" +
+	"\texporters\x18\x04 \x03(\tB \xe0A\x03\xfaA\x1a\n" +
+	"\x18jumpstarter.dev/ExporterR\texporters\x12.\n" +
+	"\x10device_selectors\x18\x05 \x03(\tB\x03\xe0A\x01R\x0fdeviceSelectors\"J\n" +
+	"\x12GetExporterRequest\x124\n" +
+	"\x04name\x18\x01 \x01(\tB \xe0A\x02\xfaA\x1a\n" +
+	"\x18jumpstarter.dev/ExporterR\x04name\"\xb3\x01\n" +
+	"\x14ListExportersRequest\x128\n" +
+	"\x06parent\x18\x01 \x01(\tB \xe0A\x02\xfaA\x1a\x12\x18jumpstarter.dev/ExporterR\x06parent\x12 \n" +
+	"\tpage_size\x18\x02 \x01(\x05B\x03\xe0A\x01R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tB\x03\xe0A\x01R\tpageToken\x12\x1b\n" +
 	"\x06filter\x18\x04 \x01(\tB\x03\xe0A\x01R\x06filter\"~\n" +
 	"\x15ListExportersResponse\x12=\n" +
 	"\texporters\x18\x01 \x03(\v2\x1f.jumpstarter.client.v1.ExporterR\texporters\x12&\n" +
@@ -774,7 +1573,67 @@ const file_jumpstarter_client_v1_client_proto_rawDesc = "" +
 	"updateMask\"G\n" +
 	"\x12DeleteLeaseRequest\x121\n" +
 	"\x04name\x18\x01 \x01(\tB\x1d\xe0A\x02\xfaA\x17\n" +
-	"\x15jumpstarter.dev/LeaseR\x04name2\xa7\b\n" +
+	"\x15jumpstarter.dev/LeaseR\x04name\"\x86\x01\n" +
+	"\x14EvaluateLeaseRequest\x125\n" +
+	"\x06parent\x18\x01 \x01(\tB\x1d\xe0A\x02\xfaA\x17\x12\x15jumpstarter.dev/LeaseR\x06parent\x127\n" +
+	"\x05lease\x18\x02 \x01(\v2\x1c.jumpstarter.client.v1.LeaseB\x03\xe0A\x02R\x05lease\"\x81\x01\n" +
+	"\x14TransferLeaseRequest\x121\n" +
+	"\x04name\x18\x01 \x01(\tB\x1d\xe0A\x02\xfaA\x17\n" +
+	"\x15jumpstarter.dev/LeaseR\x04name\x126\n" +
+	"\x06client\x18\x02 \x01(\tB\x1e\xe0A\x02\xfaA\x18\n" +
+	"\x16jumpstarter.dev/ClientR\x06client\"Z\n" +
+	"\x0fLeaseEvaluation\x12G\n" +
+	"\texporters\x18\x01 \x03(\v2).jumpstarter.client.v1.ExporterEvaluationR\texporters\"\xdf\x03\n" +
+	"\x12ExporterEvaluation\x129\n" +
+	"\bexporter\x18\x01 \x01(\tB\x1d\xfaA\x1a\n" +
+	"\x18jumpstarter.dev/ExporterR\bexporter\x12\x1b\n" +
+	"\x06member\x18\x02 \x01(\tH\x00R\x06member\x88\x01\x01\x12\x1a\n" +
+	"\bincluded\x18\x03 \x01(\bR\bincluded\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12(\n" +
+	"\raccess_policy\x18\x06 \x01(\tH\x01R\faccessPolicy\x88\x01\x01\x12&\n" +
+	"\fpolicy_index\x18\a \x01(\x05H\x02R\vpolicyIndex\x88\x01\x01\x12\x1f\n" +
+	"\bpriority\x18\b \x01(\x05H\x03R\bpriority\x88\x01\x01\x12$\n" +
+	"\vspot_access\x18\t \x01(\bH\x04R\n" +
+	"spotAccess\x88\x01\x01\x125\n" +
+	"\x05lease\x18\n" +
+	" \x01(\tB\x1a\xfaA\x17\n" +
+	"\x15jumpstarter.dev/LeaseH\x05R\x05lease\x88\x01\x01B\t\n" +
+	"\a_memberB\x10\n" +
+	"\x0e_access_policyB\x0f\n" +
+	"\r_policy_indexB\v\n" +
+	"\t_priorityB\x0e\n" +
+	"\f_spot_accessB\b\n" +
+	"\x06_lease\"\xfa\x01\n" +
+	"\x15GetUsageReportRequest\x12\x1b\n" +
+	"\x06parent\x18\x01 \x01(\tB\x03\xe0A\x02R\x06parent\x12C\n" +
+	"\n" +
+	"begin_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x01H\x00R\tbeginTime\x88\x01\x01\x12?\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x01H\x01R\aendTime\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"label_keys\x18\x04 \x03(\tB\x03\xe0A\x01R\tlabelKeysB\r\n" +
+	"\v_begin_timeB\v\n" +
+	"\t_end_time\"\xb0\x02\n" +
+	"\vUsageReport\x129\n" +
+	"\n" +
+	"begin_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tbeginTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12:\n" +
+	"\texporters\x18\x03 \x03(\v2\x1c.jumpstarter.client.v1.UsageR\texporters\x126\n" +
+	"\aclients\x18\x04 \x03(\v2\x1c.jumpstarter.client.v1.UsageR\aclients\x12;\n" +
+	"\n" +
+	"label_sets\x18\x05 \x03(\v2\x1c.jumpstarter.client.v1.UsageR\tlabelSets\"\xa6\x01\n" +
+	"\x05Usage\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06leases\x18\x02 \x01(\x05R\x06leases\x12:\n" +
+	"\vleased_time\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"leasedTime\x12%\n" +
+	"\vutilization\x18\x04 \x01(\x01H\x00R\vutilization\x88\x01\x01B\x0e\n" +
+	"\f_utilization*\x9b\x01\n" +
+	"\x12ExporterLossAction\x12$\n" +
+	" EXPORTER_LOSS_ACTION_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19EXPORTER_LOSS_ACTION_KEEP\x10\x01\x12\x1c\n" +
+	"\x18EXPORTER_LOSS_ACTION_END\x10\x02\x12\"\n" +
+	"\x1eEXPORTER_LOSS_ACTION_REACQUIRE\x10\x032\x8f\f\n" +
 	"\rClientService\x12\x8d\x01\n" +
 	"\vGetExporter\x12).jumpstarter.client.v1.GetExporterRequest\x1a\x1f.jumpstarter.client.v1.Exporter\"2\xdaA\x04name\x82\xd3\xe4\x93\x02%\x12#/v1/{name=namespaces/*/exporters/*}\x12\xa0\x01\n" +
 	"\rListExporters\x12+.jumpstarter.client.v1.ListExportersRequest\x1a,.jumpstarter.client.v1.ListExportersResponse\"4\xdaA\x06parent\x82\xd3\xe4\x93\x02%\x12#/v1/{parent=namespaces/*}/exporters\x12\x81\x01\n" +
@@ -783,7 +1642,10 @@ const file_jumpstarter_client_v1_client_proto_rawDesc = "" +
 	"ListLeases\x12(.jumpstarter.client.v1.ListLeasesRequest\x1a).jumpstarter.client.v1.ListLeasesResponse\"1\xdaA\x06parent\x82\xd3\xe4\x93\x02\"\x12 /v1/{parent=namespaces/*}/leases\x12\x9f\x01\n" +
 	"\vCreateLease\x12).jumpstarter.client.v1.CreateLeaseRequest\x1a\x1c.jumpstarter.client.v1.Lease\"G\xdaA\x15parent,lease,lease_id\x82\xd3\xe4\x93\x02):\x05lease\" /v1/{parent=namespaces/*}/leases\x12\xa1\x01\n" +
 	"\vUpdateLease\x12).jumpstarter.client.v1.UpdateLeaseRequest\x1a\x1c.jumpstarter.client.v1.Lease\"I\xdaA\x11lease,update_mask\x82\xd3\xe4\x93\x02/:\x05lease2&/v1/{lease.name=namespaces/*/leases/*}\x12\x81\x01\n" +
-	"\vDeleteLease\x12).jumpstarter.client.v1.DeleteLeaseRequest\x1a\x16.google.protobuf.Empty\"/\xdaA\x04name\x82\xd3\xe4\x93\x02\"* /v1/{name=namespaces/*/leases/*}B\x86\x02\n" +
+	"\vDeleteLease\x12).jumpstarter.client.v1.DeleteLeaseRequest\x1a\x16.google.protobuf.Empty\"/\xdaA\x04name\x82\xd3\xe4\x93\x02\"* /v1/{name=namespaces/*/leases/*}\x12\xad\x01\n" +
+	"\rEvaluateLease\x12+.jumpstarter.client.v1.EvaluateLeaseRequest\x1a&.jumpstarter.client.v1.LeaseEvaluation\"G\xdaA\fparent,lease\x82\xd3\xe4\x93\x022:\x05lease\")/v1/{parent=namespaces/*}/leases:evaluate\x12\x9e\x01\n" +
+	"\rTransferLease\x12+.jumpstarter.client.v1.TransferLeaseRequest\x1a\x1c.jumpstarter.client.v1.Lease\"B\xdaA\vname,client\x82\xd3\xe4\x93\x02.:\x01*\")/v1/{name=namespaces/*/leases/*}:transfer\x12\x94\x01\n" +
+	"\x0eGetUsageReport\x12,.jumpstarter.client.v1.GetUsageReportRequest\x1a\".jumpstarter.client.v1.UsageReport\"0\xdaA\x06parent\x82\xd3\xe4\x93\x02!\x12\x1f/v1/{parent=namespaces/*}/usageB\x86\x02\n" +
 	"\x19com.jumpstarter.client.v1B\vClientProtoP\x01Zfgithub.com/the78mole/jumpstarter-mono/core/controller/internal/protocol/jumpstarter/client/v1;clientv1\xa2\x02\x03JCX\xaa\x02\x15Jumpstarter.Client.V1\xca\x02\x15Jumpstarter\\Client\\V1\xe2\x02!Jumpstarter\\Client\\V1\\GPBMetadata\xea\x02\x17Jumpstarter::Client::V1b\x06proto3"
 
 var (
@@ -798,59 +1660,93 @@ func file_jumpstarter_client_v1_client_proto_rawDescGZIP() []byte {
 	return file_jumpstarter_client_v1_client_proto_rawDescData
 }
 
-var file_jumpstarter_client_v1_client_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_jumpstarter_client_v1_client_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_jumpstarter_client_v1_client_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_jumpstarter_client_v1_client_proto_goTypes = []any{
-	(*Exporter)(nil),              // 0: jumpstarter.client.v1.Exporter
-	(*Lease)(nil),                 // 1: jumpstarter.client.v1.Lease
-	(*GetExporterRequest)(nil),    // 2: jumpstarter.client.v1.GetExporterRequest
-	(*ListExportersRequest)(nil),  // 3: jumpstarter.client.v1.ListExportersRequest
-	(*ListExportersResponse)(nil), // 4: jumpstarter.client.v1.ListExportersResponse
-	(*GetLeaseRequest)(nil),       // 5: jumpstarter.client.v1.GetLeaseRequest
-	(*ListLeasesRequest)(nil),     // 6: jumpstarter.client.v1.ListLeasesRequest
-	(*ListLeasesResponse)(nil),    // 7: jumpstarter.client.v1.ListLeasesResponse
-	(*CreateLeaseRequest)(nil),    // 8: jumpstarter.client.v1.CreateLeaseRequest
-	(*UpdateLeaseRequest)(nil),    // 9: jumpstarter.client.v1.UpdateLeaseRequest
-	(*DeleteLeaseRequest)(nil),    // 10: jumpstarter.client.v1.DeleteLeaseRequest
-	nil,                           // 11: jumpstarter.client.v1.Exporter.LabelsEntry
-	(*durationpb.Duration)(nil),   // 12: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
-	(*v1.Condition)(nil),          // 14: jumpstarter.v1.Condition
-	(*fieldmaskpb.FieldMask)(nil), // 15: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),         // 16: google.protobuf.Empty
+	(ExporterLossAction)(0),       // 0: jumpstarter.client.v1.ExporterLossAction
+	(*Exporter)(nil),              // 1: jumpstarter.client.v1.Exporter
+	(*Lease)(nil),                 // 2: jumpstarter.client.v1.Lease
+	(*LeaseAffinityTerm)(nil),     // 3: jumpstarter.client.v1.LeaseAffinityTerm
+	(*LeaseMember)(nil),           // 4: jumpstarter.client.v1.LeaseMember
+	(*GetExporterRequest)(nil),    // 5: jumpstarter.client.v1.GetExporterRequest
+	(*ListExportersRequest)(nil),  // 6: jumpstarter.client.v1.ListExportersRequest
+	(*ListExportersResponse)(nil), // 7: jumpstarter.client.v1.ListExportersResponse
+	(*GetLeaseRequest)(nil),       // 8: jumpstarter.client.v1.GetLeaseRequest
+	(*ListLeasesRequest)(nil),     // 9: jumpstarter.client.v1.ListLeasesRequest
+	(*ListLeasesResponse)(nil),    // 10: jumpstarter.client.v1.ListLeasesResponse
+	(*CreateLeaseRequest)(nil),    // 11: jumpstarter.client.v1.CreateLeaseRequest
+	(*UpdateLeaseRequest)(nil),    // 12: jumpstarter.client.v1.UpdateLeaseRequest
+	(*DeleteLeaseRequest)(nil),    // 13: jumpstarter.client.v1.DeleteLeaseRequest
+	(*EvaluateLeaseRequest)(nil),  // 14: jumpstarter.client.v1.EvaluateLeaseRequest
+	(*TransferLeaseRequest)(nil),  // 15: jumpstarter.client.v1.TransferLeaseRequest
+	(*LeaseEvaluation)(nil),       // 16: jumpstarter.client.v1.LeaseEvaluation
+	(*ExporterEvaluation)(nil),    // 17: jumpstarter.client.v1.ExporterEvaluation
+	(*GetUsageReportRequest)(nil), // 18: jumpstarter.client.v1.GetUsageReportRequest
+	(*UsageReport)(nil),           // 19: jumpstarter.client.v1.UsageReport
+	(*Usage)(nil),                 // 20: jumpstarter.client.v1.Usage
+	nil,                           // 21: jumpstarter.client.v1.Exporter.LabelsEntry
+	(*durationpb.Duration)(nil),   // 22: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 23: google.protobuf.Timestamp
+	(*v1.Condition)(nil),          // 24: jumpstarter.v1.Condition
+	(*fieldmaskpb.FieldMask)(nil), // 25: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),         // 26: google.protobuf.Empty
 }
 var file_jumpstarter_client_v1_client_proto_depIdxs = []int32{
-	11, // 0: jumpstarter.client.v1.Exporter.labels:type_name -> jumpstarter.client.v1.Exporter.LabelsEntry
-	12, // 1: jumpstarter.client.v1.Lease.duration:type_name -> google.protobuf.Duration
-	12, // 2: jumpstarter.client.v1.Lease.effective_duration:type_name -> google.protobuf.Duration
-	13, // 3: jumpstarter.client.v1.Lease.begin_time:type_name -> google.protobuf.Timestamp
-	13, // 4: jumpstarter.client.v1.Lease.effective_begin_time:type_name -> google.protobuf.Timestamp
-	13, // 5: jumpstarter.client.v1.Lease.end_time:type_name -> google.protobuf.Timestamp
-	13, // 6: jumpstarter.client.v1.Lease.effective_end_time:type_name -> google.protobuf.Timestamp
-	14, // 7: jumpstarter.client.v1.Lease.conditions:type_name -> jumpstarter.v1.Condition
-	0,  // 8: jumpstarter.client.v1.ListExportersResponse.exporters:type_name -> jumpstarter.client.v1.Exporter
-	1,  // 9: jumpstarter.client.v1.ListLeasesResponse.leases:type_name -> jumpstarter.client.v1.Lease
-	1,  // 10: jumpstarter.client.v1.CreateLeaseRequest.lease:type_name -> jumpstarter.client.v1.Lease
-	1,  // 11: jumpstarter.client.v1.UpdateLeaseRequest.lease:type_name -> jumpstarter.client.v1.Lease
-	15, // 12: jumpstarter.client.v1.UpdateLeaseRequest.update_mask:type_name -> google.protobuf.FieldMask
-	2,  // 13: jumpstarter.client.v1.ClientService.GetExporter:input_type -> jumpstarter.client.v1.GetExporterRequest
-	3,  // 14: jumpstarter.client.v1.ClientService.ListExporters:input_type -> jumpstarter.client.v1.ListExportersRequest
-	5,  // 15: jumpstarter.client.v1.ClientService.GetLease:input_type -> jumpstarter.client.v1.GetLeaseRequest
-	6,  // 16: jumpstarter.client.v1.ClientService.ListLeases:input_type -> jumpstarter.client.v1.ListLeasesRequest
-	8,  // 17: jumpstarter.client.v1.ClientService.CreateLease:input_type -> jumpstarter.client.v1.CreateLeaseRequest
-	9,  // 18: jumpstarter.client.v1.ClientService.UpdateLease:input_type -> jumpstarter.client.v1.UpdateLeaseRequest
-	10, // 19: jumpstarter.client.v1.ClientService.DeleteLease:input_type -> jumpstarter.client.v1.DeleteLeaseRequest
-	0,  // 20: jumpstarter.client.v1.ClientService.GetExporter:output_type -> jumpstarter.client.v1.Exporter
-	4,  // 21: jumpstarter.client.v1.ClientService.ListExporters:output_type -> jumpstarter.client.v1.ListExportersResponse
-	1,  // 22: jumpstarter.client.v1.ClientService.GetLease:output_type -> jumpstarter.client.v1.Lease
-	7,  // 23: jumpstarter.client.v1.ClientService.ListLeases:output_type -> jumpstarter.client.v1.ListLeasesResponse
-	1,  // 24: jumpstarter.client.v1.ClientService.CreateLease:output_type -> jumpstarter.client.v1.Lease
-	1,  // 25: jumpstarter.client.v1.ClientService.UpdateLease:output_type -> jumpstarter.client.v1.Lease
-	16, // 26: jumpstarter.client.v1.ClientService.DeleteLease:output_type -> google.protobuf.Empty
-	20, // [20:27] is the sub-list for method output_type
-	13, // [13:20] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	21, // 0: jumpstarter.client.v1.Exporter.labels:type_name -> jumpstarter.client.v1.Exporter.LabelsEntry
+	22, // 1: jumpstarter.client.v1.Lease.duration:type_name -> google.protobuf.Duration
+	22, // 2: jumpstarter.client.v1.Lease.effective_duration:type_name -> google.protobuf.Duration
+	23, // 3: jumpstarter.client.v1.Lease.begin_time:type_name -> google.protobuf.Timestamp
+	23, // 4: jumpstarter.client.v1.Lease.effective_begin_time:type_name -> google.protobuf.Timestamp
+	23, // 5: jumpstarter.client.v1.Lease.end_time:type_name -> google.protobuf.Timestamp
+	23, // 6: jumpstarter.client.v1.Lease.effective_end_time:type_name -> google.protobuf.Timestamp
+	24, // 7: jumpstarter.client.v1.Lease.conditions:type_name -> jumpstarter.v1.Condition
+	4,  // 8: jumpstarter.client.v1.Lease.members:type_name -> jumpstarter.client.v1.LeaseMember
+	23, // 9: jumpstarter.client.v1.Lease.estimated_begin_time:type_name -> google.protobuf.Timestamp
+	0,  // 10: jumpstarter.client.v1.Lease.exporter_loss_action:type_name -> jumpstarter.client.v1.ExporterLossAction
+	22, // 11: jumpstarter.client.v1.Lease.exporter_loss_grace_period:type_name -> google.protobuf.Duration
+	3,  // 12: jumpstarter.client.v1.Lease.affinity:type_name -> jumpstarter.client.v1.LeaseAffinityTerm
+	3,  // 13: jumpstarter.client.v1.Lease.anti_affinity:type_name -> jumpstarter.client.v1.LeaseAffinityTerm
+	23, // 14: jumpstarter.client.v1.Lease.last_activity_time:type_name -> google.protobuf.Timestamp
+	1,  // 15: jumpstarter.client.v1.ListExportersResponse.exporters:type_name -> jumpstarter.client.v1.Exporter
+	2,  // 16: jumpstarter.client.v1.ListLeasesResponse.leases:type_name -> jumpstarter.client.v1.Lease
+	2,  // 17: jumpstarter.client.v1.CreateLeaseRequest.lease:type_name -> jumpstarter.client.v1.Lease
+	2,  // 18: jumpstarter.client.v1.UpdateLeaseRequest.lease:type_name -> jumpstarter.client.v1.Lease
+	25, // 19: jumpstarter.client.v1.UpdateLeaseRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 20: jumpstarter.client.v1.EvaluateLeaseRequest.lease:type_name -> jumpstarter.client.v1.Lease
+	17, // 21: jumpstarter.client.v1.LeaseEvaluation.exporters:type_name -> jumpstarter.client.v1.ExporterEvaluation
+	23, // 22: jumpstarter.client.v1.GetUsageReportRequest.begin_time:type_name -> google.protobuf.Timestamp
+	23, // 23: jumpstarter.client.v1.GetUsageReportRequest.end_time:type_name -> google.protobuf.Timestamp
+	23, // 24: jumpstarter.client.v1.UsageReport.begin_time:type_name -> google.protobuf.Timestamp
+	23, // 25: jumpstarter.client.v1.UsageReport.end_time:type_name -> google.protobuf.Timestamp
+	20, // 26: jumpstarter.client.v1.UsageReport.exporters:type_name -> jumpstarter.client.v1.Usage
+	20, // 27: jumpstarter.client.v1.UsageReport.clients:type_name -> jumpstarter.client.v1.Usage
+	20, // 28: jumpstarter.client.v1.UsageReport.label_sets:type_name -> jumpstarter.client.v1.Usage
+	22, // 29: jumpstarter.client.v1.Usage.leased_time:type_name -> google.protobuf.Duration
+	5,  // 30: jumpstarter.client.v1.ClientService.GetExporter:input_type -> jumpstarter.client.v1.GetExporterRequest
+	6,  // 31: jumpstarter.client.v1.ClientService.ListExporters:input_type -> jumpstarter.client.v1.ListExportersRequest
+	8,  // 32: jumpstarter.client.v1.ClientService.GetLease:input_type -> jumpstarter.client.v1.GetLeaseRequest
+	9,  // 33: jumpstarter.client.v1.ClientService.ListLeases:input_type -> jumpstarter.client.v1.ListLeasesRequest
+	11, // 34: jumpstarter.client.v1.ClientService.CreateLease:input_type -> jumpstarter.client.v1.CreateLeaseRequest
+	12, // 35: jumpstarter.client.v1.ClientService.UpdateLease:input_type -> jumpstarter.client.v1.UpdateLeaseRequest
+	13, // 36: jumpstarter.client.v1.ClientService.DeleteLease:input_type -> jumpstarter.client.v1.DeleteLeaseRequest
+	14, // 37: jumpstarter.client.v1.ClientService.EvaluateLease:input_type -> jumpstarter.client.v1.EvaluateLeaseRequest
+	15, // 38: jumpstarter.client.v1.ClientService.TransferLease:input_type -> jumpstarter.client.v1.TransferLeaseRequest
+	18, // 39: jumpstarter.client.v1.ClientService.GetUsageReport:input_type -> jumpstarter.client.v1.GetUsageReportRequest
+	1,  // 40: jumpstarter.client.v1.ClientService.GetExporter:output_type -> jumpstarter.client.v1.Exporter
+	7,  // 41: jumpstarter.client.v1.ClientService.ListExporters:output_type -> jumpstarter.client.v1.ListExportersResponse
+	2,  // 42: jumpstarter.client.v1.ClientService.GetLease:output_type -> jumpstarter.client.v1.Lease
+	10, // 43: jumpstarter.client.v1.ClientService.ListLeases:output_type -> jumpstarter.client.v1.ListLeasesResponse
+	2,  // 44: jumpstarter.client.v1.ClientService.CreateLease:output_type -> jumpstarter.client.v1.Lease
+	2,  // 45: jumpstarter.client.v1.ClientService.UpdateLease:output_type -> jumpstarter.client.v1.Lease
+	26, // 46: jumpstarter.client.v1.ClientService.DeleteLease:output_type -> google.protobuf.Empty
+	16, // 47: jumpstarter.client.v1.ClientService.EvaluateLease:output_type -> jumpstarter.client.v1.LeaseEvaluation
+	2,  // 48: jumpstarter.client.v1.ClientService.TransferLease:output_type -> jumpstarter.client.v1.Lease
+	19, // 49: jumpstarter.client.v1.ClientService.GetUsageReport:output_type -> jumpstarter.client.v1.UsageReport
+	40, // [40:50] is the sub-list for method output_type
+	30, // [30:40] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_jumpstarter_client_v1_client_proto_init() }
@@ -859,18 +1755,22 @@ func file_jumpstarter_client_v1_client_proto_init() {
 		return
 	}
 	file_jumpstarter_client_v1_client_proto_msgTypes[1].OneofWrappers = []any{}
+	file_jumpstarter_client_v1_client_proto_msgTypes[16].OneofWrappers = []any{}
+	file_jumpstarter_client_v1_client_proto_msgTypes[17].OneofWrappers = []any{}
+	file_jumpstarter_client_v1_client_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_jumpstarter_client_v1_client_proto_rawDesc), len(file_jumpstarter_client_v1_client_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      1,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_jumpstarter_client_v1_client_proto_goTypes,
 		DependencyIndexes: file_jumpstarter_client_v1_client_proto_depIdxs,
+		EnumInfos:         file_jumpstarter_client_v1_client_proto_enumTypes,
 		MessageInfos:      file_jumpstarter_client_v1_client_proto_msgTypes,
 	}.Build()
 	File_jumpstarter_client_v1_client_proto = out.File
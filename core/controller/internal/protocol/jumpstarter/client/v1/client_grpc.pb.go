@@ -28,13 +28,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ClientService_GetExporter_FullMethodName   = "/jumpstarter.client.v1.ClientService/GetExporter"
-	ClientService_ListExporters_FullMethodName = "/jumpstarter.client.v1.ClientService/ListExporters"
-	ClientService_GetLease_FullMethodName      = "/jumpstarter.client.v1.ClientService/GetLease"
-	ClientService_ListLeases_FullMethodName    = "/jumpstarter.client.v1.ClientService/ListLeases"
-	ClientService_CreateLease_FullMethodName   = "/jumpstarter.client.v1.ClientService/CreateLease"
-	ClientService_UpdateLease_FullMethodName   = "/jumpstarter.client.v1.ClientService/UpdateLease"
-	ClientService_DeleteLease_FullMethodName   = "/jumpstarter.client.v1.ClientService/DeleteLease"
+	ClientService_GetExporter_FullMethodName    = "/jumpstarter.client.v1.ClientService/GetExporter"
+	ClientService_ListExporters_FullMethodName  = "/jumpstarter.client.v1.ClientService/ListExporters"
+	ClientService_GetLease_FullMethodName       = "/jumpstarter.client.v1.ClientService/GetLease"
+	ClientService_ListLeases_FullMethodName     = "/jumpstarter.client.v1.ClientService/ListLeases"
+	ClientService_CreateLease_FullMethodName    = "/jumpstarter.client.v1.ClientService/CreateLease"
+	ClientService_UpdateLease_FullMethodName    = "/jumpstarter.client.v1.ClientService/UpdateLease"
+	ClientService_DeleteLease_FullMethodName    = "/jumpstarter.client.v1.ClientService/DeleteLease"
+	ClientService_EvaluateLease_FullMethodName  = "/jumpstarter.client.v1.ClientService/EvaluateLease"
+	ClientService_TransferLease_FullMethodName  = "/jumpstarter.client.v1.ClientService/TransferLease"
+	ClientService_GetUsageReport_FullMethodName = "/jumpstarter.client.v1.ClientService/GetUsageReport"
 )
 
 // ClientServiceClient is the client API for ClientService service.
@@ -48,6 +51,13 @@ type ClientServiceClient interface {
 	CreateLease(ctx context.Context, in *CreateLeaseRequest, opts ...grpc.CallOption) (*Lease, error)
 	UpdateLease(ctx context.Context, in *UpdateLeaseRequest, opts ...grpc.CallOption) (*Lease, error)
 	DeleteLease(ctx context.Context, in *DeleteLeaseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// runs the exporter selection for a lease without creating it, explaining for each
+	// exporter why it would, or would not, be assigned to the lease
+	EvaluateLease(ctx context.Context, in *EvaluateLeaseRequest, opts ...grpc.CallOption) (*LeaseEvaluation, error)
+	// hands an active lease over to another client of the namespace for the time left on it,
+	// provided the policies matching the other client allow it to lease the exporters
+	TransferLease(ctx context.Context, in *TransferLeaseRequest, opts ...grpc.CallOption) (*Lease, error)
+	GetUsageReport(ctx context.Context, in *GetUsageReportRequest, opts ...grpc.CallOption) (*UsageReport, error)
 }
 
 type clientServiceClient struct {
@@ -128,6 +138,36 @@ func (c *clientServiceClient) DeleteLease(ctx context.Context, in *DeleteLeaseRe
 	return out, nil
 }
 
+func (c *clientServiceClient) EvaluateLease(ctx context.Context, in *EvaluateLeaseRequest, opts ...grpc.CallOption) (*LeaseEvaluation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseEvaluation)
+	err := c.cc.Invoke(ctx, ClientService_EvaluateLease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientServiceClient) TransferLease(ctx context.Context, in *TransferLeaseRequest, opts ...grpc.CallOption) (*Lease, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Lease)
+	err := c.cc.Invoke(ctx, ClientService_TransferLease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientServiceClient) GetUsageReport(ctx context.Context, in *GetUsageReportRequest, opts ...grpc.CallOption) (*UsageReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsageReport)
+	err := c.cc.Invoke(ctx, ClientService_GetUsageReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClientServiceServer is the server API for ClientService service.
 // All implementations must embed UnimplementedClientServiceServer
 // for forward compatibility.
@@ -139,6 +179,13 @@ type ClientServiceServer interface {
 	CreateLease(context.Context, *CreateLeaseRequest) (*Lease, error)
 	UpdateLease(context.Context, *UpdateLeaseRequest) (*Lease, error)
 	DeleteLease(context.Context, *DeleteLeaseRequest) (*emptypb.Empty, error)
+	// runs the exporter selection for a lease without creating it, explaining for each
+	// exporter why it would, or would not, be assigned to the lease
+	EvaluateLease(context.Context, *EvaluateLeaseRequest) (*LeaseEvaluation, error)
+	// hands an active lease over to another client of the namespace for the time left on it,
+	// provided the policies matching the other client allow it to lease the exporters
+	TransferLease(context.Context, *TransferLeaseRequest) (*Lease, error)
+	GetUsageReport(context.Context, *GetUsageReportRequest) (*UsageReport, error)
 	mustEmbedUnimplementedClientServiceServer()
 }
 
@@ -170,6 +217,15 @@ func (UnimplementedClientServiceServer) UpdateLease(context.Context, *UpdateLeas
 func (UnimplementedClientServiceServer) DeleteLease(context.Context, *DeleteLeaseRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteLease not implemented")
 }
+func (UnimplementedClientServiceServer) EvaluateLease(context.Context, *EvaluateLeaseRequest) (*LeaseEvaluation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateLease not implemented")
+}
+func (UnimplementedClientServiceServer) TransferLease(context.Context, *TransferLeaseRequest) (*Lease, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferLease not implemented")
+}
+func (UnimplementedClientServiceServer) GetUsageReport(context.Context, *GetUsageReportRequest) (*UsageReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsageReport not implemented")
+}
 func (UnimplementedClientServiceServer) mustEmbedUnimplementedClientServiceServer() {}
 func (UnimplementedClientServiceServer) testEmbeddedByValue()                       {}
 
@@ -317,6 +373,60 @@ func _ClientService_DeleteLease_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientService_EvaluateLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).EvaluateLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientService_EvaluateLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).EvaluateLease(ctx, req.(*EvaluateLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientService_TransferLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).TransferLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientService_TransferLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).TransferLease(ctx, req.(*TransferLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientService_GetUsageReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).GetUsageReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientService_GetUsageReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).GetUsageReport(ctx, req.(*GetUsageReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClientService_ServiceDesc is the grpc.ServiceDesc for ClientService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -352,6 +462,18 @@ var ClientService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteLease",
 			Handler:    _ClientService_DeleteLease_Handler,
 		},
+		{
+			MethodName: "EvaluateLease",
+			Handler:    _ClientService_EvaluateLease_Handler,
+		},
+		{
+			MethodName: "TransferLease",
+			Handler:    _ClientService_TransferLease_Handler,
+		},
+		{
+			MethodName: "GetUsageReport",
+			Handler:    _ClientService_GetUsageReport_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "jumpstarter/client/v1/client.proto",
@@ -402,10 +402,15 @@ func (*StatusRequest) Descriptor() ([]byte, []int) {
 }
 
 type StatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Leased        bool                   `protobuf:"varint,1,opt,name=leased,proto3" json:"leased,omitempty"`
-	LeaseName     *string                `protobuf:"bytes,2,opt,name=lease_name,json=leaseName,proto3,oneof" json:"lease_name,omitempty"`
-	ClientName    *string                `protobuf:"bytes,3,opt,name=client_name,json=clientName,proto3,oneof" json:"client_name,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Leased     bool                   `protobuf:"varint,1,opt,name=leased,proto3" json:"leased,omitempty"`
+	LeaseName  *string                `protobuf:"bytes,2,opt,name=lease_name,json=leaseName,proto3,oneof" json:"lease_name,omitempty"`
+	ClientName *string                `protobuf:"bytes,3,opt,name=client_name,json=clientName,proto3,oneof" json:"client_name,omitempty"`
+	// human readable explanation of why the previous lease ended, i.e. preempted
+	Message *string `protobuf:"bytes,4,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	// the exporter is expected to reset its devices, and report the outcome with ReportCleanup,
+	// before it is assigned to another lease
+	Cleanup       bool `protobuf:"varint,5,opt,name=cleanup,proto3" json:"cleanup,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -461,16 +466,121 @@ func (x *StatusResponse) GetClientName() string {
 	return ""
 }
 
-type DialRequest struct {
+func (x *StatusResponse) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetCleanup() bool {
+	if x != nil {
+		return x.Cleanup
+	}
+	return false
+}
+
+type ReportCleanupRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// human readable explanation of why the cleanup failed
+	Message       *string `protobuf:"bytes,2,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportCleanupRequest) Reset() {
+	*x = ReportCleanupRequest{}
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportCleanupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportCleanupRequest) ProtoMessage() {}
+
+func (x *ReportCleanupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportCleanupRequest.ProtoReflect.Descriptor instead.
+func (*ReportCleanupRequest) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReportCleanupRequest) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReportCleanupRequest) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+type ReportCleanupResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	LeaseName     string                 `protobuf:"bytes,1,opt,name=lease_name,json=leaseName,proto3" json:"lease_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportCleanupResponse) Reset() {
+	*x = ReportCleanupResponse{}
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportCleanupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportCleanupResponse) ProtoMessage() {}
+
+func (x *ReportCleanupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportCleanupResponse.ProtoReflect.Descriptor instead.
+func (*ReportCleanupResponse) Descriptor() ([]byte, []int) {
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{10}
+}
+
+type DialRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	LeaseName string                 `protobuf:"bytes,1,opt,name=lease_name,json=leaseName,proto3" json:"lease_name,omitempty"`
+	// member of a gang lease to connect to, the first member when unset
+	Member        *string `protobuf:"bytes,2,opt,name=member,proto3,oneof" json:"member,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DialRequest) Reset() {
 	*x = DialRequest{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[9]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -482,7 +592,7 @@ func (x *DialRequest) String() string {
 func (*DialRequest) ProtoMessage() {}
 
 func (x *DialRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[9]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,7 +605,7 @@ func (x *DialRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DialRequest.ProtoReflect.Descriptor instead.
 func (*DialRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{9}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *DialRequest) GetLeaseName() string {
@@ -505,6 +615,13 @@ func (x *DialRequest) GetLeaseName() string {
 	return ""
 }
 
+func (x *DialRequest) GetMember() string {
+	if x != nil && x.Member != nil {
+		return *x.Member
+	}
+	return ""
+}
+
 type DialResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	RouterEndpoint string                 `protobuf:"bytes,1,opt,name=router_endpoint,json=routerEndpoint,proto3" json:"router_endpoint,omitempty"`
@@ -515,7 +632,7 @@ type DialResponse struct {
 
 func (x *DialResponse) Reset() {
 	*x = DialResponse{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[10]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -527,7 +644,7 @@ func (x *DialResponse) String() string {
 func (*DialResponse) ProtoMessage() {}
 
 func (x *DialResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[10]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,7 +657,7 @@ func (x *DialResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DialResponse.ProtoReflect.Descriptor instead.
 func (*DialResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{10}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DialResponse) GetRouterEndpoint() string {
@@ -571,7 +688,7 @@ type AuditStreamRequest struct {
 
 func (x *AuditStreamRequest) Reset() {
 	*x = AuditStreamRequest{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[11]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -583,7 +700,7 @@ func (x *AuditStreamRequest) String() string {
 func (*AuditStreamRequest) ProtoMessage() {}
 
 func (x *AuditStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[11]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -596,7 +713,7 @@ func (x *AuditStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuditStreamRequest.ProtoReflect.Descriptor instead.
 func (*AuditStreamRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{11}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *AuditStreamRequest) GetExporterUuid() string {
@@ -642,7 +759,7 @@ type GetReportResponse struct {
 
 func (x *GetReportResponse) Reset() {
 	*x = GetReportResponse{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[12]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -654,7 +771,7 @@ func (x *GetReportResponse) String() string {
 func (*GetReportResponse) ProtoMessage() {}
 
 func (x *GetReportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[12]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -667,7 +784,7 @@ func (x *GetReportResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetReportResponse.ProtoReflect.Descriptor instead.
 func (*GetReportResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{12}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetReportResponse) GetUuid() string {
@@ -710,7 +827,7 @@ type Endpoint struct {
 
 func (x *Endpoint) Reset() {
 	*x = Endpoint{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[13]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -722,7 +839,7 @@ func (x *Endpoint) String() string {
 func (*Endpoint) ProtoMessage() {}
 
 func (x *Endpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[13]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -735,7 +852,7 @@ func (x *Endpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
 func (*Endpoint) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{13}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Endpoint) GetEndpoint() string {
@@ -777,7 +894,7 @@ type DriverCallRequest struct {
 
 func (x *DriverCallRequest) Reset() {
 	*x = DriverCallRequest{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[14]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -789,7 +906,7 @@ func (x *DriverCallRequest) String() string {
 func (*DriverCallRequest) ProtoMessage() {}
 
 func (x *DriverCallRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[14]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -802,7 +919,7 @@ func (x *DriverCallRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DriverCallRequest.ProtoReflect.Descriptor instead.
 func (*DriverCallRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{14}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DriverCallRequest) GetUuid() string {
@@ -836,7 +953,7 @@ type DriverCallResponse struct {
 
 func (x *DriverCallResponse) Reset() {
 	*x = DriverCallResponse{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[15]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -848,7 +965,7 @@ func (x *DriverCallResponse) String() string {
 func (*DriverCallResponse) ProtoMessage() {}
 
 func (x *DriverCallResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[15]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -861,7 +978,7 @@ func (x *DriverCallResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DriverCallResponse.ProtoReflect.Descriptor instead.
 func (*DriverCallResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{15}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *DriverCallResponse) GetUuid() string {
@@ -889,7 +1006,7 @@ type StreamingDriverCallRequest struct {
 
 func (x *StreamingDriverCallRequest) Reset() {
 	*x = StreamingDriverCallRequest{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[16]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -901,7 +1018,7 @@ func (x *StreamingDriverCallRequest) String() string {
 func (*StreamingDriverCallRequest) ProtoMessage() {}
 
 func (x *StreamingDriverCallRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[16]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -914,7 +1031,7 @@ func (x *StreamingDriverCallRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamingDriverCallRequest.ProtoReflect.Descriptor instead.
 func (*StreamingDriverCallRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{16}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *StreamingDriverCallRequest) GetUuid() string {
@@ -948,7 +1065,7 @@ type StreamingDriverCallResponse struct {
 
 func (x *StreamingDriverCallResponse) Reset() {
 	*x = StreamingDriverCallResponse{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[17]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -960,7 +1077,7 @@ func (x *StreamingDriverCallResponse) String() string {
 func (*StreamingDriverCallResponse) ProtoMessage() {}
 
 func (x *StreamingDriverCallResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[17]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -973,7 +1090,7 @@ func (x *StreamingDriverCallResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamingDriverCallResponse.ProtoReflect.Descriptor instead.
 func (*StreamingDriverCallResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{17}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *StreamingDriverCallResponse) GetUuid() string {
@@ -1001,7 +1118,7 @@ type LogStreamResponse struct {
 
 func (x *LogStreamResponse) Reset() {
 	*x = LogStreamResponse{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[18]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1013,7 +1130,7 @@ func (x *LogStreamResponse) String() string {
 func (*LogStreamResponse) ProtoMessage() {}
 
 func (x *LogStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[18]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1026,7 +1143,7 @@ func (x *LogStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogStreamResponse.ProtoReflect.Descriptor instead.
 func (*LogStreamResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{18}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *LogStreamResponse) GetUuid() string {
@@ -1058,7 +1175,7 @@ type ResetRequest struct {
 
 func (x *ResetRequest) Reset() {
 	*x = ResetRequest{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[19]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1070,7 +1187,7 @@ func (x *ResetRequest) String() string {
 func (*ResetRequest) ProtoMessage() {}
 
 func (x *ResetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[19]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1083,7 +1200,7 @@ func (x *ResetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResetRequest.ProtoReflect.Descriptor instead.
 func (*ResetRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{19}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{21}
 }
 
 type ResetResponse struct {
@@ -1094,7 +1211,7 @@ type ResetResponse struct {
 
 func (x *ResetResponse) Reset() {
 	*x = ResetResponse{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[20]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1106,7 +1223,7 @@ func (x *ResetResponse) String() string {
 func (*ResetResponse) ProtoMessage() {}
 
 func (x *ResetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[20]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1119,7 +1236,7 @@ func (x *ResetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResetResponse.ProtoReflect.Descriptor instead.
 func (*ResetResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{20}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{22}
 }
 
 type GetLeaseRequest struct {
@@ -1131,7 +1248,7 @@ type GetLeaseRequest struct {
 
 func (x *GetLeaseRequest) Reset() {
 	*x = GetLeaseRequest{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[21]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1143,7 +1260,7 @@ func (x *GetLeaseRequest) String() string {
 func (*GetLeaseRequest) ProtoMessage() {}
 
 func (x *GetLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[21]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1156,7 +1273,7 @@ func (x *GetLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLeaseRequest.ProtoReflect.Descriptor instead.
 func (*GetLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{21}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetLeaseRequest) GetName() string {
@@ -1180,7 +1297,7 @@ type GetLeaseResponse struct {
 
 func (x *GetLeaseResponse) Reset() {
 	*x = GetLeaseResponse{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[22]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1192,7 +1309,7 @@ func (x *GetLeaseResponse) String() string {
 func (*GetLeaseResponse) ProtoMessage() {}
 
 func (x *GetLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[22]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1205,7 +1322,7 @@ func (x *GetLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLeaseResponse.ProtoReflect.Descriptor instead.
 func (*GetLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{22}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetLeaseResponse) GetDuration() *durationpb.Duration {
@@ -1260,7 +1377,7 @@ type RequestLeaseRequest struct {
 
 func (x *RequestLeaseRequest) Reset() {
 	*x = RequestLeaseRequest{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[23]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1272,7 +1389,7 @@ func (x *RequestLeaseRequest) String() string {
 func (*RequestLeaseRequest) ProtoMessage() {}
 
 func (x *RequestLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[23]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1285,7 +1402,7 @@ func (x *RequestLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestLeaseRequest.ProtoReflect.Descriptor instead.
 func (*RequestLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{23}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *RequestLeaseRequest) GetDuration() *durationpb.Duration {
@@ -1311,7 +1428,7 @@ type RequestLeaseResponse struct {
 
 func (x *RequestLeaseResponse) Reset() {
 	*x = RequestLeaseResponse{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[24]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1323,7 +1440,7 @@ func (x *RequestLeaseResponse) String() string {
 func (*RequestLeaseResponse) ProtoMessage() {}
 
 func (x *RequestLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[24]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1336,7 +1453,7 @@ func (x *RequestLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestLeaseResponse.ProtoReflect.Descriptor instead.
 func (*RequestLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{24}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *RequestLeaseResponse) GetName() string {
@@ -1355,7 +1472,7 @@ type ReleaseLeaseRequest struct {
 
 func (x *ReleaseLeaseRequest) Reset() {
 	*x = ReleaseLeaseRequest{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[25]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1367,7 +1484,7 @@ func (x *ReleaseLeaseRequest) String() string {
 func (*ReleaseLeaseRequest) ProtoMessage() {}
 
 func (x *ReleaseLeaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[25]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1380,7 +1497,7 @@ func (x *ReleaseLeaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseLeaseRequest.ProtoReflect.Descriptor instead.
 func (*ReleaseLeaseRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{25}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ReleaseLeaseRequest) GetName() string {
@@ -1398,7 +1515,7 @@ type ReleaseLeaseResponse struct {
 
 func (x *ReleaseLeaseResponse) Reset() {
 	*x = ReleaseLeaseResponse{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[26]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1410,7 +1527,7 @@ func (x *ReleaseLeaseResponse) String() string {
 func (*ReleaseLeaseResponse) ProtoMessage() {}
 
 func (x *ReleaseLeaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[26]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1423,7 +1540,7 @@ func (x *ReleaseLeaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseLeaseResponse.ProtoReflect.Descriptor instead.
 func (*ReleaseLeaseResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{26}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{28}
 }
 
 type ListLeasesRequest struct {
@@ -1434,7 +1551,7 @@ type ListLeasesRequest struct {
 
 func (x *ListLeasesRequest) Reset() {
 	*x = ListLeasesRequest{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[27]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1446,7 +1563,7 @@ func (x *ListLeasesRequest) String() string {
 func (*ListLeasesRequest) ProtoMessage() {}
 
 func (x *ListLeasesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[27]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1459,7 +1576,7 @@ func (x *ListLeasesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLeasesRequest.ProtoReflect.Descriptor instead.
 func (*ListLeasesRequest) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{27}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{29}
 }
 
 type ListLeasesResponse struct {
@@ -1471,7 +1588,7 @@ type ListLeasesResponse struct {
 
 func (x *ListLeasesResponse) Reset() {
 	*x = ListLeasesResponse{}
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[28]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1483,7 +1600,7 @@ func (x *ListLeasesResponse) String() string {
 func (*ListLeasesResponse) ProtoMessage() {}
 
 func (x *ListLeasesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[28]
+	mi := &file_jumpstarter_v1_jumpstarter_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1496,7 +1613,7 @@ func (x *ListLeasesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLeasesResponse.ProtoReflect.Descriptor instead.
 func (*ListLeasesResponse) Descriptor() ([]byte, []int) {
-	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{28}
+	return file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ListLeasesResponse) GetNames() []string {
@@ -1537,18 +1654,30 @@ const file_jumpstarter_v1_jumpstarter_proto_rawDesc = "" +
 	"\x0eListenResponse\x12'\n" +
 	"\x0frouter_endpoint\x18\x01 \x01(\tR\x0erouterEndpoint\x12!\n" +
 	"\frouter_token\x18\x02 \x01(\tR\vrouterToken\"\x0f\n" +
-	"\rStatusRequest\"\x91\x01\n" +
+	"\rStatusRequest\"\xd6\x01\n" +
 	"\x0eStatusResponse\x12\x16\n" +
 	"\x06leased\x18\x01 \x01(\bR\x06leased\x12\"\n" +
 	"\n" +
 	"lease_name\x18\x02 \x01(\tH\x00R\tleaseName\x88\x01\x01\x12$\n" +
 	"\vclient_name\x18\x03 \x01(\tH\x01R\n" +
-	"clientName\x88\x01\x01B\r\n" +
+	"clientName\x88\x01\x01\x12\x1d\n" +
+	"\amessage\x18\x04 \x01(\tH\x02R\amessage\x88\x01\x01\x12\x18\n" +
+	"\acleanup\x18\x05 \x01(\bR\acleanupB\r\n" +
 	"\v_lease_nameB\x0e\n" +
-	"\f_client_name\",\n" +
+	"\f_client_nameB\n" +
+	"\n" +
+	"\b_message\"[\n" +
+	"\x14ReportCleanupRequest\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
+	"\amessage\x18\x02 \x01(\tH\x00R\amessage\x88\x01\x01B\n" +
+	"\n" +
+	"\b_message\"\x17\n" +
+	"\x15ReportCleanupResponse\"T\n" +
 	"\vDialRequest\x12\x1d\n" +
 	"\n" +
-	"lease_name\x18\x01 \x01(\tR\tleaseName\"Z\n" +
+	"lease_name\x18\x01 \x01(\tR\tleaseName\x12\x1b\n" +
+	"\x06member\x18\x02 \x01(\tH\x00R\x06member\x88\x01\x01B\t\n" +
+	"\a_member\"Z\n" +
 	"\fDialResponse\x12'\n" +
 	"\x0frouter_endpoint\x18\x01 \x01(\tR\x0erouterEndpoint\x12!\n" +
 	"\frouter_token\x18\x02 \x01(\tR\vrouterToken\"\xa1\x01\n" +
@@ -1615,13 +1744,14 @@ const file_jumpstarter_v1_jumpstarter_proto_rawDesc = "" +
 	"\x14ReleaseLeaseResponse\"\x13\n" +
 	"\x11ListLeasesRequest\"*\n" +
 	"\x12ListLeasesResponse\x12\x14\n" +
-	"\x05names\x18\x01 \x03(\tR\x05names2\xb7\x06\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names2\x95\a\n" +
 	"\x11ControllerService\x12M\n" +
 	"\bRegister\x12\x1f.jumpstarter.v1.RegisterRequest\x1a .jumpstarter.v1.RegisterResponse\x12S\n" +
 	"\n" +
 	"Unregister\x12!.jumpstarter.v1.UnregisterRequest\x1a\".jumpstarter.v1.UnregisterResponse\x12I\n" +
 	"\x06Listen\x12\x1d.jumpstarter.v1.ListenRequest\x1a\x1e.jumpstarter.v1.ListenResponse0\x01\x12I\n" +
-	"\x06Status\x12\x1d.jumpstarter.v1.StatusRequest\x1a\x1e.jumpstarter.v1.StatusResponse0\x01\x12A\n" +
+	"\x06Status\x12\x1d.jumpstarter.v1.StatusRequest\x1a\x1e.jumpstarter.v1.StatusResponse0\x01\x12\\\n" +
+	"\rReportCleanup\x12$.jumpstarter.v1.ReportCleanupRequest\x1a%.jumpstarter.v1.ReportCleanupResponse\x12A\n" +
 	"\x04Dial\x12\x1b.jumpstarter.v1.DialRequest\x1a\x1c.jumpstarter.v1.DialResponse\x12K\n" +
 	"\vAuditStream\x12\".jumpstarter.v1.AuditStreamRequest\x1a\x16.google.protobuf.Empty(\x01\x12M\n" +
 	"\bGetLease\x12\x1f.jumpstarter.v1.GetLeaseRequest\x1a .jumpstarter.v1.GetLeaseResponse\x12Y\n" +
@@ -1650,7 +1780,7 @@ func file_jumpstarter_v1_jumpstarter_proto_rawDescGZIP() []byte {
 	return file_jumpstarter_v1_jumpstarter_proto_rawDescData
 }
 
-var file_jumpstarter_v1_jumpstarter_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_jumpstarter_v1_jumpstarter_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_jumpstarter_v1_jumpstarter_proto_goTypes = []any{
 	(*RegisterRequest)(nil),             // 0: jumpstarter.v1.RegisterRequest
 	(*DriverInstanceReport)(nil),        // 1: jumpstarter.v1.DriverInstanceReport
@@ -1661,86 +1791,90 @@ var file_jumpstarter_v1_jumpstarter_proto_goTypes = []any{
 	(*ListenResponse)(nil),              // 6: jumpstarter.v1.ListenResponse
 	(*StatusRequest)(nil),               // 7: jumpstarter.v1.StatusRequest
 	(*StatusResponse)(nil),              // 8: jumpstarter.v1.StatusResponse
-	(*DialRequest)(nil),                 // 9: jumpstarter.v1.DialRequest
-	(*DialResponse)(nil),                // 10: jumpstarter.v1.DialResponse
-	(*AuditStreamRequest)(nil),          // 11: jumpstarter.v1.AuditStreamRequest
-	(*GetReportResponse)(nil),           // 12: jumpstarter.v1.GetReportResponse
-	(*Endpoint)(nil),                    // 13: jumpstarter.v1.Endpoint
-	(*DriverCallRequest)(nil),           // 14: jumpstarter.v1.DriverCallRequest
-	(*DriverCallResponse)(nil),          // 15: jumpstarter.v1.DriverCallResponse
-	(*StreamingDriverCallRequest)(nil),  // 16: jumpstarter.v1.StreamingDriverCallRequest
-	(*StreamingDriverCallResponse)(nil), // 17: jumpstarter.v1.StreamingDriverCallResponse
-	(*LogStreamResponse)(nil),           // 18: jumpstarter.v1.LogStreamResponse
-	(*ResetRequest)(nil),                // 19: jumpstarter.v1.ResetRequest
-	(*ResetResponse)(nil),               // 20: jumpstarter.v1.ResetResponse
-	(*GetLeaseRequest)(nil),             // 21: jumpstarter.v1.GetLeaseRequest
-	(*GetLeaseResponse)(nil),            // 22: jumpstarter.v1.GetLeaseResponse
-	(*RequestLeaseRequest)(nil),         // 23: jumpstarter.v1.RequestLeaseRequest
-	(*RequestLeaseResponse)(nil),        // 24: jumpstarter.v1.RequestLeaseResponse
-	(*ReleaseLeaseRequest)(nil),         // 25: jumpstarter.v1.ReleaseLeaseRequest
-	(*ReleaseLeaseResponse)(nil),        // 26: jumpstarter.v1.ReleaseLeaseResponse
-	(*ListLeasesRequest)(nil),           // 27: jumpstarter.v1.ListLeasesRequest
-	(*ListLeasesResponse)(nil),          // 28: jumpstarter.v1.ListLeasesResponse
-	nil,                                 // 29: jumpstarter.v1.RegisterRequest.LabelsEntry
-	nil,                                 // 30: jumpstarter.v1.DriverInstanceReport.LabelsEntry
-	nil,                                 // 31: jumpstarter.v1.GetReportResponse.LabelsEntry
-	(*structpb.Value)(nil),              // 32: google.protobuf.Value
-	(*durationpb.Duration)(nil),         // 33: google.protobuf.Duration
-	(*LabelSelector)(nil),               // 34: jumpstarter.v1.LabelSelector
-	(*timestamppb.Timestamp)(nil),       // 35: google.protobuf.Timestamp
-	(*Condition)(nil),                   // 36: jumpstarter.v1.Condition
-	(*emptypb.Empty)(nil),               // 37: google.protobuf.Empty
+	(*ReportCleanupRequest)(nil),        // 9: jumpstarter.v1.ReportCleanupRequest
+	(*ReportCleanupResponse)(nil),       // 10: jumpstarter.v1.ReportCleanupResponse
+	(*DialRequest)(nil),                 // 11: jumpstarter.v1.DialRequest
+	(*DialResponse)(nil),                // 12: jumpstarter.v1.DialResponse
+	(*AuditStreamRequest)(nil),          // 13: jumpstarter.v1.AuditStreamRequest
+	(*GetReportResponse)(nil),           // 14: jumpstarter.v1.GetReportResponse
+	(*Endpoint)(nil),                    // 15: jumpstarter.v1.Endpoint
+	(*DriverCallRequest)(nil),           // 16: jumpstarter.v1.DriverCallRequest
+	(*DriverCallResponse)(nil),          // 17: jumpstarter.v1.DriverCallResponse
+	(*StreamingDriverCallRequest)(nil),  // 18: jumpstarter.v1.StreamingDriverCallRequest
+	(*StreamingDriverCallResponse)(nil), // 19: jumpstarter.v1.StreamingDriverCallResponse
+	(*LogStreamResponse)(nil),           // 20: jumpstarter.v1.LogStreamResponse
+	(*ResetRequest)(nil),                // 21: jumpstarter.v1.ResetRequest
+	(*ResetResponse)(nil),               // 22: jumpstarter.v1.ResetResponse
+	(*GetLeaseRequest)(nil),             // 23: jumpstarter.v1.GetLeaseRequest
+	(*GetLeaseResponse)(nil),            // 24: jumpstarter.v1.GetLeaseResponse
+	(*RequestLeaseRequest)(nil),         // 25: jumpstarter.v1.RequestLeaseRequest
+	(*RequestLeaseResponse)(nil),        // 26: jumpstarter.v1.RequestLeaseResponse
+	(*ReleaseLeaseRequest)(nil),         // 27: jumpstarter.v1.ReleaseLeaseRequest
+	(*ReleaseLeaseResponse)(nil),        // 28: jumpstarter.v1.ReleaseLeaseResponse
+	(*ListLeasesRequest)(nil),           // 29: jumpstarter.v1.ListLeasesRequest
+	(*ListLeasesResponse)(nil),          // 30: jumpstarter.v1.ListLeasesResponse
+	nil,                                 // 31: jumpstarter.v1.RegisterRequest.LabelsEntry
+	nil,                                 // 32: jumpstarter.v1.DriverInstanceReport.LabelsEntry
+	nil,                                 // 33: jumpstarter.v1.GetReportResponse.LabelsEntry
+	(*structpb.Value)(nil),              // 34: google.protobuf.Value
+	(*durationpb.Duration)(nil),         // 35: google.protobuf.Duration
+	(*LabelSelector)(nil),               // 36: jumpstarter.v1.LabelSelector
+	(*timestamppb.Timestamp)(nil),       // 37: google.protobuf.Timestamp
+	(*Condition)(nil),                   // 38: jumpstarter.v1.Condition
+	(*emptypb.Empty)(nil),               // 39: google.protobuf.Empty
 }
 var file_jumpstarter_v1_jumpstarter_proto_depIdxs = []int32{
-	29, // 0: jumpstarter.v1.RegisterRequest.labels:type_name -> jumpstarter.v1.RegisterRequest.LabelsEntry
+	31, // 0: jumpstarter.v1.RegisterRequest.labels:type_name -> jumpstarter.v1.RegisterRequest.LabelsEntry
 	1,  // 1: jumpstarter.v1.RegisterRequest.reports:type_name -> jumpstarter.v1.DriverInstanceReport
-	30, // 2: jumpstarter.v1.DriverInstanceReport.labels:type_name -> jumpstarter.v1.DriverInstanceReport.LabelsEntry
-	31, // 3: jumpstarter.v1.GetReportResponse.labels:type_name -> jumpstarter.v1.GetReportResponse.LabelsEntry
+	32, // 2: jumpstarter.v1.DriverInstanceReport.labels:type_name -> jumpstarter.v1.DriverInstanceReport.LabelsEntry
+	33, // 3: jumpstarter.v1.GetReportResponse.labels:type_name -> jumpstarter.v1.GetReportResponse.LabelsEntry
 	1,  // 4: jumpstarter.v1.GetReportResponse.reports:type_name -> jumpstarter.v1.DriverInstanceReport
-	13, // 5: jumpstarter.v1.GetReportResponse.alternative_endpoints:type_name -> jumpstarter.v1.Endpoint
-	32, // 6: jumpstarter.v1.DriverCallRequest.args:type_name -> google.protobuf.Value
-	32, // 7: jumpstarter.v1.DriverCallResponse.result:type_name -> google.protobuf.Value
-	32, // 8: jumpstarter.v1.StreamingDriverCallRequest.args:type_name -> google.protobuf.Value
-	32, // 9: jumpstarter.v1.StreamingDriverCallResponse.result:type_name -> google.protobuf.Value
-	33, // 10: jumpstarter.v1.GetLeaseResponse.duration:type_name -> google.protobuf.Duration
-	34, // 11: jumpstarter.v1.GetLeaseResponse.selector:type_name -> jumpstarter.v1.LabelSelector
-	35, // 12: jumpstarter.v1.GetLeaseResponse.begin_time:type_name -> google.protobuf.Timestamp
-	35, // 13: jumpstarter.v1.GetLeaseResponse.end_time:type_name -> google.protobuf.Timestamp
-	36, // 14: jumpstarter.v1.GetLeaseResponse.conditions:type_name -> jumpstarter.v1.Condition
-	33, // 15: jumpstarter.v1.RequestLeaseRequest.duration:type_name -> google.protobuf.Duration
-	34, // 16: jumpstarter.v1.RequestLeaseRequest.selector:type_name -> jumpstarter.v1.LabelSelector
+	15, // 5: jumpstarter.v1.GetReportResponse.alternative_endpoints:type_name -> jumpstarter.v1.Endpoint
+	34, // 6: jumpstarter.v1.DriverCallRequest.args:type_name -> google.protobuf.Value
+	34, // 7: jumpstarter.v1.DriverCallResponse.result:type_name -> google.protobuf.Value
+	34, // 8: jumpstarter.v1.StreamingDriverCallRequest.args:type_name -> google.protobuf.Value
+	34, // 9: jumpstarter.v1.StreamingDriverCallResponse.result:type_name -> google.protobuf.Value
+	35, // 10: jumpstarter.v1.GetLeaseResponse.duration:type_name -> google.protobuf.Duration
+	36, // 11: jumpstarter.v1.GetLeaseResponse.selector:type_name -> jumpstarter.v1.LabelSelector
+	37, // 12: jumpstarter.v1.GetLeaseResponse.begin_time:type_name -> google.protobuf.Timestamp
+	37, // 13: jumpstarter.v1.GetLeaseResponse.end_time:type_name -> google.protobuf.Timestamp
+	38, // 14: jumpstarter.v1.GetLeaseResponse.conditions:type_name -> jumpstarter.v1.Condition
+	35, // 15: jumpstarter.v1.RequestLeaseRequest.duration:type_name -> google.protobuf.Duration
+	36, // 16: jumpstarter.v1.RequestLeaseRequest.selector:type_name -> jumpstarter.v1.LabelSelector
 	0,  // 17: jumpstarter.v1.ControllerService.Register:input_type -> jumpstarter.v1.RegisterRequest
 	3,  // 18: jumpstarter.v1.ControllerService.Unregister:input_type -> jumpstarter.v1.UnregisterRequest
 	5,  // 19: jumpstarter.v1.ControllerService.Listen:input_type -> jumpstarter.v1.ListenRequest
 	7,  // 20: jumpstarter.v1.ControllerService.Status:input_type -> jumpstarter.v1.StatusRequest
-	9,  // 21: jumpstarter.v1.ControllerService.Dial:input_type -> jumpstarter.v1.DialRequest
-	11, // 22: jumpstarter.v1.ControllerService.AuditStream:input_type -> jumpstarter.v1.AuditStreamRequest
-	21, // 23: jumpstarter.v1.ControllerService.GetLease:input_type -> jumpstarter.v1.GetLeaseRequest
-	23, // 24: jumpstarter.v1.ControllerService.RequestLease:input_type -> jumpstarter.v1.RequestLeaseRequest
-	25, // 25: jumpstarter.v1.ControllerService.ReleaseLease:input_type -> jumpstarter.v1.ReleaseLeaseRequest
-	27, // 26: jumpstarter.v1.ControllerService.ListLeases:input_type -> jumpstarter.v1.ListLeasesRequest
-	37, // 27: jumpstarter.v1.ExporterService.GetReport:input_type -> google.protobuf.Empty
-	14, // 28: jumpstarter.v1.ExporterService.DriverCall:input_type -> jumpstarter.v1.DriverCallRequest
-	16, // 29: jumpstarter.v1.ExporterService.StreamingDriverCall:input_type -> jumpstarter.v1.StreamingDriverCallRequest
-	37, // 30: jumpstarter.v1.ExporterService.LogStream:input_type -> google.protobuf.Empty
-	19, // 31: jumpstarter.v1.ExporterService.Reset:input_type -> jumpstarter.v1.ResetRequest
-	2,  // 32: jumpstarter.v1.ControllerService.Register:output_type -> jumpstarter.v1.RegisterResponse
-	4,  // 33: jumpstarter.v1.ControllerService.Unregister:output_type -> jumpstarter.v1.UnregisterResponse
-	6,  // 34: jumpstarter.v1.ControllerService.Listen:output_type -> jumpstarter.v1.ListenResponse
-	8,  // 35: jumpstarter.v1.ControllerService.Status:output_type -> jumpstarter.v1.StatusResponse
-	10, // 36: jumpstarter.v1.ControllerService.Dial:output_type -> jumpstarter.v1.DialResponse
-	37, // 37: jumpstarter.v1.ControllerService.AuditStream:output_type -> google.protobuf.Empty
-	22, // 38: jumpstarter.v1.ControllerService.GetLease:output_type -> jumpstarter.v1.GetLeaseResponse
-	24, // 39: jumpstarter.v1.ControllerService.RequestLease:output_type -> jumpstarter.v1.RequestLeaseResponse
-	26, // 40: jumpstarter.v1.ControllerService.ReleaseLease:output_type -> jumpstarter.v1.ReleaseLeaseResponse
-	28, // 41: jumpstarter.v1.ControllerService.ListLeases:output_type -> jumpstarter.v1.ListLeasesResponse
-	12, // 42: jumpstarter.v1.ExporterService.GetReport:output_type -> jumpstarter.v1.GetReportResponse
-	15, // 43: jumpstarter.v1.ExporterService.DriverCall:output_type -> jumpstarter.v1.DriverCallResponse
-	17, // 44: jumpstarter.v1.ExporterService.StreamingDriverCall:output_type -> jumpstarter.v1.StreamingDriverCallResponse
-	18, // 45: jumpstarter.v1.ExporterService.LogStream:output_type -> jumpstarter.v1.LogStreamResponse
-	20, // 46: jumpstarter.v1.ExporterService.Reset:output_type -> jumpstarter.v1.ResetResponse
-	32, // [32:47] is the sub-list for method output_type
-	17, // [17:32] is the sub-list for method input_type
+	9,  // 21: jumpstarter.v1.ControllerService.ReportCleanup:input_type -> jumpstarter.v1.ReportCleanupRequest
+	11, // 22: jumpstarter.v1.ControllerService.Dial:input_type -> jumpstarter.v1.DialRequest
+	13, // 23: jumpstarter.v1.ControllerService.AuditStream:input_type -> jumpstarter.v1.AuditStreamRequest
+	23, // 24: jumpstarter.v1.ControllerService.GetLease:input_type -> jumpstarter.v1.GetLeaseRequest
+	25, // 25: jumpstarter.v1.ControllerService.RequestLease:input_type -> jumpstarter.v1.RequestLeaseRequest
+	27, // 26: jumpstarter.v1.ControllerService.ReleaseLease:input_type -> jumpstarter.v1.ReleaseLeaseRequest
+	29, // 27: jumpstarter.v1.ControllerService.ListLeases:input_type -> jumpstarter.v1.ListLeasesRequest
+	39, // 28: jumpstarter.v1.ExporterService.GetReport:input_type -> google.protobuf.Empty
+	16, // 29: jumpstarter.v1.ExporterService.DriverCall:input_type -> jumpstarter.v1.DriverCallRequest
+	18, // 30: jumpstarter.v1.ExporterService.StreamingDriverCall:input_type -> jumpstarter.v1.StreamingDriverCallRequest
+	39, // 31: jumpstarter.v1.ExporterService.LogStream:input_type -> google.protobuf.Empty
+	21, // 32: jumpstarter.v1.ExporterService.Reset:input_type -> jumpstarter.v1.ResetRequest
+	2,  // 33: jumpstarter.v1.ControllerService.Register:output_type -> jumpstarter.v1.RegisterResponse
+	4,  // 34: jumpstarter.v1.ControllerService.Unregister:output_type -> jumpstarter.v1.UnregisterResponse
+	6,  // 35: jumpstarter.v1.ControllerService.Listen:output_type -> jumpstarter.v1.ListenResponse
+	8,  // 36: jumpstarter.v1.ControllerService.Status:output_type -> jumpstarter.v1.StatusResponse
+	10, // 37: jumpstarter.v1.ControllerService.ReportCleanup:output_type -> jumpstarter.v1.ReportCleanupResponse
+	12, // 38: jumpstarter.v1.ControllerService.Dial:output_type -> jumpstarter.v1.DialResponse
+	39, // 39: jumpstarter.v1.ControllerService.AuditStream:output_type -> google.protobuf.Empty
+	24, // 40: jumpstarter.v1.ControllerService.GetLease:output_type -> jumpstarter.v1.GetLeaseResponse
+	26, // 41: jumpstarter.v1.ControllerService.RequestLease:output_type -> jumpstarter.v1.RequestLeaseResponse
+	28, // 42: jumpstarter.v1.ControllerService.ReleaseLease:output_type -> jumpstarter.v1.ReleaseLeaseResponse
+	30, // 43: jumpstarter.v1.ControllerService.ListLeases:output_type -> jumpstarter.v1.ListLeasesResponse
+	14, // 44: jumpstarter.v1.ExporterService.GetReport:output_type -> jumpstarter.v1.GetReportResponse
+	17, // 45: jumpstarter.v1.ExporterService.DriverCall:output_type -> jumpstarter.v1.DriverCallResponse
+	19, // 46: jumpstarter.v1.ExporterService.StreamingDriverCall:output_type -> jumpstarter.v1.StreamingDriverCallResponse
+	20, // 47: jumpstarter.v1.ExporterService.LogStream:output_type -> jumpstarter.v1.LogStreamResponse
+	22, // 48: jumpstarter.v1.ExporterService.Reset:output_type -> jumpstarter.v1.ResetResponse
+	33, // [33:49] is the sub-list for method output_type
+	17, // [17:33] is the sub-list for method input_type
 	17, // [17:17] is the sub-list for extension type_name
 	17, // [17:17] is the sub-list for extension extendee
 	0,  // [0:17] is the sub-list for field type_name
@@ -1754,14 +1888,16 @@ func file_jumpstarter_v1_jumpstarter_proto_init() {
 	file_jumpstarter_v1_kubernetes_proto_init()
 	file_jumpstarter_v1_jumpstarter_proto_msgTypes[1].OneofWrappers = []any{}
 	file_jumpstarter_v1_jumpstarter_proto_msgTypes[8].OneofWrappers = []any{}
-	file_jumpstarter_v1_jumpstarter_proto_msgTypes[22].OneofWrappers = []any{}
+	file_jumpstarter_v1_jumpstarter_proto_msgTypes[9].OneofWrappers = []any{}
+	file_jumpstarter_v1_jumpstarter_proto_msgTypes[11].OneofWrappers = []any{}
+	file_jumpstarter_v1_jumpstarter_proto_msgTypes[24].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_jumpstarter_v1_jumpstarter_proto_rawDesc), len(file_jumpstarter_v1_jumpstarter_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   32,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
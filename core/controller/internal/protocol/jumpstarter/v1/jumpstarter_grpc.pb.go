@@ -23,16 +23,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ControllerService_Register_FullMethodName     = "/jumpstarter.v1.ControllerService/Register"
-	ControllerService_Unregister_FullMethodName   = "/jumpstarter.v1.ControllerService/Unregister"
-	ControllerService_Listen_FullMethodName       = "/jumpstarter.v1.ControllerService/Listen"
-	ControllerService_Status_FullMethodName       = "/jumpstarter.v1.ControllerService/Status"
-	ControllerService_Dial_FullMethodName         = "/jumpstarter.v1.ControllerService/Dial"
-	ControllerService_AuditStream_FullMethodName  = "/jumpstarter.v1.ControllerService/AuditStream"
-	ControllerService_GetLease_FullMethodName     = "/jumpstarter.v1.ControllerService/GetLease"
-	ControllerService_RequestLease_FullMethodName = "/jumpstarter.v1.ControllerService/RequestLease"
-	ControllerService_ReleaseLease_FullMethodName = "/jumpstarter.v1.ControllerService/ReleaseLease"
-	ControllerService_ListLeases_FullMethodName   = "/jumpstarter.v1.ControllerService/ListLeases"
+	ControllerService_Register_FullMethodName      = "/jumpstarter.v1.ControllerService/Register"
+	ControllerService_Unregister_FullMethodName    = "/jumpstarter.v1.ControllerService/Unregister"
+	ControllerService_Listen_FullMethodName        = "/jumpstarter.v1.ControllerService/Listen"
+	ControllerService_Status_FullMethodName        = "/jumpstarter.v1.ControllerService/Status"
+	ControllerService_ReportCleanup_FullMethodName = "/jumpstarter.v1.ControllerService/ReportCleanup"
+	ControllerService_Dial_FullMethodName          = "/jumpstarter.v1.ControllerService/Dial"
+	ControllerService_AuditStream_FullMethodName   = "/jumpstarter.v1.ControllerService/AuditStream"
+	ControllerService_GetLease_FullMethodName      = "/jumpstarter.v1.ControllerService/GetLease"
+	ControllerService_RequestLease_FullMethodName  = "/jumpstarter.v1.ControllerService/RequestLease"
+	ControllerService_ReleaseLease_FullMethodName  = "/jumpstarter.v1.ControllerService/ReleaseLease"
+	ControllerService_ListLeases_FullMethodName    = "/jumpstarter.v1.ControllerService/ListLeases"
 )
 
 // ControllerServiceClient is the client API for ControllerService service.
@@ -54,6 +55,9 @@ type ControllerServiceClient interface {
 	// Exporter status
 	// Returns lease status for the exporter
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatusResponse], error)
+	// Exporter cleanup
+	// Reports the outcome of the reset requested by the status stream once a lease ends
+	ReportCleanup(ctx context.Context, in *ReportCleanupRequest, opts ...grpc.CallOption) (*ReportCleanupResponse, error)
 	// Client connecting
 	// Returns stream token for connecting to the desired exporter
 	// Leases are checked before token issuance
@@ -137,6 +141,16 @@ func (c *controllerServiceClient) Status(ctx context.Context, in *StatusRequest,
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ControllerService_StatusClient = grpc.ServerStreamingClient[StatusResponse]
 
+func (c *controllerServiceClient) ReportCleanup(ctx context.Context, in *ReportCleanupRequest, opts ...grpc.CallOption) (*ReportCleanupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportCleanupResponse)
+	err := c.cc.Invoke(ctx, ControllerService_ReportCleanup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controllerServiceClient) Dial(ctx context.Context, in *DialRequest, opts ...grpc.CallOption) (*DialResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DialResponse)
@@ -219,6 +233,9 @@ type ControllerServiceServer interface {
 	// Exporter status
 	// Returns lease status for the exporter
 	Status(*StatusRequest, grpc.ServerStreamingServer[StatusResponse]) error
+	// Exporter cleanup
+	// Reports the outcome of the reset requested by the status stream once a lease ends
+	ReportCleanup(context.Context, *ReportCleanupRequest) (*ReportCleanupResponse, error)
 	// Client connecting
 	// Returns stream token for connecting to the desired exporter
 	// Leases are checked before token issuance
@@ -256,6 +273,9 @@ func (UnimplementedControllerServiceServer) Listen(*ListenRequest, grpc.ServerSt
 func (UnimplementedControllerServiceServer) Status(*StatusRequest, grpc.ServerStreamingServer[StatusResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedControllerServiceServer) ReportCleanup(context.Context, *ReportCleanupRequest) (*ReportCleanupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportCleanup not implemented")
+}
 func (UnimplementedControllerServiceServer) Dial(context.Context, *DialRequest) (*DialResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Dial not implemented")
 }
@@ -353,6 +373,24 @@ func _ControllerService_Status_Handler(srv interface{}, stream grpc.ServerStream
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ControllerService_StatusServer = grpc.ServerStreamingServer[StatusResponse]
 
+func _ControllerService_ReportCleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportCleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServiceServer).ReportCleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ControllerService_ReportCleanup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServiceServer).ReportCleanup(ctx, req.(*ReportCleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ControllerService_Dial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DialRequest)
 	if err := dec(in); err != nil {
@@ -465,6 +503,10 @@ var ControllerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Unregister",
 			Handler:    _ControllerService_Unregister_Handler,
 		},
+		{
+			MethodName: "ReportCleanup",
+			Handler:    _ControllerService_ReportCleanup_Handler,
+		},
 		{
 			MethodName: "Dial",
 			Handler:    _ControllerService_Dial_Handler,
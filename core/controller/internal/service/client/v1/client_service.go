@@ -18,7 +18,9 @@ package v1
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
@@ -26,10 +28,16 @@ import (
 	cpb "github.com/the78mole/jumpstarter-mono/core/controller/internal/protocol/jumpstarter/client/v1"
 	"github.com/the78mole/jumpstarter-mono/core/controller/internal/service/auth"
 	"github.com/the78mole/jumpstarter-mono/core/controller/internal/service/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -219,7 +227,25 @@ func (s *ClientService) UpdateLease(ctx context.Context, req *cpb.UpdateLeaseReq
 		return nil, err
 	}
 
+	if jlease.Status.Ended {
+		return nil, status.Error(codes.FailedPrecondition, "UpdateLease: the lease has already ended")
+	}
+
+	if desired.Spec.Duration.Duration <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "UpdateLease: the duration must be positive")
+	}
+
+	if err := controller.ValidateLeaseDuration(ctx, s.Client, &jlease, desired.Spec.Duration.Duration); err != nil {
+		if errors.Is(err, controller.ErrLeaseDurationRejected) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+
 	jlease.Spec.Duration = desired.Spec.Duration
+	if jlease.IsScheduled() {
+		jlease.Spec.EndTime = &metav1.Time{Time: jlease.Spec.BeginTime.Add(desired.Spec.Duration.Duration)}
+	}
 
 	if err := s.Patch(ctx, &jlease, original); err != nil {
 		return nil, err
@@ -258,3 +284,175 @@ func (s *ClientService) DeleteLease(ctx context.Context, req *cpb.DeleteLeaseReq
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *ClientService) EvaluateLease(ctx context.Context, req *cpb.EvaluateLeaseRequest) (*cpb.LeaseEvaluation, error) {
+	namespace, err := utils.ParseNamespaceIdentifier(req.Parent)
+	if err != nil {
+		return nil, err
+	}
+
+	jclient, err := s.AuthClient(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Lease == nil {
+		return nil, status.Error(codes.InvalidArgument, "EvaluateLease: the lease is required")
+	}
+
+	// the lease is left unnamed as it is never created
+	jlease, err := jumpstarterdevv1alpha1.LeaseFromProtobuf(req.Lease, types.NamespacedName{
+		Namespace: namespace,
+	}, corev1.LocalObjectReference{
+		Name: jclient.Name,
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	evaluations, err := controller.EvaluateLease(ctx, s.Client, jlease)
+	if err != nil {
+		if errors.Is(err, controller.ErrLeaseInvalid) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	var result cpb.LeaseEvaluation
+	for _, evaluation := range evaluations {
+		result.Exporters = append(result.Exporters, exporterEvaluationToProtobuf(evaluation))
+	}
+	return &result, nil
+}
+
+func exporterEvaluationToProtobuf(evaluation controller.ExporterEvaluation) *cpb.ExporterEvaluation {
+	result := &cpb.ExporterEvaluation{
+		Exporter: utils.UnparseExporterIdentifier(kclient.ObjectKeyFromObject(&evaluation.Exporter)),
+		Included: evaluation.Included,
+		Reason:   evaluation.Reason,
+		Message:  evaluation.Message,
+	}
+	if evaluation.Member != "" {
+		result.Member = ptr.To(evaluation.Member)
+	}
+	if ae := evaluation.ApprovedExporter; ae != nil {
+		if ae.AccessPolicy != nil {
+			result.AccessPolicy = ptr.To(ae.AccessPolicy.Name)
+			result.PolicyIndex = ptr.To(int32(ae.PolicyIndex))
+			result.Priority = ptr.To(int32(ae.Policy.Priority))
+			result.SpotAccess = ptr.To(ae.Policy.SpotAccess)
+		}
+		if ae.ExistingLease != nil {
+			result.Lease = ptr.To(utils.UnparseLeaseIdentifier(kclient.ObjectKeyFromObject(ae.ExistingLease)))
+		}
+	}
+	return result
+}
+
+func (s *ClientService) TransferLease(ctx context.Context, req *cpb.TransferLeaseRequest) (*cpb.Lease, error) {
+	key, err := utils.ParseLeaseIdentifier(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	clientKey, err := utils.ParseClientIdentifier(req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientKey.Namespace != key.Namespace {
+		return nil, status.Error(codes.InvalidArgument,
+			"TransferLease: the lease can only be transferred to a client of its namespace")
+	}
+
+	jclient, err := s.AuthClient(ctx, key.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var jlease jumpstarterdevv1alpha1.Lease
+	if err := s.Get(ctx, *key, &jlease); err != nil {
+		return nil, err
+	}
+
+	if jlease.Spec.ClientRef.Name != jclient.Name {
+		return nil, fmt.Errorf("TransferLease permission denied")
+	}
+
+	if err := controller.TransferLease(ctx, s.Client, &jlease, clientKey.Name); err != nil {
+		if errors.Is(err, controller.ErrLeaseTransferRejected) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+
+	return jlease.ToProtobuf(), nil
+}
+
+func (s *ClientService) GetUsageReport(ctx context.Context, req *cpb.GetUsageReportRequest) (*cpb.UsageReport, error) {
+	namespace, err := utils.ParseNamespaceIdentifier(req.Parent)
+	if err != nil {
+		return nil, err
+	}
+
+	jclient, err := s.AuthClient(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var begin, end time.Time
+	if req.BeginTime != nil {
+		begin = req.BeginTime.AsTime()
+	}
+	if req.EndTime != nil {
+		end = req.EndTime.AsTime()
+	}
+	if !begin.IsZero() && !end.IsZero() && !begin.Before(end) {
+		return nil, status.Error(codes.InvalidArgument, "GetUsageReport: the begin time must be before the end time")
+	}
+
+	report, err := controller.AccountUsage(ctx, s.Client, namespace, begin, end, req.LabelKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cpb.UsageReport{
+		BeginTime: timestamppb.New(report.BeginTime),
+		EndTime:   timestamppb.New(report.EndTime),
+	}
+	for _, entry := range report.Exporters {
+		usage := usageToProtobuf(entry, utils.UnparseExporterIdentifier(kclient.ObjectKey{
+			Namespace: entry.Namespace,
+			Name:      entry.Name,
+		}))
+		result.Exporters = append(result.Exporters, usage)
+	}
+	for _, entry := range report.Clients {
+		// clients only get to see how much they leased themselves
+		if entry.Name != jclient.Name {
+			continue
+		}
+		usage := usageToProtobuf(entry, utils.UnparseObjectIdentifier(kclient.ObjectKey{
+			Namespace: entry.Namespace,
+			Name:      entry.Name,
+		}, "clients"))
+		result.Clients = append(result.Clients, usage)
+	}
+	for _, entry := range report.LabelSets {
+		result.LabelSets = append(result.LabelSets, usageToProtobuf(entry, entry.Name))
+	}
+
+	return result, nil
+}
+
+func usageToProtobuf(entry controller.UsageEntry, name string) *cpb.Usage {
+	usage := &cpb.Usage{
+		Name:       name,
+		Leases:     int32(entry.Leases),
+		LeasedTime: durationpb.New(entry.LeasedTime),
+	}
+	if entry.Capacity > 0 {
+		usage.Utilization = ptr.To(entry.Utilization())
+	}
+	return usage
+}
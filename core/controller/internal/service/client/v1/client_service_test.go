@@ -0,0 +1,107 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	cpb "github.com/the78mole/jumpstarter-mono/core/controller/internal/protocol/jumpstarter/client/v1"
+	"github.com/the78mole/jumpstarter-mono/core/controller/internal/service/auth"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// clientAuthenticator authenticates every request as the given client
+type clientAuthenticator struct {
+	namespace string
+	name      string
+}
+
+func (a clientAuthenticator) AuthenticateContext(context.Context) (*authenticator.Response, bool, error) {
+	return &authenticator.Response{User: &user.DefaultInfo{Name: a.name}}, true, nil
+}
+
+func (a clientAuthenticator) ContextAttributes(context.Context, user.Info) (authorizer.Attributes, error) {
+	return authorizer.AttributesRecord{Resource: "Client", Namespace: a.namespace, Name: a.name}, nil
+}
+
+// endedLease returns a lease of the client which held the exporter during the last hour
+func endedLease(name string, clientName string, now time.Time) *jumpstarterdevv1alpha1.Lease {
+	return &jumpstarterdevv1alpha1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: jumpstarterdevv1alpha1.LeaseSpec{
+			ClientRef: corev1.LocalObjectReference{Name: clientName},
+		},
+		Status: jumpstarterdevv1alpha1.LeaseStatus{
+			BeginTime:   &metav1.Time{Time: now.Add(-time.Hour)},
+			EndTime:     &metav1.Time{Time: now.Add(-time.Minute)},
+			Ended:       true,
+			ExporterRef: &corev1.LocalObjectReference{Name: "exporter"},
+		},
+	}
+}
+
+func TestGetUsageReportOnlyReportsTheRequestingClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := jumpstarterdevv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	kclient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&jumpstarterdevv1alpha1.Client{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "client-a"}},
+		&jumpstarterdevv1alpha1.Client{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "client-b"}},
+		&jumpstarterdevv1alpha1.Exporter{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "exporter"}},
+		endedLease("lease-a", "client-a", now),
+		endedLease("lease-b", "client-b", now),
+	).Build()
+
+	authn := clientAuthenticator{namespace: "default", name: "client-a"}
+	authz := authorizer.AuthorizerFunc(func(context.Context, authorizer.Attributes) (authorizer.Decision, string, error) {
+		return authorizer.DecisionAllow, "", nil
+	})
+	svc := NewClientService(kclient, *auth.NewAuth(kclient, authn, authz, authn))
+
+	report, err := svc.GetUsageReport(context.Background(), &cpb.GetUsageReportRequest{
+		Parent: "namespaces/default",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Clients) != 1 {
+		t.Fatalf("expected the usage of a single client, got %d", len(report.Clients))
+	}
+	if name := report.Clients[0].Name; name != "namespaces/default/clients/client-a" {
+		t.Errorf("expected the usage of the requesting client, got %s", name)
+	}
+	if report.Clients[0].Leases != 1 {
+		t.Errorf("expected the client to have held a single lease, got %d", report.Clients[0].Leases)
+	}
+	// exporters are still reported with the leases of every client
+	if len(report.Exporters) != 1 || report.Exporters[0].Leases != 2 {
+		t.Errorf("expected the exporter to be reported with both leases, got %v", report.Exporters)
+	}
+}
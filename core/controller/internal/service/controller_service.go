@@ -52,6 +52,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -140,13 +141,13 @@ func (s *ControllerService) Register(ctx context.Context, req *pb.RegisterReques
 	}
 
 	for k := range exporter.Labels {
-		if strings.HasPrefix(k, "jumpstarter.dev/") {
+		if strings.HasPrefix(k, jumpstarterdevv1alpha1.ReservedLabelPrefix) {
 			delete(exporter.Labels, k)
 		}
 	}
 
 	for k, v := range req.Labels {
-		if strings.HasPrefix(k, "jumpstarter.dev/") {
+		if strings.HasPrefix(k, jumpstarterdevv1alpha1.ReservedLabelPrefix) {
 			exporter.Labels[k] = v
 		}
 	}
@@ -247,13 +248,14 @@ func (s *ControllerService) Listen(req *pb.ListenRequest, stream pb.ControllerSe
 		return err
 	}
 
-	if lease.Status.ExporterRef == nil || lease.Status.ExporterRef.Name != exporter.Name {
+	if !lease.HoldsExporter(exporter.Name) {
 		err := fmt.Errorf("permission denied")
 		logger.Error(err, "lease not held by exporter")
 		return err
 	}
 
-	queue, _ := s.listenQueues.LoadOrStore(leaseName, make(chan *pb.ListenResponse, 8))
+	// gang leases hold several exporters, each one listening on its own queue
+	queue, _ := s.listenQueues.LoadOrStore(leaseName+"/"+exporter.Name, make(chan *pb.ListenResponse, 8))
 	for {
 		select {
 		case <-ctx.Done():
@@ -372,6 +374,14 @@ func (s *ControllerService) Status(req *pb.StatusRequest, stream pb.ControllerSe
 					Leased:     leased,
 					LeaseName:  leaseName,
 					ClientName: clientName,
+					// the cleanup is only requested once the exporter has been released
+					Cleanup: !leased && exporter.IsCleaning(),
+				}
+
+				// let the exporter know when the previous lease was taken away from it
+				if lastPbStatusResponse != nil && lastPbStatusResponse.LeaseName != nil &&
+					lastPbStatusResponse.GetLeaseName() != status.GetLeaseName() {
+					status.Message = s.leaseEndedMessage(ctx, exporter.Namespace, lastPbStatusResponse.GetLeaseName())
 				}
 				if proto.Equal(lastPbStatusResponse, &status) {
 					jlog.Verbose(logger, "Not sending status update to exporter, it is the same as the last one")
@@ -391,6 +401,77 @@ func (s *ControllerService) Status(req *pb.StatusRequest, stream pb.ControllerSe
 	}
 }
 
+// ReportCleanup records the outcome of the cleanup requested by the status stream, exporters
+// failing to clean up are put under maintenance
+func (s *ControllerService) ReportCleanup(
+	ctx context.Context,
+	req *pb.ReportCleanupRequest,
+) (
+	*pb.ReportCleanupResponse,
+	error,
+) {
+	logger := log.FromContext(ctx)
+
+	exporter, err := s.authenticateExporter(ctx)
+	if err != nil {
+		logger.Error(err, "unable to authenticate exporter")
+		return nil, err
+	}
+
+	logger = logger.WithValues("exporter", types.NamespacedName{
+		Namespace: exporter.Namespace,
+		Name:      exporter.Name,
+	})
+
+	if !exporter.IsCleaning() {
+		// the cleanup already timed out, or is no longer required
+		logger.Info("Ignoring cleanup report, no cleanup is pending", "success", req.GetSuccess())
+		return &pb.ReportCleanupResponse{}, nil
+	}
+	leaseName := exporter.Status.Cleanup.LeaseRef.Name
+
+	if !req.GetSuccess() {
+		reason := fmt.Sprintf("cleanup after lease %s failed", leaseName)
+		if req.Message != nil {
+			reason += ": " + req.GetMessage()
+		}
+
+		original := client.MergeFrom(exporter.DeepCopy())
+		exporter.FailCleanup(reason)
+		if err := s.Client.Patch(ctx, exporter, original); err != nil {
+			logger.Error(err, "unable to put exporter under maintenance")
+			return nil, status.Errorf(codes.Internal, "unable to put exporter under maintenance: %s", err)
+		}
+	}
+
+	original := client.MergeFrom(exporter.DeepCopy())
+	exporter.Status.Cleanup = nil
+
+	if err := s.Client.Status().Patch(ctx, exporter, original); err != nil {
+		logger.Error(err, "unable to update exporter status")
+		return nil, status.Errorf(codes.Internal, "unable to update exporter status: %s", err)
+	}
+
+	logger.Info("exporter reported its cleanup", "lease", leaseName, "success", req.GetSuccess())
+
+	return &pb.ReportCleanupResponse{}, nil
+}
+
+// leaseEndedMessage returns the reason for a lease to have ended early, or nil if it ended normally
+func (s *ControllerService) leaseEndedMessage(ctx context.Context, namespace string, name string) *string {
+	var lease jumpstarterdevv1alpha1.Lease
+	if err := s.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &lease); err != nil {
+		return nil
+	}
+
+	condition := meta.FindStatusCondition(lease.Status.Conditions, string(jumpstarterdevv1alpha1.LeaseConditionTypePreempted))
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		return nil
+	}
+
+	return &condition.Message
+}
+
 func (s *ControllerService) Dial(ctx context.Context, req *pb.DialRequest) (*pb.DialResponse, error) {
 	logger := log.FromContext(ctx)
 
@@ -433,15 +514,26 @@ func (s *ControllerService) Dial(ctx context.Context, req *pb.DialRequest) (*pb.
 		return nil, err
 	}
 
-	if lease.Status.ExporterRef == nil {
+	if lease.Status.ExporterRef == nil || lease.Status.BeginTime == nil {
 		err := fmt.Errorf("lease not active")
 		logger.Error(err, "unable to get exporter referenced by lease")
 		return nil, err
 	}
 
+	exporterName := lease.Status.ExporterRef.Name
+	if member := req.GetMember(); member != "" {
+		name, ok := lease.GetMemberExporterName(member)
+		if !ok {
+			err := fmt.Errorf("unknown lease member %s", member)
+			logger.Error(err, "unable to get exporter referenced by lease member")
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		exporterName = name
+	}
+
 	var exporter jumpstarterdevv1alpha1.Exporter
 	if err := s.Client.Get(ctx,
-		types.NamespacedName{Namespace: client.Namespace, Name: lease.Status.ExporterRef.Name}, &exporter); err != nil {
+		types.NamespacedName{Namespace: client.Namespace, Name: exporterName}, &exporter); err != nil {
 		logger.Error(err, "unable to get exporter referenced by lease")
 		return nil, err
 	}
@@ -463,14 +555,18 @@ func (s *ControllerService) Dial(ctx context.Context, req *pb.DialRequest) (*pb.
 
 	stream := k8suuid.NewUUID()
 
-	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		Issuer:    "https://jumpstarter.dev/stream",
-		Subject:   string(stream),
-		Audience:  []string{"https://jumpstarter.dev/router"},
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 30)),
-		NotBefore: jwt.NewNumericDate(time.Now()),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		ID:        string(k8suuid.NewUUID()),
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, streamClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "https://jumpstarter.dev/stream",
+			Subject:   string(stream),
+			Audience:  []string{"https://jumpstarter.dev/router"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 30)),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        string(k8suuid.NewUUID()),
+		},
+		Namespace: lease.Namespace,
+		Lease:     lease.Name,
 	}).SignedString([]byte(os.Getenv("ROUTER_KEY")))
 
 	if err != nil {
@@ -483,7 +579,7 @@ func (s *ControllerService) Dial(ctx context.Context, req *pb.DialRequest) (*pb.
 		RouterToken:    token,
 	}
 
-	queue, _ := s.listenQueues.LoadOrStore(leaseName, make(chan *pb.ListenResponse, 8))
+	queue, _ := s.listenQueues.LoadOrStore(leaseName+"/"+exporter.Name, make(chan *pb.ListenResponse, 8))
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -491,6 +587,13 @@ func (s *ControllerService) Dial(ctx context.Context, req *pb.DialRequest) (*pb.
 	}
 
 	logger.Info("Client dial assigned stream", "stream", stream)
+	if err := controller.RecordLeaseActivity(ctx, s.Client, types.NamespacedName{
+		Namespace: lease.Namespace,
+		Name:      lease.Name,
+	}, time.Now()); err != nil {
+		// the dial went through, the lease is only missing an activity record
+		logger.Error(err, "unable to record lease activity")
+	}
 	return &pb.DialResponse{
 		RouterEndpoint: endpoint,
 		RouterToken:    token,
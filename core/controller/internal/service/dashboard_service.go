@@ -19,11 +19,15 @@ package service
 import (
 	"context"
 	"embed"
+	"fmt"
 	"html/template"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	"github.com/the78mole/jumpstarter-mono/core/controller/internal/controller"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -41,7 +45,14 @@ type DashboardService struct {
 func (s *DashboardService) Start(ctx context.Context) error {
 	r := gin.Default()
 
-	r.SetHTMLTemplate(template.Must(template.ParseFS(fs, "templates/*")))
+	r.SetHTMLTemplate(template.Must(template.New("").Funcs(template.FuncMap{
+		"percent": func(entry controller.UsageEntry) string {
+			return fmt.Sprintf("%.1f%%", entry.Utilization()*100)
+		},
+		"hours": func(d time.Duration) string {
+			return fmt.Sprintf("%.1fh", d.Hours())
+		},
+	}).ParseFS(fs, "templates/*")))
 
 	r.GET("/", func(c *gin.Context) {
 		var exporters jumpstarterdevv1alpha1.ExporterList
@@ -56,16 +67,48 @@ func (s *DashboardService) Start(ctx context.Context) error {
 			return
 		}
 
+		var groups jumpstarterdevv1alpha1.ClientGroupList
+		if err := s.List(ctx, &groups); err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+
 		var leases jumpstarterdevv1alpha1.LeaseList
 		if err := s.List(ctx, &leases); err != nil {
 			c.String(http.StatusInternalServerError, err.Error())
 			return
 		}
 
+		// the usage window defaults to the last week, and can be aggregated by exporter
+		// label keys, e.g. /?period=24h&labels=board-type
+		period := controller.DefaultUsagePeriod
+		if raw := c.Query("period"); raw != "" {
+			parsed, err := time.ParseDuration(raw)
+			if err != nil || parsed <= 0 {
+				c.String(http.StatusBadRequest, "invalid period %q", raw)
+				return
+			}
+			period = parsed
+		}
+		var labelKeys []string
+		if raw := c.Query("labels"); raw != "" {
+			labelKeys = strings.Split(raw, ",")
+		}
+
+		now := time.Now()
+		usage, err := controller.AccountUsage(ctx, s.Client, "", now.Add(-period), now, labelKeys)
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+
 		c.HTML(http.StatusOK, "index.html", map[string]interface{}{
 			"Exporters": exporters.Items,
 			"Clients":   clients.Items,
+			"Groups":    groups.Items,
 			"Leases":    leases.Items,
+			"Usage":     usage,
+			"Period":    period,
 		})
 	})
 
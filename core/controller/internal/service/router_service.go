@@ -21,25 +21,62 @@ import (
 	"net"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
 	"github.com/the78mole/jumpstarter-mono/core/controller/internal/authentication"
+	"github.com/the78mole/jumpstarter-mono/core/controller/internal/controller"
 	pb "github.com/the78mole/jumpstarter-mono/core/controller/internal/protocol/jumpstarter/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// leaseActivityInterval is how often the router records the activity of the streams of a lease
+const leaseActivityInterval = 30 * time.Second
+
 // RouterService exposes a gRPC service
 type RouterService struct {
 	pb.UnimplementedRouterServiceServer
 	ServerOption grpc.ServerOption
-	pending      sync.Map
+	// Client is used to record the activity of the streams on their lease, when set
+	Client  client.Client
+	pending sync.Map
+}
+
+// NewRouterScheme returns the scheme of the router client, which holds the jumpstarter resources
+// along with the built-in ones, as the router records the activity of the streams on their lease
+func NewRouterScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(jumpstarterdevv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+// NewRouterClient returns the client the router loads its configuration and records the
+// activity of the streams with
+func NewRouterClient(cfg *rest.Config) (client.Client, error) {
+	return client.New(cfg, client.Options{Scheme: NewRouterScheme()})
+}
+
+// streamClaims are the claims of the tokens authorizing router streams, along with the
+// lease the stream has been dialed for
+type streamClaims struct {
+	jwt.RegisteredClaims
+	Namespace string `json:"namespace,omitempty"`
+	Lease     string `json:"lease,omitempty"`
 }
 
 type streamContext struct {
@@ -47,15 +84,16 @@ type streamContext struct {
 	stream pb.RouterService_StreamServer
 }
 
-func (s *RouterService) authenticate(ctx context.Context) (string, error) {
+func (s *RouterService) authenticate(ctx context.Context) (*streamClaims, error) {
 	token, err := authentication.BearerTokenFromContext(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
+	var claims streamClaims
 	parsed, err := jwt.ParseWithClaims(
 		token,
-		&jwt.RegisteredClaims{},
+		&claims,
 		func(t *jwt.Token) (any, error) { return []byte(os.Getenv("ROUTER_KEY")), nil },
 		jwt.WithIssuer("https://jumpstarter.dev/stream"),
 		jwt.WithAudience("https://jumpstarter.dev/router"),
@@ -69,21 +107,22 @@ func (s *RouterService) authenticate(ctx context.Context) (string, error) {
 	)
 
 	if err != nil || !parsed.Valid {
-		return "", status.Errorf(codes.InvalidArgument, "invalid jwt token")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid jwt token")
 	}
 
-	return parsed.Claims.GetSubject()
+	return &claims, nil
 }
 
 func (s *RouterService) Stream(stream pb.RouterService_StreamServer) error {
 	ctx := stream.Context()
 	logger := log.FromContext(ctx)
 
-	streamName, err := s.authenticate(ctx)
+	claims, err := s.authenticate(ctx)
 	if err != nil {
 		logger.Error(err, "failed to authenticate")
 		return err
 	}
+	streamName := claims.Subject
 
 	logger.Info("streaming", "stream", streamName)
 
@@ -99,7 +138,19 @@ func (s *RouterService) Stream(stream pb.RouterService_StreamServer) error {
 	if loaded {
 		defer actual.(streamContext).cancel()
 		logger.Info("forwarding", "stream", streamName)
-		return Forward(ctx, stream, actual.(streamContext).stream)
+		if s.Client == nil || claims.Lease == "" {
+			return Forward(ctx, stream, actual.(streamContext).stream)
+		}
+		activity := &streamActivity{}
+		activity.Touch()
+		go s.recordLeaseActivity(ctx, types.NamespacedName{
+			Namespace: claims.Namespace,
+			Name:      claims.Lease,
+		}, activity)
+		return Forward(ctx,
+			activityStream{RouterService_StreamServer: stream, activity: activity},
+			activityStream{RouterService_StreamServer: actual.(streamContext).stream, activity: activity},
+		)
 	} else {
 		logger.Info("waiting for the other side", "stream", streamName)
 		<-ctx.Done()
@@ -107,6 +158,37 @@ func (s *RouterService) Stream(stream pb.RouterService_StreamServer) error {
 	}
 }
 
+// recordLeaseActivity records the activity of a stream on its lease until the stream is closed
+func (s *RouterService) recordLeaseActivity(ctx context.Context, key types.NamespacedName, activity *streamActivity) {
+	logger := log.FromContext(ctx).WithValues("lease", key)
+
+	var recorded time.Time
+	record := func() {
+		last := activity.Last()
+		if !last.After(recorded) {
+			return
+		}
+		// the stream context may already be done, the last activity is recorded anyway
+		if err := controller.RecordLeaseActivity(context.WithoutCancel(ctx), s.Client, key, last); err != nil {
+			logger.Error(err, "failed to record lease activity")
+			return
+		}
+		recorded = last
+	}
+
+	ticker := time.NewTicker(leaseActivityInterval)
+	defer ticker.Stop()
+	for {
+		record()
+		select {
+		case <-ctx.Done():
+			record()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (s *RouterService) Start(ctx context.Context) error {
 	log := log.FromContext(ctx)
 
@@ -0,0 +1,58 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRouterServiceRecordsLeaseActivity(t *testing.T) {
+	lease := &jumpstarterdevv1alpha1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lease"},
+	}
+	// the client is built with the scheme of the router binary
+	kclient := fake.NewClientBuilder().WithScheme(NewRouterScheme()).WithObjects(lease).
+		WithStatusSubresource(lease).Build()
+	svc := &RouterService{Client: kclient}
+
+	activity := &streamActivity{}
+	activity.Touch()
+
+	// the activity is recorded once more as the stream is closed
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	key := types.NamespacedName{Namespace: lease.Namespace, Name: lease.Name}
+	svc.recordLeaseActivity(ctx, key, activity)
+
+	var recorded jumpstarterdevv1alpha1.Lease
+	if err := kclient.Get(context.Background(), key, &recorded); err != nil {
+		t.Fatal(err)
+	}
+	if recorded.Status.LastActivityTime == nil {
+		t.Fatalf("the activity of the stream has not been recorded on the lease")
+	}
+	if recorded.Status.LastActivityTime.Unix() != activity.Last().Unix() {
+		t.Errorf("the recorded activity %s does not match the last activity of the stream %s",
+			recorded.Status.LastActivityTime, activity.Last())
+	}
+}
@@ -4,11 +4,40 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync/atomic"
+	"time"
 
 	pb "github.com/the78mole/jumpstarter-mono/core/controller/internal/protocol/jumpstarter/v1"
 	"golang.org/x/sync/errgroup"
 )
 
+// streamActivity is the time of the last frame received on either side of a stream
+type streamActivity struct {
+	last atomic.Int64
+}
+
+func (a *streamActivity) Touch() {
+	a.last.Store(time.Now().UnixNano())
+}
+
+func (a *streamActivity) Last() time.Time {
+	return time.Unix(0, a.last.Load())
+}
+
+// activityStream touches the activity of the stream on each frame received
+type activityStream struct {
+	pb.RouterService_StreamServer
+	activity *streamActivity
+}
+
+func (s activityStream) Recv() (*pb.StreamRequest, error) {
+	msg, err := s.RouterService_StreamServer.Recv()
+	if err == nil {
+		s.activity.Touch()
+	}
+	return msg, err
+}
+
 func pipe(a pb.RouterService_StreamServer, b pb.RouterService_StreamServer) error {
 	for {
 		msg, err := a.Recv()
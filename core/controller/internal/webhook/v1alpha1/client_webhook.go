@@ -0,0 +1,99 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+var clientlog = logf.Log.WithName("client-resource")
+
+// SetupClientWebhookWithManager registers the webhook for Client in the manager.
+func SetupClientWebhookWithManager(mgr ctrl.Manager, controller ControllerIdentity) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&jumpstarterdevv1alpha1.Client{}).
+		WithValidator(&ClientCustomValidator{Controller: controller}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-jumpstarter-dev-v1alpha1-client,mutating=false,failurePolicy=fail,sideEffects=None,groups=jumpstarter.dev,resources=clients,verbs=create;update,versions=v1alpha1,name=vclient-v1alpha1.jumpstarter.dev,admissionReviewVersions=v1
+
+// ClientCustomValidator validates the clients on creation and update
+type ClientCustomValidator struct {
+	Controller ControllerIdentity
+}
+
+var _ webhook.CustomValidator = &ClientCustomValidator{}
+
+// ValidateCreate validates the username of the client and forbids setting the reserved labels
+func (v *ClientCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	jclient, ok := obj.(*jumpstarterdevv1alpha1.Client)
+	if !ok {
+		return nil, fmt.Errorf("expected a Client object but got %T", obj)
+	}
+	clientlog.Info("Validation for Client upon creation", "name", jclient.GetName())
+
+	allErrs, err := validateReservedLabels(ctx, v.Controller, nil, jclient)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, validateClientSpec(jclient)...)
+	return nil, invalid("Client", jclient.Name, allErrs)
+}
+
+// ValidateUpdate validates the username of the client and forbids changing the reserved labels
+func (v *ClientCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	jclient, ok := newObj.(*jumpstarterdevv1alpha1.Client)
+	if !ok {
+		return nil, fmt.Errorf("expected a Client object for the newObj but got %T", newObj)
+	}
+	oldClient, ok := oldObj.(*jumpstarterdevv1alpha1.Client)
+	if !ok {
+		return nil, fmt.Errorf("expected a Client object for the oldObj but got %T", oldObj)
+	}
+	clientlog.Info("Validation for Client upon update", "name", jclient.GetName())
+
+	allErrs, err := validateReservedLabels(ctx, v.Controller, oldClient, jclient)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, validateClientSpec(jclient)...)
+	return nil, invalid("Client", jclient.Name, allErrs)
+}
+
+// ValidateDelete allows deleting any client
+func (v *ClientCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateClientSpec validates the username of the client, which must not be empty when set
+func validateClientSpec(jclient *jumpstarterdevv1alpha1.Client) field.ErrorList {
+	if jclient.Spec.Username != nil && *jclient.Spec.Username == "" {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "username"), "",
+			"must not be empty, unset it to only authenticate with the internal provider")}
+	}
+	return nil
+}
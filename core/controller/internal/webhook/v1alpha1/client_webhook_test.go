@@ -0,0 +1,66 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+var _ = Describe("Client Webhook", func() {
+	var (
+		jclient   *jumpstarterdevv1alpha1.Client
+		validator ClientCustomValidator
+	)
+
+	BeforeEach(func() {
+		jclient = &jumpstarterdevv1alpha1.Client{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "client",
+				Namespace: "default",
+			},
+			Spec: jumpstarterdevv1alpha1.ClientSpec{
+				Username: ptr.To("oidc:developer"),
+			},
+		}
+		validator = ClientCustomValidator{Controller: controllerIdentity}
+	})
+
+	Context("When creating a Client under the Validating Webhook", func() {
+		It("Should admit a valid client", func() {
+			Expect(validator.ValidateCreate(asUser("admin"), jclient)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny an empty username", func() {
+			jclient.Spec.Username = ptr.To("")
+			_, err := validator.ValidateCreate(asUser("admin"), jclient)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.username"))
+		})
+
+		It("Should deny reserved labels", func() {
+			jclient.Labels = map[string]string{"jumpstarter.dev/team": "qa"}
+			_, err := validator.ValidateCreate(asUser("admin"), jclient)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,100 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+var exporterlog = logf.Log.WithName("exporter-resource")
+
+// SetupExporterWebhookWithManager registers the webhook for Exporter in the manager.
+func SetupExporterWebhookWithManager(mgr ctrl.Manager, controller ControllerIdentity) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&jumpstarterdevv1alpha1.Exporter{}).
+		WithValidator(&ExporterCustomValidator{Controller: controller}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-jumpstarter-dev-v1alpha1-exporter,mutating=false,failurePolicy=fail,sideEffects=None,groups=jumpstarter.dev,resources=exporters,verbs=create;update,versions=v1alpha1,name=vexporter-v1alpha1.jumpstarter.dev,admissionReviewVersions=v1
+
+// ExporterCustomValidator validates the exporters on creation and update
+type ExporterCustomValidator struct {
+	Controller ControllerIdentity
+}
+
+var _ webhook.CustomValidator = &ExporterCustomValidator{}
+
+// ValidateCreate forbids setting the reserved labels, which are reported by the exporter itself,
+// and validates the cleanup of the exporter
+func (v *ExporterCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	exporter, ok := obj.(*jumpstarterdevv1alpha1.Exporter)
+	if !ok {
+		return nil, fmt.Errorf("expected an Exporter object but got %T", obj)
+	}
+	exporterlog.Info("Validation for Exporter upon creation", "name", exporter.GetName())
+
+	allErrs, err := validateReservedLabels(ctx, v.Controller, nil, exporter)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, validateExporterSpec(exporter)...)
+	return nil, invalid("Exporter", exporter.Name, allErrs)
+}
+
+// ValidateUpdate forbids changing the reserved labels, which are reported by the exporter itself,
+// and validates the cleanup of the exporter
+func (v *ExporterCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	exporter, ok := newObj.(*jumpstarterdevv1alpha1.Exporter)
+	if !ok {
+		return nil, fmt.Errorf("expected an Exporter object for the newObj but got %T", newObj)
+	}
+	oldExporter, ok := oldObj.(*jumpstarterdevv1alpha1.Exporter)
+	if !ok {
+		return nil, fmt.Errorf("expected an Exporter object for the oldObj but got %T", oldObj)
+	}
+	exporterlog.Info("Validation for Exporter upon update", "name", exporter.GetName())
+
+	allErrs, err := validateReservedLabels(ctx, v.Controller, oldExporter, exporter)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, validateExporterSpec(exporter)...)
+	return nil, invalid("Exporter", exporter.Name, allErrs)
+}
+
+// ValidateDelete allows deleting any exporter
+func (v *ExporterCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateExporterSpec validates the fields of the exporter
+func validateExporterSpec(exporter *jumpstarterdevv1alpha1.Exporter) field.ErrorList {
+	if exporter.Spec.Cleanup == nil {
+		return nil
+	}
+	return validatePositiveDuration(exporter.Spec.Cleanup.Timeout, field.NewPath("spec", "cleanup", "timeout"))
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+var _ = Describe("Exporter Webhook", func() {
+	var (
+		exporter  *jumpstarterdevv1alpha1.Exporter
+		validator ExporterCustomValidator
+	)
+
+	BeforeEach(func() {
+		exporter = &jumpstarterdevv1alpha1.Exporter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "exporter",
+				Namespace: "default",
+				Labels: map[string]string{
+					"dut":                      "a",
+					"jumpstarter.dev/hostname": "rack-1",
+				},
+			},
+		}
+		validator = ExporterCustomValidator{Controller: controllerIdentity}
+	})
+
+	Context("When updating an Exporter under the Validating Webhook", func() {
+		It("Should admit changing the labels that are not reserved", func() {
+			updated := exporter.DeepCopy()
+			updated.Labels["dut"] = "b"
+			updated.Labels["rack"] = "1"
+			Expect(validator.ValidateUpdate(asUser("admin"), exporter, updated)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny adding, changing or removing reserved labels", func() {
+			updated := exporter.DeepCopy()
+			updated.Labels["jumpstarter.dev/board"] = "rpi4"
+			_, err := validator.ValidateUpdate(asUser("admin"), exporter, updated)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(`metadata.labels[jumpstarter.dev/board]`))
+
+			updated = exporter.DeepCopy()
+			updated.Labels["jumpstarter.dev/hostname"] = "rack-2"
+			_, err = validator.ValidateUpdate(asUser("admin"), exporter, updated)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+
+			updated = exporter.DeepCopy()
+			delete(updated.Labels, "jumpstarter.dev/hostname")
+			_, err = validator.ValidateUpdate(asUser("admin"), exporter, updated)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("Should let the controller register the labels reported by the exporter", func() {
+			updated := exporter.DeepCopy()
+			updated.Labels["jumpstarter.dev/hostname"] = "rack-2"
+			Expect(validator.ValidateUpdate(asUser(controllerUsername), exporter, updated)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should let the members of the controller groups register the labels", func() {
+			updated := exporter.DeepCopy()
+			updated.Labels["jumpstarter.dev/hostname"] = "rack-2"
+			memberCtx := admission.NewContextWithRequest(ctx, admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{
+						Username: "system:serviceaccount:jumpstarter-lab:other",
+						Groups:   []string{"system:serviceaccounts", controllerGroup},
+					},
+				},
+			})
+			Expect(validator.ValidateUpdate(memberCtx, exporter, updated)).Error().NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When creating an Exporter under the Validating Webhook", func() {
+		It("Should deny reserved labels", func() {
+			_, err := validator.ValidateCreate(asUser("admin"), exporter)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("Should deny a cleanup without a positive timeout", func() {
+			delete(exporter.Labels, "jumpstarter.dev/hostname")
+			exporter.Spec.Cleanup = &jumpstarterdevv1alpha1.ExporterCleanup{
+				Timeout: &metav1.Duration{},
+			}
+			_, err := validator.ValidateCreate(asUser("admin"), exporter)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.cleanup.timeout"))
+
+			exporter.Spec.Cleanup.Timeout = &metav1.Duration{Duration: time.Minute}
+			Expect(validator.ValidateCreate(asUser("admin"), exporter)).Error().NotTo(HaveOccurred())
+		})
+	})
+})
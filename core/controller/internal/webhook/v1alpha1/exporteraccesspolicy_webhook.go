@@ -0,0 +1,153 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+var exporteraccesspolicylog = logf.Log.WithName("exporteraccesspolicy-resource")
+
+// SetupExporterAccessPolicyWebhookWithManager registers the webhook for ExporterAccessPolicy in the manager.
+func SetupExporterAccessPolicyWebhookWithManager(mgr ctrl.Manager, controller ControllerIdentity) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&jumpstarterdevv1alpha1.ExporterAccessPolicy{}).
+		WithValidator(&ExporterAccessPolicyCustomValidator{Controller: controller}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-jumpstarter-dev-v1alpha1-exporteraccesspolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=jumpstarter.dev,resources=exporteraccesspolicies,verbs=create;update,versions=v1alpha1,name=vexporteraccesspolicy-v1alpha1.jumpstarter.dev,admissionReviewVersions=v1
+
+// ExporterAccessPolicyCustomValidator validates the exporter access policies on creation and update
+type ExporterAccessPolicyCustomValidator struct {
+	Controller ControllerIdentity
+}
+
+var _ webhook.CustomValidator = &ExporterAccessPolicyCustomValidator{}
+
+// ValidateCreate validates the selectors, durations, quotas and time windows of the policies
+func (v *ExporterAccessPolicyCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*jumpstarterdevv1alpha1.ExporterAccessPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an ExporterAccessPolicy object but got %T", obj)
+	}
+	exporteraccesspolicylog.Info("Validation for ExporterAccessPolicy upon creation", "name", policy.GetName())
+
+	allErrs, err := validateReservedLabels(ctx, v.Controller, nil, policy)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, validateExporterAccessPolicySpec(policy)...)
+	return nil, invalid("ExporterAccessPolicy", policy.Name, allErrs)
+}
+
+// ValidateUpdate validates the selectors, durations, quotas and time windows of the policies
+func (v *ExporterAccessPolicyCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	policy, ok := newObj.(*jumpstarterdevv1alpha1.ExporterAccessPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an ExporterAccessPolicy object for the newObj but got %T", newObj)
+	}
+	oldPolicy, ok := oldObj.(*jumpstarterdevv1alpha1.ExporterAccessPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an ExporterAccessPolicy object for the oldObj but got %T", oldObj)
+	}
+	exporteraccesspolicylog.Info("Validation for ExporterAccessPolicy upon update", "name", policy.GetName())
+
+	allErrs, err := validateReservedLabels(ctx, v.Controller, oldPolicy, policy)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, validateExporterAccessPolicySpec(policy)...)
+	return nil, invalid("ExporterAccessPolicy", policy.Name, allErrs)
+}
+
+// ValidateDelete allows deleting any exporter access policy
+func (v *ExporterAccessPolicyCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateExporterAccessPolicySpec validates the fields of the exporter access policy
+func validateExporterAccessPolicySpec(policy *jumpstarterdevv1alpha1.ExporterAccessPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+	spec := field.NewPath("spec")
+	opts := metav1validation.LabelSelectorValidationOptions{}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&policy.Spec.ExporterSelector,
+		opts, spec.Child("exporterSelector"))...)
+
+	for i, p := range policy.Spec.Policies {
+		path := spec.Child("policies").Index(i)
+		for j, from := range p.From {
+			fromPath := path.Child("from").Index(j)
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&from.ClientSelector,
+				opts, fromPath.Child("clientSelector"))...)
+			for k, claim := range from.Claims {
+				if claim.Claim == "" {
+					allErrs = append(allErrs, field.Required(fromPath.Child("claims").Index(k).Child("claim"),
+						"the key of the claim is required"))
+				}
+			}
+			if from.ClientGroupRef != nil && from.ClientGroupRef.Name == "" {
+				allErrs = append(allErrs, field.Required(fromPath.Child("clientGroupRef", "name"),
+					"the name of the client group is required"))
+			}
+		}
+
+		allErrs = append(allErrs, validatePositiveDuration(p.MaximumDuration, path.Child("maximumDuration"))...)
+		allErrs = append(allErrs, validatePositiveDuration(p.DefaultDuration, path.Child("defaultDuration"))...)
+		allErrs = append(allErrs, validatePositiveDuration(p.IdleTimeout, path.Child("idleTimeout"))...)
+		if p.DefaultDuration != nil && p.MaximumDuration != nil &&
+			p.DefaultDuration.Duration > p.MaximumDuration.Duration {
+			allErrs = append(allErrs, field.Invalid(path.Child("defaultDuration"), p.DefaultDuration.String(),
+				"must not exceed maximumDuration"))
+		}
+
+		if p.Quota != nil {
+			quotaPath := path.Child("quota")
+			allErrs = append(allErrs, validatePositiveDuration(p.Quota.MaximumLeasedTime,
+				quotaPath.Child("maximumLeasedTime"))...)
+			allErrs = append(allErrs, validatePositiveDuration(p.Quota.Period, quotaPath.Child("period"))...)
+		}
+
+		if p.TimeWindows != nil {
+			if _, err := p.TimeWindows.Location(); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("timeWindows", "timeZone"),
+					p.TimeWindows.TimeZone, err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validatePositiveDuration validates an optional duration, which must be positive when set
+func validatePositiveDuration(duration *metav1.Duration, path *field.Path) field.ErrorList {
+	if duration != nil && duration.Duration <= 0 {
+		return field.ErrorList{field.Invalid(path, duration.String(), "must be positive")}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+var _ = Describe("ExporterAccessPolicy Webhook", func() {
+	var (
+		policy    *jumpstarterdevv1alpha1.ExporterAccessPolicy
+		validator ExporterAccessPolicyCustomValidator
+	)
+
+	BeforeEach(func() {
+		policy = &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "policy",
+				Namespace: "default",
+			},
+			Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+				ExporterSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"dut": "a"},
+				},
+				Policies: []jumpstarterdevv1alpha1.Policy{{
+					From: []jumpstarterdevv1alpha1.From{{
+						ClientSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"team": "qa"},
+						},
+					}},
+					MaximumDuration: &metav1.Duration{Duration: time.Hour},
+					DefaultDuration: &metav1.Duration{Duration: 30 * time.Minute},
+				}},
+			},
+		}
+		validator = ExporterAccessPolicyCustomValidator{Controller: controllerIdentity}
+	})
+
+	Context("When creating an ExporterAccessPolicy under the Validating Webhook", func() {
+		It("Should admit a valid policy", func() {
+			Expect(validator.ValidateCreate(asUser("admin"), policy)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny an invalid client selector", func() {
+			policy.Spec.Policies[0].From[0].ClientSelector.MatchLabels["team/"] = "qa"
+			_, err := validator.ValidateCreate(asUser("admin"), policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.policies[0].from[0].clientSelector"))
+		})
+
+		It("Should deny a default duration exceeding the maximum duration", func() {
+			policy.Spec.Policies[0].DefaultDuration = &metav1.Duration{Duration: 2 * time.Hour}
+			_, err := validator.ValidateCreate(asUser("admin"), policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("must not exceed maximumDuration"))
+		})
+
+		It("Should deny durations that are not positive", func() {
+			policy.Spec.Policies[0].IdleTimeout = &metav1.Duration{}
+			_, err := validator.ValidateCreate(asUser("admin"), policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.policies[0].idleTimeout"))
+		})
+
+		It("Should deny an unknown time zone", func() {
+			policy.Spec.Policies[0].TimeWindows = &jumpstarterdevv1alpha1.TimeWindows{TimeZone: "Mars/Olympus"}
+			_, err := validator.ValidateCreate(asUser("admin"), policy)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.policies[0].timeWindows.timeZone"))
+		})
+	})
+})
@@ -0,0 +1,226 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+	"github.com/the78mole/jumpstarter-mono/core/controller/internal/controller"
+)
+
+var leaselog = logf.Log.WithName("lease-resource")
+
+// SetupLeaseWebhookWithManager registers the webhooks for Lease in the manager.
+func SetupLeaseWebhookWithManager(mgr ctrl.Manager, controller ControllerIdentity) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&jumpstarterdevv1alpha1.Lease{}).
+		WithValidator(&LeaseCustomValidator{Controller: controller}).
+		WithDefaulter(&LeaseCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-jumpstarter-dev-v1alpha1-lease,mutating=true,failurePolicy=fail,sideEffects=None,groups=jumpstarter.dev,resources=leases,verbs=create,versions=v1alpha1,name=mlease-v1alpha1.jumpstarter.dev,admissionReviewVersions=v1
+
+// LeaseCustomDefaulter sets the duration of the leases not requesting one
+type LeaseCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &LeaseCustomDefaulter{}
+
+// Default sets the duration of the lease to the span of its scheduled window, or to the default
+// duration of the policies matching its client
+func (d *LeaseCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	lease, ok := obj.(*jumpstarterdevv1alpha1.Lease)
+	if !ok {
+		return fmt.Errorf("expected a Lease object but got %T", obj)
+	}
+	leaselog.Info("Defaulting for Lease", "name", lease.GetName())
+
+	if lease.Spec.Duration.Duration != 0 {
+		return nil
+	}
+
+	if lease.Spec.BeginTime != nil && lease.Spec.EndTime != nil {
+		lease.Spec.Duration = metav1.Duration{Duration: lease.Spec.EndTime.Sub(lease.Spec.BeginTime.Time)}
+		return nil
+	}
+
+	duration, err := controller.DefaultLeaseDuration(ctx, d.Client, lease)
+	if apierrors.IsNotFound(err) {
+		// the missing client is reported by the controller
+		return nil
+	} else if err != nil {
+		return err
+	}
+	lease.Spec.Duration = metav1.Duration{Duration: duration}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-jumpstarter-dev-v1alpha1-lease,mutating=false,failurePolicy=fail,sideEffects=None,groups=jumpstarter.dev,resources=leases,verbs=create;update,versions=v1alpha1,name=vlease-v1alpha1.jumpstarter.dev,admissionReviewVersions=v1
+
+// LeaseCustomValidator validates the leases on creation and update
+type LeaseCustomValidator struct {
+	Controller ControllerIdentity
+}
+
+var _ webhook.CustomValidator = &LeaseCustomValidator{}
+
+// ValidateCreate validates the selectors, members, duration and window of the lease
+func (v *LeaseCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	lease, ok := obj.(*jumpstarterdevv1alpha1.Lease)
+	if !ok {
+		return nil, fmt.Errorf("expected a Lease object but got %T", obj)
+	}
+	leaselog.Info("Validation for Lease upon creation", "name", lease.GetName())
+
+	allErrs, err := validateReservedLabels(ctx, v.Controller, nil, lease)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, validateLeaseSpec(lease)...)
+	if lease.Spec.EndTime != nil && !lease.Spec.EndTime.After(time.Now()) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "endTime"),
+			lease.Spec.EndTime.String(), "must be in the future"))
+	}
+	return nil, invalid("Lease", lease.Name, allErrs)
+}
+
+// ValidateUpdate additionally forbids changing the exporters requested by the lease, and anyone
+// but the controller changing its client
+func (v *LeaseCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	lease, ok := newObj.(*jumpstarterdevv1alpha1.Lease)
+	if !ok {
+		return nil, fmt.Errorf("expected a Lease object for the newObj but got %T", newObj)
+	}
+	oldLease, ok := oldObj.(*jumpstarterdevv1alpha1.Lease)
+	if !ok {
+		return nil, fmt.Errorf("expected a Lease object for the oldObj but got %T", oldObj)
+	}
+	leaselog.Info("Validation for Lease upon update", "name", lease.GetName())
+
+	allErrs, err := validateReservedLabels(ctx, v.Controller, oldLease, lease)
+	if err != nil {
+		return nil, err
+	}
+	// leases admitted before the webhooks keep their labels and status updatable
+	if !apiequality.Semantic.DeepEqual(lease.Spec, oldLease.Spec) {
+		allErrs = append(allErrs, validateLeaseSpec(lease)...)
+	}
+
+	spec := field.NewPath("spec")
+	if !apiequality.Semantic.DeepEqual(lease.Spec.ClientRef, oldLease.Spec.ClientRef) {
+		// leases are handed over to another client through the controller only, once checked
+		// against the policies matching the other client
+		byController, err := isController(ctx, v.Controller)
+		if err != nil {
+			return nil, err
+		}
+		if !byController {
+			allErrs = append(allErrs, field.Forbidden(spec.Child("clientRef"), "field is immutable"))
+		}
+	}
+	if !apiequality.Semantic.DeepEqual(lease.Spec.Selector, oldLease.Spec.Selector) {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("selector"), "field is immutable"))
+	}
+	if !apiequality.Semantic.DeepEqual(lease.Spec.DeviceSelectors, oldLease.Spec.DeviceSelectors) {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("deviceSelectors"), "field is immutable"))
+	}
+	if !apiequality.Semantic.DeepEqual(lease.Spec.Members, oldLease.Spec.Members) {
+		allErrs = append(allErrs, field.Forbidden(spec.Child("members"), "field is immutable"))
+	}
+	return nil, invalid("Lease", lease.Name, allErrs)
+}
+
+// ValidateDelete allows deleting any lease
+func (v *LeaseCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateLeaseSpec validates the fields of the lease that hold on creation and update
+func validateLeaseSpec(lease *jumpstarterdevv1alpha1.Lease) field.ErrorList {
+	var allErrs field.ErrorList
+	spec := field.NewPath("spec")
+
+	if lease.Spec.ClientRef.Name == "" {
+		allErrs = append(allErrs, field.Required(spec.Child("clientRef", "name"), "a client is required"))
+	}
+	if lease.Spec.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(spec.Child("duration"),
+			lease.Spec.Duration.String(), "must be positive"))
+	}
+	if lease.Spec.BeginTime != nil && lease.Spec.EndTime != nil &&
+		!lease.Spec.EndTime.After(lease.Spec.BeginTime.Time) {
+		allErrs = append(allErrs, field.Invalid(spec.Child("endTime"),
+			lease.Spec.EndTime.String(), "must be after beginTime"))
+	}
+	if lease.Spec.ExporterLossGracePeriod != nil && lease.Spec.ExporterLossGracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(spec.Child("exporterLossGracePeriod"),
+			lease.Spec.ExporterLossGracePeriod.String(), "must not be negative"))
+	}
+
+	if !lease.IsGang() {
+		allErrs = append(allErrs, validateExporterSelector(&lease.Spec.Selector, spec.Child("selector"))...)
+	}
+	allErrs = append(allErrs, validateDeviceSelectors(lease.Spec.DeviceSelectors, spec.Child("deviceSelectors"))...)
+
+	names := make(map[string]bool)
+	for i, member := range lease.Spec.Members {
+		path := spec.Child("members").Index(i)
+		if member.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("name"), "each member requires a name"))
+		} else if names[member.Name] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), member.Name))
+		}
+		names[member.Name] = true
+		allErrs = append(allErrs, validateExporterSelector(&member.Selector, path.Child("selector"))...)
+		allErrs = append(allErrs, validateDeviceSelectors(member.DeviceSelectors, path.Child("deviceSelectors"))...)
+	}
+	return allErrs
+}
+
+// validateExporterSelector validates a selector for exporters, which must not be empty
+func validateExporterSelector(selector *metav1.LabelSelector, path *field.Path) field.ErrorList {
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		return field.ErrorList{field.Required(path, "a selector is required")}
+	}
+	return metav1validation.ValidateLabelSelector(selector, metav1validation.LabelSelectorValidationOptions{}, path)
+}
+
+// validateDeviceSelectors validates the selectors for the devices reported by exporters
+func validateDeviceSelectors(selectors []metav1.LabelSelector, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i := range selectors {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&selectors[i],
+			metav1validation.LabelSelectorValidationOptions{}, path.Index(i))...)
+	}
+	return allErrs
+}
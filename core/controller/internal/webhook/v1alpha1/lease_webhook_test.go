@@ -0,0 +1,212 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+var _ = Describe("Lease Webhook", func() {
+	var (
+		lease     *jumpstarterdevv1alpha1.Lease
+		validator LeaseCustomValidator
+		defaulter LeaseCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		lease = &jumpstarterdevv1alpha1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "lease",
+				Namespace: "default",
+			},
+			Spec: jumpstarterdevv1alpha1.LeaseSpec{
+				ClientRef: corev1.LocalObjectReference{Name: "client"},
+				Duration:  metav1.Duration{Duration: time.Hour},
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"dut": "a"},
+				},
+			},
+		}
+		validator = LeaseCustomValidator{Controller: controllerIdentity}
+		defaulter = LeaseCustomDefaulter{Client: k8sClient}
+	})
+
+	Context("When creating a Lease under the Validating Webhook", func() {
+		It("Should admit a valid lease", func() {
+			Expect(validator.ValidateCreate(asUser("developer"), lease)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a lease with an empty selector", func() {
+			lease.Spec.Selector = metav1.LabelSelector{}
+			_, err := validator.ValidateCreate(asUser("developer"), lease)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.selector"))
+		})
+
+		It("Should deny a lease with an invalid selector", func() {
+			lease.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{{
+				Key:      "dut",
+				Operator: metav1.LabelSelectorOpIn,
+			}}
+			_, err := validator.ValidateCreate(asUser("developer"), lease)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.selector.matchExpressions[0].values"))
+		})
+
+		It("Should deny a lease without a positive duration", func() {
+			lease.Spec.Duration = metav1.Duration{Duration: -time.Minute}
+			_, err := validator.ValidateCreate(asUser("developer"), lease)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.duration"))
+		})
+
+		It("Should deny a scheduled lease ending before it begins", func() {
+			begin := time.Now().Add(2 * time.Hour)
+			lease.Spec.BeginTime = &metav1.Time{Time: begin}
+			lease.Spec.EndTime = &metav1.Time{Time: begin.Add(-time.Hour)}
+			_, err := validator.ValidateCreate(asUser("developer"), lease)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("must be after beginTime"))
+		})
+
+		It("Should deny a gang lease with duplicate member names", func() {
+			member := jumpstarterdevv1alpha1.LeaseMember{
+				Name:     "board",
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"dut": "a"}},
+				Count:    1,
+			}
+			lease.Spec.Members = []jumpstarterdevv1alpha1.LeaseMember{member, member}
+			_, err := validator.ValidateCreate(asUser("developer"), lease)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.members[1].name"))
+		})
+
+		It("Should deny reserved labels set by anyone but the controller", func() {
+			lease.Labels = map[string]string{
+				string(jumpstarterdevv1alpha1.LeaseLabelEnded): jumpstarterdevv1alpha1.LeaseLabelEndedValue,
+			}
+			_, err := validator.ValidateCreate(asUser("developer"), lease)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("managed by the controller"))
+
+			Expect(validator.ValidateCreate(asUser(controllerUsername), lease)).Error().NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When updating a Lease under the Validating Webhook", func() {
+		It("Should deny changing the selector", func() {
+			updated := lease.DeepCopy()
+			updated.Spec.Selector.MatchLabels["dut"] = "b"
+			_, err := validator.ValidateUpdate(asUser("developer"), lease, updated)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.selector: Forbidden: field is immutable"))
+		})
+
+		It("Should admit releasing and extending the lease", func() {
+			updated := lease.DeepCopy()
+			updated.Spec.Release = true
+			updated.Spec.Duration = metav1.Duration{Duration: 2 * time.Hour}
+			Expect(validator.ValidateUpdate(asUser("developer"), lease, updated)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should let only the controller transfer the lease to another client", func() {
+			updated := lease.DeepCopy()
+			updated.Spec.ClientRef = corev1.LocalObjectReference{Name: "developer"}
+			_, err := validator.ValidateUpdate(asUser("developer"), lease, updated)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.clientRef: Forbidden: field is immutable"))
+
+			Expect(validator.ValidateUpdate(asUser(controllerUsername), lease, updated)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should let the controller mark the lease as ended", func() {
+			updated := lease.DeepCopy()
+			updated.Labels = map[string]string{
+				string(jumpstarterdevv1alpha1.LeaseLabelEnded): jumpstarterdevv1alpha1.LeaseLabelEndedValue,
+			}
+			Expect(validator.ValidateUpdate(asUser(controllerUsername), lease, updated)).Error().NotTo(HaveOccurred())
+
+			_, err := validator.ValidateUpdate(asUser("developer"), updated, lease)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+	})
+
+	Context("When creating a Lease under the Defaulting Webhook", func() {
+		It("Should not change the requested duration", func() {
+			Expect(defaulter.Default(ctx, lease)).To(Succeed())
+			Expect(lease.Spec.Duration.Duration).To(Equal(time.Hour))
+		})
+
+		It("Should default the duration to the scheduled window", func() {
+			begin := time.Now().Add(time.Hour).Truncate(time.Second)
+			lease.Spec.Duration = metav1.Duration{}
+			lease.Spec.BeginTime = &metav1.Time{Time: begin}
+			lease.Spec.EndTime = &metav1.Time{Time: begin.Add(30 * time.Minute)}
+			Expect(defaulter.Default(ctx, lease)).To(Succeed())
+			Expect(lease.Spec.Duration.Duration).To(Equal(30 * time.Minute))
+		})
+
+		It("Should default the duration to the one of the policy matching the client", func() {
+			jclient := &jumpstarterdevv1alpha1.Client{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "client",
+					Namespace: "default",
+					Labels:    map[string]string{"team": "qa"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, jclient)).To(Succeed())
+
+			policy := &jumpstarterdevv1alpha1.ExporterAccessPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "defaults",
+					Namespace: "default",
+				},
+				Spec: jumpstarterdevv1alpha1.ExporterAccessPolicySpec{
+					Policies: []jumpstarterdevv1alpha1.Policy{{
+						Priority: 10,
+						From: []jumpstarterdevv1alpha1.From{{
+							ClientSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "qa"}},
+						}},
+						DefaultDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					}, {
+						From: []jumpstarterdevv1alpha1.From{{
+							ClientSelector: metav1.LabelSelector{},
+						}},
+						DefaultDuration: &metav1.Duration{Duration: 5 * time.Minute},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, jclient)).To(Succeed())
+			})
+
+			lease.Spec.Duration = metav1.Duration{}
+			Expect(defaulter.Default(ctx, lease)).To(Succeed())
+			Expect(lease.Spec.Duration.Duration).To(Equal(15 * time.Minute))
+		})
+	})
+})
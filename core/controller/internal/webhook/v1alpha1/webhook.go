@@ -0,0 +1,139 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	jumpstarterdevv1alpha1 "github.com/the78mole/jumpstarter-mono/core/controller/api/v1alpha1"
+)
+
+// ControllerIdentity are the usernames and groups the controller authenticates as
+type ControllerIdentity struct {
+	// Usernames are the usernames of the controller, e.g. of its service account
+	Usernames []string
+	// Groups are groups only the controller belongs to
+	Groups []string
+}
+
+// Empty returns true if no username nor group identifies the controller
+func (c ControllerIdentity) Empty() bool {
+	return len(c.Usernames) == 0 && len(c.Groups) == 0
+}
+
+// Matches returns true if the user has one of the usernames, or belongs to one of the groups
+func (c ControllerIdentity) Matches(user authenticationv1.UserInfo) bool {
+	return slices.Contains(c.Usernames, user.Username) ||
+		slices.ContainsFunc(user.Groups, func(group string) bool {
+			return slices.Contains(c.Groups, group)
+		})
+}
+
+// SetupWebhooksWithManager registers the webhooks of all the resources in the manager. The
+// controller, authenticated as one of the controller identities, is the only one allowed to
+// manage the reserved labels of the resources.
+func SetupWebhooksWithManager(mgr ctrl.Manager, controller ControllerIdentity) error {
+	if controller.Empty() {
+		return fmt.Errorf("SetupWebhooksWithManager: no username nor group identifies the controller")
+	}
+	if err := SetupLeaseWebhookWithManager(mgr, controller); err != nil {
+		return fmt.Errorf("SetupWebhooksWithManager: %w", err)
+	}
+	if err := SetupExporterWebhookWithManager(mgr, controller); err != nil {
+		return fmt.Errorf("SetupWebhooksWithManager: %w", err)
+	}
+	if err := SetupClientWebhookWithManager(mgr, controller); err != nil {
+		return fmt.Errorf("SetupWebhooksWithManager: %w", err)
+	}
+	if err := SetupExporterAccessPolicyWebhookWithManager(mgr, controller); err != nil {
+		return fmt.Errorf("SetupWebhooksWithManager: %w", err)
+	}
+	return nil
+}
+
+// isController returns true if the admission request has been made by the controller
+func isController(ctx context.Context, controller ControllerIdentity) (bool, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false, apierrors.NewBadRequest(err.Error())
+	}
+	return controller.Matches(req.UserInfo), nil
+}
+
+// validateReservedLabels forbids adding, changing or removing the reserved labels of the object,
+// unless done by the controller, oldObj is nil on creation
+func validateReservedLabels(
+	ctx context.Context,
+	controller ControllerIdentity,
+	oldObj, obj metav1.Object,
+) (field.ErrorList, error) {
+	byController, err := isController(ctx, controller)
+	if err != nil || byController {
+		return nil, err
+	}
+
+	var oldLabels map[string]string
+	if oldObj != nil {
+		oldLabels = oldObj.GetLabels()
+	}
+	labels := obj.GetLabels()
+
+	var keys []string
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	for key := range oldLabels {
+		if _, ok := labels[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var allErrs field.ErrorList
+	path := field.NewPath("metadata", "labels")
+	for _, key := range keys {
+		if !strings.HasPrefix(key, jumpstarterdevv1alpha1.ReservedLabelPrefix) {
+			continue
+		}
+		oldValue, hadKey := oldLabels[key]
+		value, hasKey := labels[key]
+		if hadKey != hasKey || oldValue != value {
+			allErrs = append(allErrs, field.Forbidden(path.Key(key),
+				fmt.Sprintf("labels prefixed with %s are managed by the controller",
+					jumpstarterdevv1alpha1.ReservedLabelPrefix)))
+		}
+	}
+	return allErrs, nil
+}
+
+// invalid returns the errors as an Invalid status error for the object, or nil when there are none
+func invalid(kind, name string, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(jumpstarterdevv1alpha1.GroupVersion.WithKind(kind).GroupKind(), name, allErrs)
+}
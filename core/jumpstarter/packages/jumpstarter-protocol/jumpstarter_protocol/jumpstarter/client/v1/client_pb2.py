@@ -33,7 +33,7 @@ from google.protobuf import timestamp_pb2 as google_dot_protobuf_dot_timestamp__
 from jumpstarter_protocol.jumpstarter.v1 import kubernetes_pb2 as jumpstarter_dot_v1_dot_kubernetes__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\"jumpstarter/client/v1/client.proto\x12\x15jumpstarter.client.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1fjumpstarter/v1/kubernetes.proto\"\xa1\x02\n\x08\x45xporter\x12\x17\n\x04name\x18\x01 \x01(\tB\x03\xe0\x41\x08R\x04name\x12\x43\n\x06labels\x18\x02 \x03(\x0b\x32+.jumpstarter.client.v1.Exporter.LabelsEntryR\x06labels\x12\x1b\n\x06online\x18\x03 \x01(\x08\x42\x03\xe0\x41\x03R\x06online\x1a\x39\n\x0bLabelsEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01:_\xea\x41\\\n\x18jumpstarter.dev/Exporter\x12+namespaces/{namespace}/exporters/{exporter}*\texporters2\x08\x65xporter\"\xed\x06\n\x05Lease\x12\x17\n\x04name\x18\x01 \x01(\tB\x03\xe0\x41\x08R\x04name\x12\"\n\x08selector\x18\x02 \x01(\tB\x06\xe0\x41\x02\xe0\x41\x05R\x08selector\x12:\n\x08\x64uration\x18\x03 \x01(\x0b\x32\x19.google.protobuf.DurationB\x03\xe0\x41\x02R\x08\x64uration\x12M\n\x12\x65\x66\x66\x65\x63tive_duration\x18\x04 \x01(\x0b\x32\x19.google.protobuf.DurationB\x03\xe0\x41\x03R\x11\x65\x66\x66\x65\x63tiveDuration\x12>\n\nbegin_time\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x00R\tbeginTime\x88\x01\x01\x12V\n\x14\x65\x66\x66\x65\x63tive_begin_time\x18\x06 \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x03\xe0\x41\x03H\x01R\x12\x65\x66\x66\x65\x63tiveBeginTime\x88\x01\x01\x12:\n\x08\x65nd_time\x18\x07 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x02R\x07\x65ndTime\x88\x01\x01\x12R\n\x12\x65\x66\x66\x65\x63tive_end_time\x18\x08 \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x03\xe0\x41\x03H\x03R\x10\x65\x66\x66\x65\x63tiveEndTime\x88\x01\x01\x12;\n\x06\x63lient\x18\t \x01(\tB\x1e\xe0\x41\x03\xfa\x41\x18\n\x16jumpstarter.dev/ClientH\x04R\x06\x63lient\x88\x01\x01\x12\x41\n\x08\x65xporter\x18\n \x01(\tB \xe0\x41\x03\xfa\x41\x1a\n\x18jumpstarter.dev/ExporterH\x05R\x08\x65xporter\x88\x01\x01\x12>\n\nconditions\x18\x0b \x03(\x0b\x32\x19.jumpstarter.v1.ConditionB\x03\xe0\x41\x03R\nconditions:P\xea\x41M\n\x15jumpstarter.dev/Lease\x12%namespaces/{namespace}/leases/{lease}*\x06leases2\x05leaseB\r\n\x0b_begin_timeB\x17\n\x15_effective_begin_timeB\x0b\n\t_end_timeB\x15\n\x13_effective_end_timeB\t\n\x07_clientB\x0b\n\t_exporter\"J\n\x12GetExporterRequest\x12\x34\n\x04name\x18\x01 \x01(\tB \xe0\x41\x02\xfa\x41\x1a\n\x18jumpstarter.dev/ExporterR\x04name\"\xb3\x01\n\x14ListExportersRequest\x12\x38\n\x06parent\x18\x01 \x01(\tB \xe0\x41\x02\xfa\x41\x1a\x12\x18jumpstarter.dev/ExporterR\x06parent\x12 \n\tpage_size\x18\x02 \x01(\x05\x42\x03\xe0\x41\x01R\x08pageSize\x12\"\n\npage_token\x18\x03 \x01(\tB\x03\xe0\x41\x01R\tpageToken\x12\x1b\n\x06\x66ilter\x18\x04 \x01(\tB\x03\xe0\x41\x01R\x06\x66ilter\"~\n\x15ListExportersResponse\x12=\n\texporters\x18\x01 \x03(\x0b\x32\x1f.jumpstarter.client.v1.ExporterR\texporters\x12&\n\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"D\n\x0fGetLeaseRequest\x12\x31\n\x04name\x18\x01 \x01(\tB\x1d\xe0\x41\x02\xfa\x41\x17\n\x15jumpstarter.dev/LeaseR\x04name\"\xad\x01\n\x11ListLeasesRequest\x12\x35\n\x06parent\x18\x01 \x01(\tB\x1d\xe0\x41\x02\xfa\x41\x17\x12\x15jumpstarter.dev/LeaseR\x06parent\x12 \n\tpage_size\x18\x02 \x01(\x05\x42\x03\xe0\x41\x01R\x08pageSize\x12\"\n\npage_token\x18\x03 \x01(\tB\x03\xe0\x41\x01R\tpageToken\x12\x1b\n\x06\x66ilter\x18\x04 \x01(\tB\x03\xe0\x41\x01R\x06\x66ilter\"r\n\x12ListLeasesResponse\x12\x34\n\x06leases\x18\x01 \x03(\x0b\x32\x1c.jumpstarter.client.v1.LeaseR\x06leases\x12&\n\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa4\x01\n\x12\x43reateLeaseRequest\x12\x35\n\x06parent\x18\x01 \x01(\tB\x1d\xe0\x41\x02\xfa\x41\x17\x12\x15jumpstarter.dev/LeaseR\x06parent\x12\x1e\n\x08lease_id\x18\x02 \x01(\tB\x03\xe0\x41\x01R\x07leaseId\x12\x37\n\x05lease\x18\x03 \x01(\x0b\x32\x1c.jumpstarter.client.v1.LeaseB\x03\xe0\x41\x02R\x05lease\"\x8f\x01\n\x12UpdateLeaseRequest\x12\x37\n\x05lease\x18\x01 \x01(\x0b\x32\x1c.jumpstarter.client.v1.LeaseB\x03\xe0\x41\x02R\x05lease\x12@\n\x0bupdate_mask\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.FieldMaskB\x03\xe0\x41\x01R\nupdateMask\"G\n\x12\x44\x65leteLeaseRequest\x12\x31\n\x04name\x18\x01 \x01(\tB\x1d\xe0\x41\x02\xfa\x41\x17\n\x15jumpstarter.dev/LeaseR\x04name2\xa7\x08\n\rClientService\x12\x8d\x01\n\x0bGetExporter\x12).jumpstarter.client.v1.GetExporterRequest\x1a\x1f.jumpstarter.client.v1.Exporter\"2\xda\x41\x04name\x82\xd3\xe4\x93\x02%\x12#/v1/{name=namespaces/*/exporters/*}\x12\xa0\x01\n\rListExporters\x12+.jumpstarter.client.v1.ListExportersRequest\x1a,.jumpstarter.client.v1.ListExportersResponse\"4\xda\x41\x06parent\x82\xd3\xe4\x93\x02%\x12#/v1/{parent=namespaces/*}/exporters\x12\x81\x01\n\x08GetLease\x12&.jumpstarter.client.v1.GetLeaseRequest\x1a\x1c.jumpstarter.client.v1.Lease\"/\xda\x41\x04name\x82\xd3\xe4\x93\x02\"\x12 /v1/{name=namespaces/*/leases/*}\x12\x94\x01\n\nListLeases\x12(.jumpstarter.client.v1.ListLeasesRequest\x1a).jumpstarter.client.v1.ListLeasesResponse\"1\xda\x41\x06parent\x82\xd3\xe4\x93\x02\"\x12 /v1/{parent=namespaces/*}/leases\x12\x9f\x01\n\x0b\x43reateLease\x12).jumpstarter.client.v1.CreateLeaseRequest\x1a\x1c.jumpstarter.client.v1.Lease\"G\xda\x41\x15parent,lease,lease_id\x82\xd3\xe4\x93\x02)\" /v1/{parent=namespaces/*}/leases:\x05lease\x12\xa1\x01\n\x0bUpdateLease\x12).jumpstarter.client.v1.UpdateLeaseRequest\x1a\x1c.jumpstarter.client.v1.Lease\"I\xda\x41\x11lease,update_mask\x82\xd3\xe4\x93\x02/2&/v1/{lease.name=namespaces/*/leases/*}:\x05lease\x12\x81\x01\n\x0b\x44\x65leteLease\x12).jumpstarter.client.v1.DeleteLeaseRequest\x1a\x16.google.protobuf.Empty\"/\xda\x41\x04name\x82\xd3\xe4\x93\x02\"* /v1/{name=namespaces/*/leases/*}B\x9e\x01\n\x19\x63om.jumpstarter.client.v1B\x0b\x43lientProtoP\x01\xa2\x02\x03JCX\xaa\x02\x15Jumpstarter.Client.V1\xca\x02\x15Jumpstarter\\Client\\V1\xe2\x02!Jumpstarter\\Client\\V1\\GPBMetadata\xea\x02\x17Jumpstarter::Client::V1b\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\"jumpstarter/client/v1/client.proto\x12\x15jumpstarter.client.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1fjumpstarter/v1/kubernetes.proto\"\xa1\x02\n\x08\x45xporter\x12\x17\n\x04name\x18\x01 \x01(\tB\x03\xe0\x41\x08R\x04name\x12\x43\n\x06labels\x18\x02 \x03(\x0b\x32+.jumpstarter.client.v1.Exporter.LabelsEntryR\x06labels\x12\x1b\n\x06online\x18\x03 \x01(\x08\x42\x03\xe0\x41\x03R\x06online\x1a\x39\n\x0bLabelsEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01:_\xea\x41\\\n\x18jumpstarter.dev/Exporter\x12+namespaces/{namespace}/exporters/{exporter}*\texporters2\x08\x65xporter\"\xae\r\n\x05Lease\x12\x17\n\x04name\x18\x01 \x01(\tB\x03\xe0\x41\x08R\x04name\x12\"\n\x08selector\x18\x02 \x01(\tB\x06\xe0\x41\x02\xe0\x41\x05R\x08selector\x12:\n\x08\x64uration\x18\x03 \x01(\x0b\x32\x19.google.protobuf.DurationB\x03\xe0\x41\x02R\x08\x64uration\x12M\n\x12\x65\x66\x66\x65\x63tive_duration\x18\x04 \x01(\x0b\x32\x19.google.protobuf.DurationB\x03\xe0\x41\x03R\x11\x65\x66\x66\x65\x63tiveDuration\x12>\n\nbegin_time\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x00R\tbeginTime\x88\x01\x01\x12V\n\x14\x65\x66\x66\x65\x63tive_begin_time\x18\x06 \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x03\xe0\x41\x03H\x01R\x12\x65\x66\x66\x65\x63tiveBeginTime\x88\x01\x01\x12:\n\x08\x65nd_time\x18\x07 \x01(\x0b\x32\x1a.google.protobuf.TimestampH\x02R\x07\x65ndTime\x88\x01\x01\x12R\n\x12\x65\x66\x66\x65\x63tive_end_time\x18\x08 \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x03\xe0\x41\x03H\x03R\x10\x65\x66\x66\x65\x63tiveEndTime\x88\x01\x01\x12;\n\x06\x63lient\x18\t \x01(\tB\x1e\xe0\x41\x03\xfa\x41\x18\n\x16jumpstarter.dev/ClientH\x04R\x06\x63lient\x88\x01\x01\x12\x41\n\x08\x65xporter\x18\n \x01(\tB \xe0\x41\x03\xfa\x41\x1a\n\x18jumpstarter.dev/ExporterH\x05R\x08\x65xporter\x88\x01\x01\x12>\n\nconditions\x18\x0b \x03(\x0b\x32\x19.jumpstarter.v1.ConditionB\x03\xe0\x41\x03R\nconditions\x12*\n\x0e\x63lamp_duration\x18\x0c \x01(\x08\x42\x03\xe0\x41\x01R\rclampDuration\x12\x41\n\x07members\x18\r \x03(\x0b\x32\".jumpstarter.client.v1.LeaseMemberB\x03\xe0\x41\x05R\x07members\x12/\n\x0equeue_position\x18\x0e \x01(\x05\x42\x03\xe0\x41\x03H\x06R\rqueuePosition\x88\x01\x01\x12V\n\x14\x65stimated_begin_time\x18\x0f \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x03\xe0\x41\x03H\x07R\x12\x65stimatedBeginTime\x88\x01\x01\x12`\n\x14\x65xporter_loss_action\x18\x10 \x01(\x0e\x32).jumpstarter.client.v1.ExporterLossActionB\x03\xe0\x41\x01R\x12\x65xporterLossAction\x12`\n\x1a\x65xporter_loss_grace_period\x18\x11 \x01(\x0b\x32\x19.google.protobuf.DurationB\x03\xe0\x41\x01H\x08R\x17\x65xporterLossGracePeriod\x88\x01\x01\x12.\n\x10\x64\x65vice_selectors\x18\x12 \x03(\tB\x03\xe0\x41\x05R\x0f\x64\x65viceSelectors\x12I\n\x08\x61\x66\x66inity\x18\x13 \x03(\x0b\x32(.jumpstarter.client.v1.LeaseAffinityTermB\x03\xe0\x41\x05R\x08\x61\x66\x66inity\x12R\n\ranti_affinity\x18\x14 \x03(\x0b\x32(.jumpstarter.client.v1.LeaseAffinityTermB\x03\xe0\x41\x05R\x0c\x61ntiAffinity\x12R\n\x12last_activity_time\x18\x15 \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x03\xe0\x41\x03H\tR\x10lastActivityTime\x88\x01\x01:P\xea\x41M\n\x15jumpstarter.dev/Lease\x12%namespaces/{namespace}/leases/{lease}*\x06leases2\x05leaseB\r\n\x0b_begin_timeB\x17\n\x15_effective_begin_timeB\x0b\n\t_end_timeB\x15\n\x13_effective_end_timeB\t\n\x07_clientB\x0b\n\t_exporterB\x11\n\x0f_queue_positionB\x17\n\x15_estimated_begin_timeB\x1d\n\x1b_exporter_loss_grace_periodB\x15\n\x13_last_activity_time\"r\n\x11LeaseAffinityTerm\x12\x35\n\x06leases\x18\x01 \x03(\tB\x1d\xe0\x41\x02\xfa\x41\x17\n\x15jumpstarter.dev/LeaseR\x06leases\x12&\n\x0ctopology_key\x18\x02 \x01(\tB\x03\xe0\x41\x02R\x0btopologyKey\"\xd2\x01\n\x0bLeaseMember\x12\x17\n\x04name\x18\x01 \x01(\tB\x03\xe0\x41\x02R\x04name\x12\x1f\n\x08selector\x18\x02 \x01(\tB\x03\xe0\x41\x02R\x08selector\x12\x19\n\x05\x63ount\x18\x03 \x01(\x05\x42\x03\xe0\x41\x01R\x05\x63ount\x12>\n\texporters\x18\x04 \x03(\tB \xe0\x41\x03\xfa\x41\x1a\n\x18jumpstarter.dev/ExporterR\texporters\x12.\n\x10\x64\x65vice_selectors\x18\x05 \x03(\tB\x03\xe0\x41\x01R\x0f\x64\x65viceSelectors\"J\n\x12GetExporterRequest\x12\x34\n\x04name\x18\x01 \x01(\tB \xe0\x41\x02\xfa\x41\x1a\n\x18jumpstarter.dev/ExporterR\x04name\"\xb3\x01\n\x14ListExportersRequest\x12\x38\n\x06parent\x18\x01 \x01(\tB \xe0\x41\x02\xfa\x41\x1a\x12\x18jumpstarter.dev/ExporterR\x06parent\x12 \n\tpage_size\x18\x02 \x01(\x05\x42\x03\xe0\x41\x01R\x08pageSize\x12\"\n\npage_token\x18\x03 \x01(\tB\x03\xe0\x41\x01R\tpageToken\x12\x1b\n\x06\x66ilter\x18\x04 \x01(\tB\x03\xe0\x41\x01R\x06\x66ilter\"~\n\x15ListExportersResponse\x12=\n\texporters\x18\x01 \x03(\x0b\x32\x1f.jumpstarter.client.v1.ExporterR\texporters\x12&\n\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"D\n\x0fGetLeaseRequest\x12\x31\n\x04name\x18\x01 \x01(\tB\x1d\xe0\x41\x02\xfa\x41\x17\n\x15jumpstarter.dev/LeaseR\x04name\"\xad\x01\n\x11ListLeasesRequest\x12\x35\n\x06parent\x18\x01 \x01(\tB\x1d\xe0\x41\x02\xfa\x41\x17\x12\x15jumpstarter.dev/LeaseR\x06parent\x12 \n\tpage_size\x18\x02 \x01(\x05\x42\x03\xe0\x41\x01R\x08pageSize\x12\"\n\npage_token\x18\x03 \x01(\tB\x03\xe0\x41\x01R\tpageToken\x12\x1b\n\x06\x66ilter\x18\x04 \x01(\tB\x03\xe0\x41\x01R\x06\x66ilter\"r\n\x12ListLeasesResponse\x12\x34\n\x06leases\x18\x01 \x03(\x0b\x32\x1c.jumpstarter.client.v1.LeaseR\x06leases\x12&\n\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa4\x01\n\x12\x43reateLeaseRequest\x12\x35\n\x06parent\x18\x01 \x01(\tB\x1d\xe0\x41\x02\xfa\x41\x17\x12\x15jumpstarter.dev/LeaseR\x06parent\x12\x1e\n\x08lease_id\x18\x02 \x01(\tB\x03\xe0\x41\x01R\x07leaseId\x12\x37\n\x05lease\x18\x03 \x01(\x0b\x32\x1c.jumpstarter.client.v1.LeaseB\x03\xe0\x41\x02R\x05lease\"\x8f\x01\n\x12UpdateLeaseRequest\x12\x37\n\x05lease\x18\x01 \x01(\x0b\x32\x1c.jumpstarter.client.v1.LeaseB\x03\xe0\x41\x02R\x05lease\x12@\n\x0bupdate_mask\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.FieldMaskB\x03\xe0\x41\x01R\nupdateMask\"G\n\x12\x44\x65leteLeaseRequest\x12\x31\n\x04name\x18\x01 \x01(\tB\x1d\xe0\x41\x02\xfa\x41\x17\n\x15jumpstarter.dev/LeaseR\x04name\"\x86\x01\n\x14\x45valuateLeaseRequest\x12\x35\n\x06parent\x18\x01 \x01(\tB\x1d\xe0\x41\x02\xfa\x41\x17\x12\x15jumpstarter.dev/LeaseR\x06parent\x12\x37\n\x05lease\x18\x02 \x01(\x0b\x32\x1c.jumpstarter.client.v1.LeaseB\x03\xe0\x41\x02R\x05lease\"\x81\x01\n\x14TransferLeaseRequest\x12\x31\n\x04name\x18\x01 \x01(\tB\x1d\xe0\x41\x02\xfa\x41\x17\n\x15jumpstarter.dev/LeaseR\x04name\x12\x36\n\x06\x63lient\x18\x02 \x01(\tB\x1e\xe0\x41\x02\xfa\x41\x18\n\x16jumpstarter.dev/ClientR\x06\x63lient\"Z\n\x0fLeaseEvaluation\x12G\n\texporters\x18\x01 \x03(\x0b\x32).jumpstarter.client.v1.ExporterEvaluationR\texporters\"\xdf\x03\n\x12\x45xporterEvaluation\x12\x39\n\x08\x65xporter\x18\x01 \x01(\tB\x1d\xfa\x41\x1a\n\x18jumpstarter.dev/ExporterR\x08\x65xporter\x12\x1b\n\x06member\x18\x02 \x01(\tH\x00R\x06member\x88\x01\x01\x12\x1a\n\x08included\x18\x03 \x01(\x08R\x08included\x12\x16\n\x06reason\x18\x04 \x01(\tR\x06reason\x12\x18\n\x07message\x18\x05 \x01(\tR\x07message\x12(\n\raccess_policy\x18\x06 \x01(\tH\x01R\x0c\x61\x63\x63\x65ssPolicy\x88\x01\x01\x12&\n\x0cpolicy_index\x18\x07 \x01(\x05H\x02R\x0bpolicyIndex\x88\x01\x01\x12\x1f\n\x08priority\x18\x08 \x01(\x05H\x03R\x08priority\x88\x01\x01\x12$\n\x0bspot_access\x18\t \x01(\x08H\x04R\nspotAccess\x88\x01\x01\x12\x35\n\x05lease\x18\n \x01(\tB\x1a\xfa\x41\x17\n\x15jumpstarter.dev/LeaseH\x05R\x05lease\x88\x01\x01\x42\t\n\x07_memberB\x10\n\x0e_access_policyB\x0f\n\r_policy_indexB\x0b\n\t_priorityB\x0e\n\x0c_spot_accessB\x08\n\x06_lease\"\xfa\x01\n\x15GetUsageReportRequest\x12\x1b\n\x06parent\x18\x01 \x01(\tB\x03\xe0\x41\x02R\x06parent\x12\x43\n\nbegin_time\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x03\xe0\x41\x01H\x00R\tbeginTime\x88\x01\x01\x12?\n\x08\x65nd_time\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.TimestampB\x03\xe0\x41\x01H\x01R\x07\x65ndTime\x88\x01\x01\x12\"\n\nlabel_keys\x18\x04 \x03(\tB\x03\xe0\x41\x01R\tlabelKeysB\r\n\x0b_begin_timeB\x0b\n\t_end_time\"\xb0\x02\n\x0bUsageReport\x12\x39\n\nbegin_time\x18\x01 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\tbeginTime\x12\x35\n\x08\x65nd_time\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\x07\x65ndTime\x12:\n\texporters\x18\x03 \x03(\x0b\x32\x1c.jumpstarter.client.v1.UsageR\texporters\x12\x36\n\x07\x63lients\x18\x04 \x03(\x0b\x32\x1c.jumpstarter.client.v1.UsageR\x07\x63lients\x12;\n\nlabel_sets\x18\x05 \x03(\x0b\x32\x1c.jumpstarter.client.v1.UsageR\tlabelSets\"\xa6\x01\n\x05Usage\x12\x12\n\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n\x06leases\x18\x02 \x01(\x05R\x06leases\x12:\n\x0bleased_time\x18\x03 \x01(\x0b\x32\x19.google.protobuf.DurationR\nleasedTime\x12%\n\x0butilization\x18\x04 \x01(\x01H\x00R\x0butilization\x88\x01\x01\x42\x0e\n\x0c_utilization*\x9b\x01\n\x12\x45xporterLossAction\x12$\n EXPORTER_LOSS_ACTION_UNSPECIFIED\x10\x00\x12\x1d\n\x19\x45XPORTER_LOSS_ACTION_KEEP\x10\x01\x12\x1c\n\x18\x45XPORTER_LOSS_ACTION_END\x10\x02\x12\"\n\x1e\x45XPORTER_LOSS_ACTION_REACQUIRE\x10\x03\x32\x8f\x0c\n\rClientService\x12\x8d\x01\n\x0bGetExporter\x12).jumpstarter.client.v1.GetExporterRequest\x1a\x1f.jumpstarter.client.v1.Exporter\"2\xda\x41\x04name\x82\xd3\xe4\x93\x02%\x12#/v1/{name=namespaces/*/exporters/*}\x12\xa0\x01\n\rListExporters\x12+.jumpstarter.client.v1.ListExportersRequest\x1a,.jumpstarter.client.v1.ListExportersResponse\"4\xda\x41\x06parent\x82\xd3\xe4\x93\x02%\x12#/v1/{parent=namespaces/*}/exporters\x12\x81\x01\n\x08GetLease\x12&.jumpstarter.client.v1.GetLeaseRequest\x1a\x1c.jumpstarter.client.v1.Lease\"/\xda\x41\x04name\x82\xd3\xe4\x93\x02\"\x12 /v1/{name=namespaces/*/leases/*}\x12\x94\x01\n\nListLeases\x12(.jumpstarter.client.v1.ListLeasesRequest\x1a).jumpstarter.client.v1.ListLeasesResponse\"1\xda\x41\x06parent\x82\xd3\xe4\x93\x02\"\x12 /v1/{parent=namespaces/*}/leases\x12\x9f\x01\n\x0b\x43reateLease\x12).jumpstarter.client.v1.CreateLeaseRequest\x1a\x1c.jumpstarter.client.v1.Lease\"G\xda\x41\x15parent,lease,lease_id\x82\xd3\xe4\x93\x02)\" /v1/{parent=namespaces/*}/leases:\x05lease\x12\xa1\x01\n\x0bUpdateLease\x12).jumpstarter.client.v1.UpdateLeaseRequest\x1a\x1c.jumpstarter.client.v1.Lease\"I\xda\x41\x11lease,update_mask\x82\xd3\xe4\x93\x02/2&/v1/{lease.name=namespaces/*/leases/*}:\x05lease\x12\x81\x01\n\x0b\x44\x65leteLease\x12).jumpstarter.client.v1.DeleteLeaseRequest\x1a\x16.google.protobuf.Empty\"/\xda\x41\x04name\x82\xd3\xe4\x93\x02\"* /v1/{name=namespaces/*/leases/*}\x12\xad\x01\n\rEvaluateLease\x12+.jumpstarter.client.v1.EvaluateLeaseRequest\x1a&.jumpstarter.client.v1.LeaseEvaluation\"G\xda\x41\x0cparent,lease\x82\xd3\xe4\x93\x02\x32\")/v1/{parent=namespaces/*}/leases:evaluate:\x05lease\x12\x9e\x01\n\rTransferLease\x12+.jumpstarter.client.v1.TransferLeaseRequest\x1a\x1c.jumpstarter.client.v1.Lease\"B\xda\x41\x0bname,client\x82\xd3\xe4\x93\x02.\")/v1/{name=namespaces/*/leases/*}:transfer:\x01*\x12\x94\x01\n\x0eGetUsageReport\x12,.jumpstarter.client.v1.GetUsageReportRequest\x1a\".jumpstarter.client.v1.UsageReport\"0\xda\x41\x06parent\x82\xd3\xe4\x93\x02!\x12\x1f/v1/{parent=namespaces/*}/usageB\x9e\x01\n\x19\x63om.jumpstarter.client.v1B\x0b\x43lientProtoP\x01\xa2\x02\x03JCX\xaa\x02\x15Jumpstarter.Client.V1\xca\x02\x15Jumpstarter\\Client\\V1\xe2\x02!Jumpstarter\\Client\\V1\\GPBMetadata\xea\x02\x17Jumpstarter::Client::V1b\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -67,8 +67,42 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_LEASE'].fields_by_name['exporter']._serialized_options = b'\340A\003\372A\032\n\030jumpstarter.dev/Exporter'
   _globals['_LEASE'].fields_by_name['conditions']._loaded_options = None
   _globals['_LEASE'].fields_by_name['conditions']._serialized_options = b'\340A\003'
+  _globals['_LEASE'].fields_by_name['clamp_duration']._loaded_options = None
+  _globals['_LEASE'].fields_by_name['clamp_duration']._serialized_options = b'\340A\001'
+  _globals['_LEASE'].fields_by_name['members']._loaded_options = None
+  _globals['_LEASE'].fields_by_name['members']._serialized_options = b'\340A\005'
+  _globals['_LEASE'].fields_by_name['queue_position']._loaded_options = None
+  _globals['_LEASE'].fields_by_name['queue_position']._serialized_options = b'\340A\003'
+  _globals['_LEASE'].fields_by_name['estimated_begin_time']._loaded_options = None
+  _globals['_LEASE'].fields_by_name['estimated_begin_time']._serialized_options = b'\340A\003'
+  _globals['_LEASE'].fields_by_name['exporter_loss_action']._loaded_options = None
+  _globals['_LEASE'].fields_by_name['exporter_loss_action']._serialized_options = b'\340A\001'
+  _globals['_LEASE'].fields_by_name['exporter_loss_grace_period']._loaded_options = None
+  _globals['_LEASE'].fields_by_name['exporter_loss_grace_period']._serialized_options = b'\340A\001'
+  _globals['_LEASE'].fields_by_name['device_selectors']._loaded_options = None
+  _globals['_LEASE'].fields_by_name['device_selectors']._serialized_options = b'\340A\005'
+  _globals['_LEASE'].fields_by_name['affinity']._loaded_options = None
+  _globals['_LEASE'].fields_by_name['affinity']._serialized_options = b'\340A\005'
+  _globals['_LEASE'].fields_by_name['anti_affinity']._loaded_options = None
+  _globals['_LEASE'].fields_by_name['anti_affinity']._serialized_options = b'\340A\005'
+  _globals['_LEASE'].fields_by_name['last_activity_time']._loaded_options = None
+  _globals['_LEASE'].fields_by_name['last_activity_time']._serialized_options = b'\340A\003'
   _globals['_LEASE']._loaded_options = None
   _globals['_LEASE']._serialized_options = b'\352AM\n\025jumpstarter.dev/Lease\022%namespaces/{namespace}/leases/{lease}*\006leases2\005lease'
+  _globals['_LEASEAFFINITYTERM'].fields_by_name['leases']._loaded_options = None
+  _globals['_LEASEAFFINITYTERM'].fields_by_name['leases']._serialized_options = b'\340A\002\372A\027\n\025jumpstarter.dev/Lease'
+  _globals['_LEASEAFFINITYTERM'].fields_by_name['topology_key']._loaded_options = None
+  _globals['_LEASEAFFINITYTERM'].fields_by_name['topology_key']._serialized_options = b'\340A\002'
+  _globals['_LEASEMEMBER'].fields_by_name['name']._loaded_options = None
+  _globals['_LEASEMEMBER'].fields_by_name['name']._serialized_options = b'\340A\002'
+  _globals['_LEASEMEMBER'].fields_by_name['selector']._loaded_options = None
+  _globals['_LEASEMEMBER'].fields_by_name['selector']._serialized_options = b'\340A\002'
+  _globals['_LEASEMEMBER'].fields_by_name['count']._loaded_options = None
+  _globals['_LEASEMEMBER'].fields_by_name['count']._serialized_options = b'\340A\001'
+  _globals['_LEASEMEMBER'].fields_by_name['exporters']._loaded_options = None
+  _globals['_LEASEMEMBER'].fields_by_name['exporters']._serialized_options = b'\340A\003\372A\032\n\030jumpstarter.dev/Exporter'
+  _globals['_LEASEMEMBER'].fields_by_name['device_selectors']._loaded_options = None
+  _globals['_LEASEMEMBER'].fields_by_name['device_selectors']._serialized_options = b'\340A\001'
   _globals['_GETEXPORTERREQUEST'].fields_by_name['name']._loaded_options = None
   _globals['_GETEXPORTERREQUEST'].fields_by_name['name']._serialized_options = b'\340A\002\372A\032\n\030jumpstarter.dev/Exporter'
   _globals['_LISTEXPORTERSREQUEST'].fields_by_name['parent']._loaded_options = None
@@ -101,6 +135,26 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_UPDATELEASEREQUEST'].fields_by_name['update_mask']._serialized_options = b'\340A\001'
   _globals['_DELETELEASEREQUEST'].fields_by_name['name']._loaded_options = None
   _globals['_DELETELEASEREQUEST'].fields_by_name['name']._serialized_options = b'\340A\002\372A\027\n\025jumpstarter.dev/Lease'
+  _globals['_EVALUATELEASEREQUEST'].fields_by_name['parent']._loaded_options = None
+  _globals['_EVALUATELEASEREQUEST'].fields_by_name['parent']._serialized_options = b'\340A\002\372A\027\022\025jumpstarter.dev/Lease'
+  _globals['_EVALUATELEASEREQUEST'].fields_by_name['lease']._loaded_options = None
+  _globals['_EVALUATELEASEREQUEST'].fields_by_name['lease']._serialized_options = b'\340A\002'
+  _globals['_TRANSFERLEASEREQUEST'].fields_by_name['name']._loaded_options = None
+  _globals['_TRANSFERLEASEREQUEST'].fields_by_name['name']._serialized_options = b'\340A\002\372A\027\n\025jumpstarter.dev/Lease'
+  _globals['_TRANSFERLEASEREQUEST'].fields_by_name['client']._loaded_options = None
+  _globals['_TRANSFERLEASEREQUEST'].fields_by_name['client']._serialized_options = b'\340A\002\372A\030\n\026jumpstarter.dev/Client'
+  _globals['_EXPORTEREVALUATION'].fields_by_name['exporter']._loaded_options = None
+  _globals['_EXPORTEREVALUATION'].fields_by_name['exporter']._serialized_options = b'\372A\032\n\030jumpstarter.dev/Exporter'
+  _globals['_EXPORTEREVALUATION'].fields_by_name['lease']._loaded_options = None
+  _globals['_EXPORTEREVALUATION'].fields_by_name['lease']._serialized_options = b'\372A\027\n\025jumpstarter.dev/Lease'
+  _globals['_GETUSAGEREPORTREQUEST'].fields_by_name['parent']._loaded_options = None
+  _globals['_GETUSAGEREPORTREQUEST'].fields_by_name['parent']._serialized_options = b'\340A\002'
+  _globals['_GETUSAGEREPORTREQUEST'].fields_by_name['begin_time']._loaded_options = None
+  _globals['_GETUSAGEREPORTREQUEST'].fields_by_name['begin_time']._serialized_options = b'\340A\001'
+  _globals['_GETUSAGEREPORTREQUEST'].fields_by_name['end_time']._loaded_options = None
+  _globals['_GETUSAGEREPORTREQUEST'].fields_by_name['end_time']._serialized_options = b'\340A\001'
+  _globals['_GETUSAGEREPORTREQUEST'].fields_by_name['label_keys']._loaded_options = None
+  _globals['_GETUSAGEREPORTREQUEST'].fields_by_name['label_keys']._serialized_options = b'\340A\001'
   _globals['_CLIENTSERVICE'].methods_by_name['GetExporter']._loaded_options = None
   _globals['_CLIENTSERVICE'].methods_by_name['GetExporter']._serialized_options = b'\332A\004name\202\323\344\223\002%\022#/v1/{name=namespaces/*/exporters/*}'
   _globals['_CLIENTSERVICE'].methods_by_name['ListExporters']._loaded_options = None
@@ -115,30 +169,56 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_CLIENTSERVICE'].methods_by_name['UpdateLease']._serialized_options = b'\332A\021lease,update_mask\202\323\344\223\002/2&/v1/{lease.name=namespaces/*/leases/*}:\005lease'
   _globals['_CLIENTSERVICE'].methods_by_name['DeleteLease']._loaded_options = None
   _globals['_CLIENTSERVICE'].methods_by_name['DeleteLease']._serialized_options = b'\332A\004name\202\323\344\223\002\"* /v1/{name=namespaces/*/leases/*}'
+  _globals['_CLIENTSERVICE'].methods_by_name['EvaluateLease']._loaded_options = None
+  _globals['_CLIENTSERVICE'].methods_by_name['EvaluateLease']._serialized_options = b'\332A\014parent,lease\202\323\344\223\0022\")/v1/{parent=namespaces/*}/leases:evaluate:\005lease'
+  _globals['_CLIENTSERVICE'].methods_by_name['TransferLease']._loaded_options = None
+  _globals['_CLIENTSERVICE'].methods_by_name['TransferLease']._serialized_options = b'\332A\013name,client\202\323\344\223\002.\")/v1/{name=namespaces/*/leases/*}:transfer:\001*'
+  _globals['_CLIENTSERVICE'].methods_by_name['GetUsageReport']._loaded_options = None
+  _globals['_CLIENTSERVICE'].methods_by_name['GetUsageReport']._serialized_options = b'\332A\006parent\202\323\344\223\002!\022\037/v1/{parent=namespaces/*}/usage'
+  _globals['_EXPORTERLOSSACTION']._serialized_start=5378
+  _globals['_EXPORTERLOSSACTION']._serialized_end=5533
   _globals['_EXPORTER']._serialized_start=338
   _globals['_EXPORTER']._serialized_end=627
   _globals['_EXPORTER_LABELSENTRY']._serialized_start=473
   _globals['_EXPORTER_LABELSENTRY']._serialized_end=530
   _globals['_LEASE']._serialized_start=630
-  _globals['_LEASE']._serialized_end=1507
-  _globals['_GETEXPORTERREQUEST']._serialized_start=1509
-  _globals['_GETEXPORTERREQUEST']._serialized_end=1583
-  _globals['_LISTEXPORTERSREQUEST']._serialized_start=1586
-  _globals['_LISTEXPORTERSREQUEST']._serialized_end=1765
-  _globals['_LISTEXPORTERSRESPONSE']._serialized_start=1767
-  _globals['_LISTEXPORTERSRESPONSE']._serialized_end=1893
-  _globals['_GETLEASEREQUEST']._serialized_start=1895
-  _globals['_GETLEASEREQUEST']._serialized_end=1963
-  _globals['_LISTLEASESREQUEST']._serialized_start=1966
-  _globals['_LISTLEASESREQUEST']._serialized_end=2139
-  _globals['_LISTLEASESRESPONSE']._serialized_start=2141
-  _globals['_LISTLEASESRESPONSE']._serialized_end=2255
-  _globals['_CREATELEASEREQUEST']._serialized_start=2258
-  _globals['_CREATELEASEREQUEST']._serialized_end=2422
-  _globals['_UPDATELEASEREQUEST']._serialized_start=2425
-  _globals['_UPDATELEASEREQUEST']._serialized_end=2568
-  _globals['_DELETELEASEREQUEST']._serialized_start=2570
-  _globals['_DELETELEASEREQUEST']._serialized_end=2641
-  _globals['_CLIENTSERVICE']._serialized_start=2644
-  _globals['_CLIENTSERVICE']._serialized_end=3707
+  _globals['_LEASE']._serialized_end=2340
+  _globals['_LEASEAFFINITYTERM']._serialized_start=2342
+  _globals['_LEASEAFFINITYTERM']._serialized_end=2456
+  _globals['_LEASEMEMBER']._serialized_start=2459
+  _globals['_LEASEMEMBER']._serialized_end=2669
+  _globals['_GETEXPORTERREQUEST']._serialized_start=2671
+  _globals['_GETEXPORTERREQUEST']._serialized_end=2745
+  _globals['_LISTEXPORTERSREQUEST']._serialized_start=2748
+  _globals['_LISTEXPORTERSREQUEST']._serialized_end=2927
+  _globals['_LISTEXPORTERSRESPONSE']._serialized_start=2929
+  _globals['_LISTEXPORTERSRESPONSE']._serialized_end=3055
+  _globals['_GETLEASEREQUEST']._serialized_start=3057
+  _globals['_GETLEASEREQUEST']._serialized_end=3125
+  _globals['_LISTLEASESREQUEST']._serialized_start=3128
+  _globals['_LISTLEASESREQUEST']._serialized_end=3301
+  _globals['_LISTLEASESRESPONSE']._serialized_start=3303
+  _globals['_LISTLEASESRESPONSE']._serialized_end=3417
+  _globals['_CREATELEASEREQUEST']._serialized_start=3420
+  _globals['_CREATELEASEREQUEST']._serialized_end=3584
+  _globals['_UPDATELEASEREQUEST']._serialized_start=3587
+  _globals['_UPDATELEASEREQUEST']._serialized_end=3730
+  _globals['_DELETELEASEREQUEST']._serialized_start=3732
+  _globals['_DELETELEASEREQUEST']._serialized_end=3803
+  _globals['_EVALUATELEASEREQUEST']._serialized_start=3806
+  _globals['_EVALUATELEASEREQUEST']._serialized_end=3940
+  _globals['_TRANSFERLEASEREQUEST']._serialized_start=3943
+  _globals['_TRANSFERLEASEREQUEST']._serialized_end=4072
+  _globals['_LEASEEVALUATION']._serialized_start=4074
+  _globals['_LEASEEVALUATION']._serialized_end=4164
+  _globals['_EXPORTEREVALUATION']._serialized_start=4167
+  _globals['_EXPORTEREVALUATION']._serialized_end=4646
+  _globals['_GETUSAGEREPORTREQUEST']._serialized_start=4649
+  _globals['_GETUSAGEREPORTREQUEST']._serialized_end=4899
+  _globals['_USAGEREPORT']._serialized_start=4902
+  _globals['_USAGEREPORT']._serialized_end=5206
+  _globals['_USAGE']._serialized_start=5209
+  _globals['_USAGE']._serialized_end=5375
+  _globals['_CLIENTSERVICE']._serialized_start=5536
+  _globals['_CLIENTSERVICE']._serialized_end=7087
 # @@protoc_insertion_point(module_scope)
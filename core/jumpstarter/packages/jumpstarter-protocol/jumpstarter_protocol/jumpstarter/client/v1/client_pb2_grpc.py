@@ -50,6 +50,21 @@ class ClientServiceStub(object):
                 request_serializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.DeleteLeaseRequest.SerializeToString,
                 response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
                 _registered_method=True)
+        self.EvaluateLease = channel.unary_unary(
+                '/jumpstarter.client.v1.ClientService/EvaluateLease',
+                request_serializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.EvaluateLeaseRequest.SerializeToString,
+                response_deserializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.LeaseEvaluation.FromString,
+                _registered_method=True)
+        self.TransferLease = channel.unary_unary(
+                '/jumpstarter.client.v1.ClientService/TransferLease',
+                request_serializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.TransferLeaseRequest.SerializeToString,
+                response_deserializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.Lease.FromString,
+                _registered_method=True)
+        self.GetUsageReport = channel.unary_unary(
+                '/jumpstarter.client.v1.ClientService/GetUsageReport',
+                request_serializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.GetUsageReportRequest.SerializeToString,
+                response_deserializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.UsageReport.FromString,
+                _registered_method=True)
 
 
 class ClientServiceServicer(object):
@@ -97,6 +112,28 @@ class ClientServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def EvaluateLease(self, request, context):
+        """runs the exporter selection for a lease without creating it, explaining for each
+        exporter why it would, or would not, be assigned to the lease
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def TransferLease(self, request, context):
+        """hands an active lease over to another client of the namespace for the time left on it,
+        provided the policies matching the other client allow it to lease the exporters
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def GetUsageReport(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_ClientServiceServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -135,6 +172,21 @@ def add_ClientServiceServicer_to_server(servicer, server):
                     request_deserializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.DeleteLeaseRequest.FromString,
                     response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
             ),
+            'EvaluateLease': grpc.unary_unary_rpc_method_handler(
+                    servicer.EvaluateLease,
+                    request_deserializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.EvaluateLeaseRequest.FromString,
+                    response_serializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.LeaseEvaluation.SerializeToString,
+            ),
+            'TransferLease': grpc.unary_unary_rpc_method_handler(
+                    servicer.TransferLease,
+                    request_deserializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.TransferLeaseRequest.FromString,
+                    response_serializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.Lease.SerializeToString,
+            ),
+            'GetUsageReport': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetUsageReport,
+                    request_deserializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.GetUsageReportRequest.FromString,
+                    response_serializer=jumpstarter_dot_client_dot_v1_dot_client__pb2.UsageReport.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'jumpstarter.client.v1.ClientService', rpc_method_handlers)
@@ -334,3 +386,84 @@ class ClientService(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def EvaluateLease(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/jumpstarter.client.v1.ClientService/EvaluateLease',
+            jumpstarter_dot_client_dot_v1_dot_client__pb2.EvaluateLeaseRequest.SerializeToString,
+            jumpstarter_dot_client_dot_v1_dot_client__pb2.LeaseEvaluation.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def TransferLease(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/jumpstarter.client.v1.ClientService/TransferLease',
+            jumpstarter_dot_client_dot_v1_dot_client__pb2.TransferLeaseRequest.SerializeToString,
+            jumpstarter_dot_client_dot_v1_dot_client__pb2.Lease.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def GetUsageReport(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/jumpstarter.client.v1.ClientService/GetUsageReport',
+            jumpstarter_dot_client_dot_v1_dot_client__pb2.GetUsageReportRequest.SerializeToString,
+            jumpstarter_dot_client_dot_v1_dot_client__pb2.UsageReport.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
@@ -36,6 +36,11 @@ class ControllerServiceStub(object):
                 request_serializer=jumpstarter_dot_v1_dot_jumpstarter__pb2.StatusRequest.SerializeToString,
                 response_deserializer=jumpstarter_dot_v1_dot_jumpstarter__pb2.StatusResponse.FromString,
                 _registered_method=True)
+        self.ReportCleanup = channel.unary_unary(
+                '/jumpstarter.v1.ControllerService/ReportCleanup',
+                request_serializer=jumpstarter_dot_v1_dot_jumpstarter__pb2.ReportCleanupRequest.SerializeToString,
+                response_deserializer=jumpstarter_dot_v1_dot_jumpstarter__pb2.ReportCleanupResponse.FromString,
+                _registered_method=True)
         self.Dial = channel.unary_unary(
                 '/jumpstarter.v1.ControllerService/Dial',
                 request_serializer=jumpstarter_dot_v1_dot_jumpstarter__pb2.DialRequest.SerializeToString,
@@ -105,6 +110,14 @@ class ControllerServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def ReportCleanup(self, request, context):
+        """Exporter cleanup
+        Reports the outcome of the reset requested by the status stream once a lease ends
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
     def Dial(self, request, context):
         """Client connecting
         Returns stream token for connecting to the desired exporter
@@ -173,6 +186,11 @@ def add_ControllerServiceServicer_to_server(servicer, server):
                     request_deserializer=jumpstarter_dot_v1_dot_jumpstarter__pb2.StatusRequest.FromString,
                     response_serializer=jumpstarter_dot_v1_dot_jumpstarter__pb2.StatusResponse.SerializeToString,
             ),
+            'ReportCleanup': grpc.unary_unary_rpc_method_handler(
+                    servicer.ReportCleanup,
+                    request_deserializer=jumpstarter_dot_v1_dot_jumpstarter__pb2.ReportCleanupRequest.FromString,
+                    response_serializer=jumpstarter_dot_v1_dot_jumpstarter__pb2.ReportCleanupResponse.SerializeToString,
+            ),
             'Dial': grpc.unary_unary_rpc_method_handler(
                     servicer.Dial,
                     request_deserializer=jumpstarter_dot_v1_dot_jumpstarter__pb2.DialRequest.FromString,
@@ -323,6 +341,33 @@ class ControllerService(object):
             metadata,
             _registered_method=True)
 
+    @staticmethod
+    def ReportCleanup(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/jumpstarter.v1.ControllerService/ReportCleanup',
+            jumpstarter_dot_v1_dot_jumpstarter__pb2.ReportCleanupRequest.SerializeToString,
+            jumpstarter_dot_v1_dot_jumpstarter__pb2.ReportCleanupResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
     @staticmethod
     def Dial(request,
             target,
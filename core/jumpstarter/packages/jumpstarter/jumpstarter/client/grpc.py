@@ -79,6 +79,8 @@ class Lease(BaseModel):
     exporter: str
     conditions: list[kubernetes_pb2.Condition]
     effective_begin_time: datetime | None = None
+    queue_position: int | None = None
+    estimated_begin_time: datetime | None = None
 
     model_config = ConfigDict(
         arbitrary_types_allowed=True,
@@ -105,6 +107,16 @@ class Lease(BaseModel):
                 tzinfo=datetime.now().astimezone().tzinfo,
             )
 
+        queue_position = None
+        if data.HasField("queue_position"):
+            queue_position = data.queue_position
+
+        estimated_begin_time = None
+        if data.HasField("estimated_begin_time"):
+            estimated_begin_time = data.estimated_begin_time.ToDatetime(
+                tzinfo=datetime.now().astimezone().tzinfo,
+            )
+
         return cls(
             namespace=namespace,
             name=name,
@@ -113,6 +125,8 @@ class Lease(BaseModel):
             client=client,
             exporter=exporter,
             effective_begin_time=effective_begin_time,
+            queue_position=queue_position,
+            estimated_begin_time=estimated_begin_time,
             conditions=data.conditions,
         )
 
@@ -293,6 +307,141 @@ class LeaseList(BaseModel):
             lease.rich_add_names(names)
 
 
+class Usage(BaseModel):
+    kind: str
+    name: str
+    leases: int
+    leased_time: timedelta
+    utilization: float | None = None
+
+    @classmethod
+    def from_protobuf(cls, kind: str, data: client_pb2.Usage) -> Usage:
+        return cls(
+            kind=kind,
+            name=data.name,
+            leases=data.leases,
+            leased_time=data.leased_time.ToTimedelta(),
+            utilization=data.utilization if data.HasField("utilization") else None,
+        )
+
+    @classmethod
+    def rich_add_columns(cls, table):
+        table.add_column("KIND")
+        table.add_column("NAME")
+        table.add_column("LEASES")
+        table.add_column("LEASED TIME")
+        table.add_column("UTILIZATION")
+
+    def rich_add_rows(self, table):
+        table.add_row(
+            self.kind,
+            self.name,
+            str(self.leases),
+            str(self.leased_time),
+            "" if self.utilization is None else "{:.1%}".format(self.utilization),
+        )
+
+    def rich_add_names(self, names):
+        names.append(self.name)
+
+
+class UsageReport(BaseModel):
+    begin_time: datetime
+    end_time: datetime
+    usages: list[Usage]
+
+    @classmethod
+    def from_protobuf(cls, data: client_pb2.UsageReport) -> UsageReport:
+        return cls(
+            begin_time=data.begin_time.ToDatetime(),
+            end_time=data.end_time.ToDatetime(),
+            usages=[Usage.from_protobuf("exporter", usage) for usage in data.exporters]
+            + [Usage.from_protobuf("labels", usage) for usage in data.label_sets]
+            + [Usage.from_protobuf("client", usage) for usage in data.clients],
+        )
+
+    @classmethod
+    def rich_add_columns(cls, table):
+        Usage.rich_add_columns(table)
+
+    def rich_add_rows(self, table):
+        for usage in self.usages:
+            usage.rich_add_rows(table)
+
+    def rich_add_names(self, names):
+        for usage in self.usages:
+            usage.rich_add_names(names)
+
+
+class ExporterEvaluation(BaseModel):
+    exporter: str
+    member: str | None = None
+    included: bool
+    reason: str
+    message: str
+    access_policy: str | None = None
+    policy_index: int | None = None
+    lease: str | None = None
+
+    @classmethod
+    def from_protobuf(cls, data: client_pb2.ExporterEvaluation) -> ExporterEvaluation:
+        return cls(
+            exporter=parse_exporter_identifier(data.exporter)[1],
+            member=data.member if data.HasField("member") else None,
+            included=data.included,
+            reason=data.reason,
+            message=data.message,
+            access_policy=data.access_policy if data.HasField("access_policy") else None,
+            policy_index=data.policy_index if data.HasField("policy_index") else None,
+            lease=parse_lease_identifier(data.lease)[1] if data.HasField("lease") else None,
+        )
+
+    @classmethod
+    def rich_add_columns(cls, table):
+        table.add_column("EXPORTER")
+        table.add_column("MEMBER")
+        table.add_column("INCLUDED")
+        table.add_column("REASON")
+        table.add_column("POLICY")
+        table.add_column("MESSAGE")
+
+    def rich_add_rows(self, table):
+        policy = ""
+        if self.access_policy is not None:
+            policy = "{}[{}]".format(self.access_policy, self.policy_index)
+        table.add_row(
+            self.exporter,
+            self.member or "",
+            "yes" if self.included else "no",
+            self.reason,
+            policy,
+            self.message,
+        )
+
+    def rich_add_names(self, names):
+        names.append(self.exporter)
+
+
+class LeaseEvaluation(BaseModel):
+    exporters: list[ExporterEvaluation]
+
+    @classmethod
+    def from_protobuf(cls, data: client_pb2.LeaseEvaluation) -> LeaseEvaluation:
+        return cls(exporters=[ExporterEvaluation.from_protobuf(exporter) for exporter in data.exporters])
+
+    @classmethod
+    def rich_add_columns(cls, table):
+        ExporterEvaluation.rich_add_columns(table)
+
+    def rich_add_rows(self, table):
+        for exporter in self.exporters:
+            exporter.rich_add_rows(table)
+
+    def rich_add_names(self, names):
+        for exporter in self.exporters:
+            exporter.rich_add_names(names)
+
+
 @dataclass(kw_only=True, slots=True)
 class ClientService:
     channel: Channel
@@ -409,6 +558,57 @@ class ClientService:
                 )
             )
 
+    async def TransferLease(self, *, name: str, client: str):
+        with translate_grpc_exceptions():
+            lease = await self.stub.TransferLease(
+                client_pb2.TransferLeaseRequest(
+                    name="namespaces/{}/leases/{}".format(self.namespace, name),
+                    client="namespaces/{}/clients/{}".format(self.namespace, client),
+                )
+            )
+        return Lease.from_protobuf(lease)
+
+    async def EvaluateLease(
+        self,
+        *,
+        selector: str,
+        duration: timedelta,
+    ):
+        duration_pb = duration_pb2.Duration()
+        duration_pb.FromTimedelta(duration)
+
+        with translate_grpc_exceptions():
+            evaluation = await self.stub.EvaluateLease(
+                client_pb2.EvaluateLeaseRequest(
+                    parent="namespaces/{}".format(self.namespace),
+                    lease=client_pb2.Lease(
+                        duration=duration_pb,
+                        selector=selector,
+                    ),
+                )
+            )
+        return LeaseEvaluation.from_protobuf(evaluation)
+
+    async def GetUsageReport(
+        self,
+        *,
+        begin_time: datetime | None = None,
+        end_time: datetime | None = None,
+        label_keys: list[str] | None = None,
+    ):
+        request = client_pb2.GetUsageReportRequest(
+            parent="namespaces/{}".format(self.namespace),
+            label_keys=label_keys or [],
+        )
+        if begin_time is not None:
+            request.begin_time.FromDatetime(begin_time)
+        if end_time is not None:
+            request.end_time.FromDatetime(end_time)
+
+        with translate_grpc_exceptions():
+            report = await self.stub.GetUsageReport(request)
+        return UsageReport.from_protobuf(report)
+
 
 @dataclass(frozen=True, slots=True)
 class MultipathExporterStub:
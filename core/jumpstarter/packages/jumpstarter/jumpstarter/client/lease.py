@@ -102,6 +102,7 @@ class Lease(AbstractContextManager, AbstractAsyncContextManager):
 
         Makes sure the lease is ready, and returns the lease object.
         """
+        queue_position = None
         with fail_after(300):  # TODO: configurable timeout
             while True:
                 logger.debug("Polling Lease %s", self.name)
@@ -130,6 +131,20 @@ class Lease(AbstractContextManager, AbstractAsyncContextManager):
                 if condition_present_and_equal(result.conditions, "Ready", "False", "Released"):
                     raise LeaseError(f"lease {self.name} released")
 
+                # lease queued
+                if result.queue_position is not None and result.queue_position != queue_position:
+                    queue_position = result.queue_position
+                    if result.estimated_begin_time is not None:
+                        remain = max(result.estimated_begin_time - datetime.now().astimezone(), timedelta(0))
+                        logger.info(
+                            "Lease %s is #%d in the queue, expected in ~%s",
+                            self.name,
+                            queue_position,
+                            timedelta(seconds=round(remain.total_seconds())),
+                        )
+                    else:
+                        logger.info("Lease %s is #%d in the queue", self.name, queue_position)
+
                 await sleep(1)
 
     async def __aenter__(self):
@@ -166,8 +181,26 @@ class Lease(AbstractContextManager, AbstractAsyncContextManager):
     @asynccontextmanager
     async def monitor_async(self, threshold: timedelta = timedelta(minutes=5)):
         async def _monitor():
+            degraded = False
+            idle = False
             while True:
                 lease = await self.get()
+                # the exporter of the lease went offline, warn once until it is back
+                if condition_true(lease.conditions, "Degraded"):
+                    if not degraded:
+                        logger.warning(
+                            "Lease %s is degraded: %s", self.name, condition_message(lease.conditions, "Degraded")
+                        )
+                    degraded = True
+                else:
+                    degraded = False
+                # the lease is about to be released for being idle, warn once until it is used again
+                if condition_true(lease.conditions, "Idle"):
+                    if not idle:
+                        logger.warning("Lease %s is idle: %s", self.name, condition_message(lease.conditions, "Idle"))
+                    idle = True
+                else:
+                    idle = False
                 # TODO: use effective_end_time as the authoritative source for lease end time
                 if lease.effective_begin_time:
                     end_time = lease.effective_begin_time + lease.duration
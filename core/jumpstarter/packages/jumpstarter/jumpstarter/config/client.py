@@ -3,7 +3,7 @@ from __future__ import annotations
 import asyncio
 import os
 from contextlib import asynccontextmanager, contextmanager
-from datetime import timedelta
+from datetime import datetime, timedelta
 from functools import wraps
 from pathlib import Path
 from typing import Annotated, ClassVar, Literal, Optional, Self
@@ -220,6 +220,37 @@ class ClientConfigV1Alpha1(BaseSettings):
         svc = ClientService(channel=await self.channel(), namespace=self.metadata.namespace)
         return await svc.UpdateLease(name=name, duration=duration)
 
+    @_blocking_compat
+    @_handle_connection_error
+    async def transfer_lease(
+        self,
+        name: str,
+        client: str,
+    ):
+        svc = ClientService(channel=await self.channel(), namespace=self.metadata.namespace)
+        return await svc.TransferLease(name=name, client=client)
+
+    @_blocking_compat
+    @_handle_connection_error
+    async def evaluate_lease(
+        self,
+        selector: str,
+        duration: timedelta,
+    ):
+        svc = ClientService(channel=await self.channel(), namespace=self.metadata.namespace)
+        return await svc.EvaluateLease(selector=selector, duration=duration)
+
+    @_blocking_compat
+    @_handle_connection_error
+    async def get_usage_report(
+        self,
+        begin_time: datetime | None = None,
+        end_time: datetime | None = None,
+        label_keys: list[str] | None = None,
+    ):
+        svc = ClientService(channel=await self.channel(), namespace=self.metadata.namespace)
+        return await svc.GetUsageReport(begin_time=begin_time, end_time=end_time, label_keys=label_keys)
+
     @asynccontextmanager
     async def lease_async(
         self,
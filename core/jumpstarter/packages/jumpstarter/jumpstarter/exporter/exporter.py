@@ -25,6 +25,7 @@ class Exporter(AbstractAsyncContextManager, Metadata):
     channel_factory: Callable[[], grpc.aio.Channel]
     device_factory: Callable[[], Driver]
     lease_name: str = field(init=False, default="")
+    client_name: str = field(init=False, default="")
     tls: TLSConfigV1Alpha1 = field(default_factory=TLSConfigV1Alpha1)
     grpc_options: dict[str, str] = field(default_factory=dict)
     registered: bool = field(init=False, default=False)
@@ -105,11 +106,28 @@ class Exporter(AbstractAsyncContextManager, Metadata):
                     self.__handle, path, request.router_endpoint, request.router_token, self.tls, self.grpc_options
                 )
 
+    async def cleanup(self):
+        controller = jumpstarter_pb2_grpc.ControllerServiceStub(await self.channel_factory())
+        logger.info("Cleaning up after the previous lease")
+        try:
+            async with self.session() as path:
+                async with grpc.aio.secure_channel(
+                    f"unix://{path}", grpc.local_channel_credentials(grpc.LocalConnectionType.UDS)
+                ) as channel:
+                    await jumpstarter_pb2_grpc.ExporterServiceStub(channel).Reset(jumpstarter_pb2.ResetRequest())
+        except Exception as e:
+            logger.error("Failed to clean up after the previous lease: %s", e)
+            await controller.ReportCleanup(jumpstarter_pb2.ReportCleanupRequest(success=False, message=str(e)))
+        else:
+            logger.info("Cleaned up after the previous lease")
+            await controller.ReportCleanup(jumpstarter_pb2.ReportCleanupRequest(success=True))
+
     async def serve(self):  # noqa: C901
         # initial registration
         async with self.session():
             pass
         started = False
+        cleaning = False
         status_tx, status_rx = create_memory_object_stream()
 
         async def status(retries=5, backoff=3):
@@ -138,10 +156,21 @@ class Exporter(AbstractAsyncContextManager, Metadata):
             async for status in status_rx:
                 if self.lease_name != "" and self.lease_name != status.lease_name:
                     self.lease_name = status.lease_name
+                    if status.HasField("message"):
+                        logger.info("Previous lease ended: %s", status.message)
                     logger.info("Lease status changed, killing existing connections")
                     tg.cancel_scope.cancel()
                     break
+                if self.client_name != "" and status.leased and self.client_name != status.client_name:
+                    self.client_name = status.client_name
+                    logger.info("Lease transferred to %s, killing existing connections", status.client_name)
+                    tg.cancel_scope.cancel()
+                    break
                 self.lease_name = status.lease_name
+                self.client_name = status.client_name if status.leased else ""
+                if status.cleanup and not cleaning:
+                    tg.start_soon(self.cleanup)
+                cleaning = status.cleanup
                 if not started and self.lease_name != "":
                     started = True
                     tg.start_soon(self.handle, self.lease_name, tg)
@@ -11,7 +11,7 @@ from logging.handlers import QueueHandler
 from uuid import UUID
 
 import grpc
-from anyio import Event, TypedAttributeLookupError, sleep
+from anyio import Event, TypedAttributeLookupError, sleep, to_thread
 from anyio.from_thread import start_blocking_portal
 from jumpstarter_protocol import (
     jumpstarter_pb2,
@@ -125,6 +125,11 @@ class Session(
                     context.add_done_callback(lambda _: event.set())
                     await event.wait()
 
+    async def Reset(self, request, context):
+        logger.debug("Reset()")
+        await to_thread.run_sync(self.root_device.reset)
+        return jumpstarter_pb2.ResetResponse()
+
     async def LogStream(self, request, context):
         while True:
             try: